@@ -0,0 +1,136 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// AllowedOriginsAnnotation is the annotation set in target namespaces to opt in to receive releases from other
+	// namespaces. Its value is a comma separated list of origin namespaces, or '*' to allow any origin
+	AllowedOriginsAnnotation = "release.appstudio.openshift.io/allowed-origins"
+
+	// AuthorizationAnnotation is the annotation used to record the authorization decision made by the webhooks
+	AuthorizationAnnotation = "release.appstudio.openshift.io/authorization"
+)
+
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// authorize checks that the user making the admission request stored in the given context may create Releases in the
+// origin namespace and, if the target is a different namespace, that the target namespace allows releases coming from
+// the origin namespace. The given client is used to issue the SubjectAccessReview and to read the target namespace. If
+// the request is authorized, a message describing the decision is returned. Otherwise, an error is returned, which is
+// also the case if the admission request or the client are missing, so the check never fails open.
+func authorize(ctx context.Context, cli client.Client, origin, target string) (string, error) {
+	request, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to authorize the request: %w", err)
+	}
+	if cli == nil {
+		return "", fmt.Errorf("unable to authorize the request: no client to issue the SubjectAccessReview")
+	}
+
+	username := request.UserInfo.Username
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range request.UserInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	subjectAccessReview := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: origin,
+				Verb:      "create",
+				Group:     GroupVersion.Group,
+				Resource:  "releases",
+			},
+			User:   username,
+			Groups: request.UserInfo.Groups,
+			Extra:  extra,
+			UID:    request.UserInfo.UID,
+		},
+	}
+	err = cli.Create(ctx, subjectAccessReview)
+	if err != nil {
+		return "", err
+	}
+
+	if !subjectAccessReview.Status.Allowed {
+		return "", fmt.Errorf("user '%s' is not allowed to create Releases in namespace '%s'", username, origin)
+	}
+
+	if target == "" {
+		return fmt.Sprintf("user '%s' is allowed to create Releases in namespace '%s'", username, origin), nil
+	}
+
+	if target != origin {
+		namespace := &corev1.Namespace{}
+		err = cli.Get(ctx, types.NamespacedName{Name: target}, namespace)
+		if err != nil {
+			return "", err
+		}
+
+		if !allowsOrigin(namespace, origin) {
+			return "", fmt.Errorf("namespace '%s' doesn't allow releases from namespace '%s' (see the '%s' "+
+				"annotation)", target, origin, AllowedOriginsAnnotation)
+		}
+	}
+
+	return fmt.Sprintf("user '%s' is allowed to create Releases in namespace '%s' targeting namespace '%s'",
+		username, origin, target), nil
+}
+
+// allowsOrigin checks whether the given namespace opted in to receive releases from the given origin namespace.
+func allowsOrigin(namespace *corev1.Namespace, origin string) bool {
+	for _, allowedOrigin := range strings.Split(namespace.GetAnnotations()[AllowedOriginsAnnotation], ",") {
+		allowedOrigin = strings.TrimSpace(allowedOrigin)
+		if allowedOrigin == "*" || allowedOrigin == origin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordAuthorization sets the AuthorizationAnnotation in the given object to the result of authorizing the admission
+// request stored in the given context. As the object will be rejected by the validating webhooks if the request is not
+// authorized, a denied decision is only recorded to replace any value set by the user.
+func recordAuthorization(ctx context.Context, cli client.Client, object client.Object, origin, target string) {
+	decision, err := authorize(ctx, cli, origin, target)
+	if err != nil {
+		decision = fmt.Sprintf("denied: %s", err.Error())
+	} else {
+		decision = fmt.Sprintf("allowed: %s", decision)
+	}
+
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AuthorizationAnnotation] = decision
+	object.SetAnnotations(annotations)
+}
@@ -0,0 +1,56 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("Authorization", func() {
+
+	When("authorize is called", func() {
+		It("should deny the request if the admission request is missing", func() {
+			_, err := authorize(context.Background(), k8sClient, "default", "")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should deny the request if the client is missing", func() {
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UserInfo: authenticationv1.UserInfo{Username: "user"},
+				},
+			})
+			_, err := authorize(ctx, nil, "default", "")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("recordAuthorization is called", func() {
+		It("should record a denied decision if the request can't be authorized", func() {
+			release := &Release{}
+			recordAuthorization(context.Background(), k8sClient, release, "default", "")
+			Expect(release.GetAnnotations()[AuthorizationAnnotation]).To(HavePrefix("denied:"))
+		})
+	})
+
+})
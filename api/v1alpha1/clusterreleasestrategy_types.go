@@ -0,0 +1,73 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterReleaseStrategyKind is the kind used by ReleasePlanAdmissions to reference a ClusterReleaseStrategy
+	ClusterReleaseStrategyKind = "ClusterReleaseStrategy"
+
+	// ReleaseStrategyKind is the kind used by ReleasePlanAdmissions to reference a ReleaseStrategy
+	ReleaseStrategyKind = "ReleaseStrategy"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Pipeline",type=string,JSONPath=`.spec.pipeline`
+//+kubebuilder:printcolumn:name="Resolver",type=string,JSONPath=`.spec.pipelineRef.resolver`
+
+// ClusterReleaseStrategy is the Schema for the clusterreleasestrategies API. It defines a release strategy that can
+// be shared across managed namespaces, either by referencing it directly from a ReleasePlanAdmission or by
+// inheriting from it in a ReleaseStrategy.
+type ClusterReleaseStrategy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ReleaseStrategySpec `json:"spec,omitempty"`
+}
+
+// AsReleaseStrategy returns the ReleaseStrategy resulting from using the ClusterReleaseStrategy in the given
+// namespace. ConfigMaps, Secrets and policies referenced by the ClusterReleaseStrategy will be looked up in that
+// namespace.
+func (crs *ClusterReleaseStrategy) AsReleaseStrategy(namespace string) *ReleaseStrategy {
+	releaseStrategy := &ReleaseStrategy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      crs.Name,
+			Namespace: namespace,
+		},
+		Spec: *crs.Spec.DeepCopy(),
+	}
+	releaseStrategy.Spec.ClusterReleaseStrategy = crs.Name
+
+	return releaseStrategy
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterReleaseStrategyList contains a list of ClusterReleaseStrategy
+type ClusterReleaseStrategyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterReleaseStrategy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterReleaseStrategy{}, &ClusterReleaseStrategyList{})
+}
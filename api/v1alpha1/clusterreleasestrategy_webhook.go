@@ -0,0 +1,127 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func (crs *ClusterReleaseStrategy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	w := &clusterReleaseStrategyWebhook{client: mgr.GetClient()}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(crs).
+		WithValidator(w).
+		Complete()
+}
+
+// clusterReleaseStrategyWebhook wraps the ClusterReleaseStrategy Validator implementation and rejects the deletion of
+// ClusterReleaseStrategies still referenced by ReleasePlanAdmissions.
+type clusterReleaseStrategyWebhook struct {
+	// client is used to read the ReleasePlanAdmissions and ReleaseStrategies using the ClusterReleaseStrategy
+	client client.Reader
+}
+
+var _ admission.CustomValidator = &clusterReleaseStrategyWebhook{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (w *clusterReleaseStrategyWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return obj.(*ClusterReleaseStrategy).ValidateCreate()
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (w *clusterReleaseStrategyWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return newObj.(*ClusterReleaseStrategy).ValidateUpdate(oldObj)
+}
+
+// ValidateDelete implements admission.CustomValidator. The deletion will be rejected if any ReleasePlanAdmission
+// still references the ClusterReleaseStrategy, either directly or through a ReleaseStrategy inheriting from it.
+func (w *clusterReleaseStrategyWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	crs := obj.(*ClusterReleaseStrategy)
+	if w.client == nil {
+		return nil
+	}
+
+	releaseStrategies := &ReleaseStrategyList{}
+	err := w.client.List(ctx, releaseStrategies)
+	if err != nil {
+		return err
+	}
+
+	inheritingReleaseStrategies := map[string]bool{}
+	for _, releaseStrategy := range releaseStrategies.Items {
+		if releaseStrategy.Spec.ClusterReleaseStrategy == crs.Name {
+			inheritingReleaseStrategies[releaseStrategy.Namespace+"/"+releaseStrategy.Name] = true
+		}
+	}
+
+	releasePlanAdmissions := &ReleasePlanAdmissionList{}
+	err = w.client.List(ctx, releasePlanAdmissions)
+	if err != nil {
+		return err
+	}
+
+	var referencingReleasePlanAdmissions []ReleasePlanAdmission
+	for _, releasePlanAdmission := range releasePlanAdmissions.Items {
+		if releasePlanAdmission.Spec.ReleaseStrategyKind == ClusterReleaseStrategyKind {
+			if releasePlanAdmission.Spec.ReleaseStrategy == crs.Name {
+				referencingReleasePlanAdmissions = append(referencingReleasePlanAdmissions, releasePlanAdmission)
+			}
+		} else if inheritingReleaseStrategies[releasePlanAdmission.Namespace+"/"+releasePlanAdmission.Spec.ReleaseStrategy] {
+			referencingReleasePlanAdmissions = append(referencingReleasePlanAdmissions, releasePlanAdmission)
+		}
+	}
+
+	return NewReferencedError(ClusterReleaseStrategyKind, crs.Name, referencingReleasePlanAdmissions)
+}
+
+//+kubebuilder:webhook:path=/validate-appstudio-redhat-com-v1alpha1-clusterreleasestrategy,mutating=false,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=clusterreleasestrategies,verbs=create;update;delete,versions=v1alpha1,name=vclusterreleasestrategy.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ClusterReleaseStrategy{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (crs *ClusterReleaseStrategy) ValidateCreate() error {
+	return crs.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (crs *ClusterReleaseStrategy) ValidateUpdate(old runtime.Object) error {
+	return crs.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type. The ReleasePlanAdmissions
+// using the ClusterReleaseStrategy are checked by the clusterReleaseStrategyWebhook, as they have to be read from the
+// cluster.
+func (crs *ClusterReleaseStrategy) ValidateDelete() error {
+	return nil
+}
+
+// validate throws an error if the ClusterReleaseStrategy inherits from another one or its spec is not valid.
+func (crs *ClusterReleaseStrategy) validate() error {
+	if crs.Spec.ClusterReleaseStrategy != "" {
+		return fmt.Errorf("a ClusterReleaseStrategy cannot inherit from another ClusterReleaseStrategy")
+	}
+
+	return crs.Spec.validate(false)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ClusterReleaseStrategy webhook", func() {
+	var clusterReleaseStrategy *ClusterReleaseStrategy
+
+	BeforeEach(func() {
+		clusterReleaseStrategy = &ClusterReleaseStrategy{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "appstudio.redhat.com/v1alpha1",
+				Kind:       "ClusterReleaseStrategy",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "clusterreleasestrategy",
+			},
+			Spec: ReleaseStrategySpec{
+				Pipeline: "pipeline",
+				Policy:   "policy",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		err := k8sClient.Delete(ctx, clusterReleaseStrategy)
+		Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	Context("When a ClusterReleaseStrategy is created with a pipeline and a policy", func() {
+		It("should be accepted", func() {
+			Expect(k8sClient.Create(ctx, clusterReleaseStrategy)).Should(Succeed())
+		})
+	})
+
+	Context("When a ClusterReleaseStrategy is created without referencing a pipeline", func() {
+		It("should get rejected", func() {
+			clusterReleaseStrategy.Spec.Pipeline = ""
+			err := k8sClient.Create(ctx, clusterReleaseStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("either pipeline or pipelineRef has to be set"))
+		})
+	})
+
+	Context("When a ClusterReleaseStrategy is created without a policy", func() {
+		It("should get rejected", func() {
+			clusterReleaseStrategy.Spec.Policy = ""
+			err := k8sClient.Create(ctx, clusterReleaseStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("policy has to be set"))
+		})
+	})
+
+	Context("When a ClusterReleaseStrategy is created inheriting from another one", func() {
+		It("should get rejected", func() {
+			clusterReleaseStrategy.Spec.ClusterReleaseStrategy = "foo"
+			err := k8sClient.Create(ctx, clusterReleaseStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot inherit from another ClusterReleaseStrategy"))
+		})
+	})
+
+	Context("When AsReleaseStrategy method is called", func() {
+		It("should return a ReleaseStrategy in the given namespace with the same spec", func() {
+			releaseStrategy := clusterReleaseStrategy.AsReleaseStrategy("default")
+			Expect(releaseStrategy.Name).To(Equal(clusterReleaseStrategy.Name))
+			Expect(releaseStrategy.Namespace).To(Equal("default"))
+			Expect(releaseStrategy.Spec.Pipeline).To(Equal(clusterReleaseStrategy.Spec.Pipeline))
+			Expect(releaseStrategy.Spec.ClusterReleaseStrategy).To(Equal(clusterReleaseStrategy.Name))
+		})
+	})
+
+	Context("When a ClusterReleaseStrategy used by a ReleasePlanAdmission is deleted", func() {
+		It("should get rejected if a ReleasePlanAdmission references it", func() {
+			Expect(k8sClient.Create(ctx, clusterReleaseStrategy)).Should(Succeed())
+
+			releasePlanAdmission := &ReleasePlanAdmission{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "clusterreleasestrategy-deletion",
+					Namespace: "default",
+				},
+				Spec: ReleasePlanAdmissionSpec{
+					Application:         "clusterreleasestrategy-deletion",
+					Origin:              "default",
+					Environment:         "environment",
+					ReleaseStrategy:     clusterReleaseStrategy.Name,
+					ReleaseStrategyKind: ClusterReleaseStrategyKind,
+				},
+			}
+			Expect(k8sClient.Create(ctx, releasePlanAdmission)).Should(Succeed())
+			defer k8sClient.Delete(ctx, releasePlanAdmission)
+
+			Eventually(func() error {
+				return k8sClient.Delete(ctx, clusterReleaseStrategy)
+			}).Should(MatchError(ContainSubstring("ClusterReleaseStrategy 'clusterreleasestrategy' cannot be " +
+				"deleted as it's referenced by the ReleasePlanAdmissions: default/clusterreleasestrategy-deletion")))
+		})
+
+		It("should get rejected if a ReleasePlanAdmission references a ReleaseStrategy inheriting from it", func() {
+			Expect(k8sClient.Create(ctx, clusterReleaseStrategy)).Should(Succeed())
+
+			releaseStrategy := &ReleaseStrategy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "inheriting-releasestrategy",
+					Namespace: "default",
+				},
+				Spec: ReleaseStrategySpec{
+					ClusterReleaseStrategy: clusterReleaseStrategy.Name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, releaseStrategy)).Should(Succeed())
+
+			releasePlanAdmission := &ReleasePlanAdmission{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "inheriting-releasestrategy-deletion",
+					Namespace: "default",
+				},
+				Spec: ReleasePlanAdmissionSpec{
+					Application:     "inheriting-releasestrategy-deletion",
+					Origin:          "default",
+					Environment:     "environment",
+					ReleaseStrategy: releaseStrategy.Name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, releasePlanAdmission)).Should(Succeed())
+			defer func() {
+				_ = k8sClient.Delete(ctx, releasePlanAdmission)
+				Eventually(func() error {
+					return k8sClient.Delete(ctx, releaseStrategy)
+				}).Should(Succeed())
+			}()
+
+			Eventually(func() error {
+				return k8sClient.Delete(ctx, clusterReleaseStrategy)
+			}).Should(MatchError(ContainSubstring("referenced by the ReleasePlanAdmissions: " +
+				"default/inheriting-releasestrategy-deletion")))
+		})
+	})
+
+	Describe("When ValidateDelete method is called", func() {
+		It("should return nil if no ReleasePlanAdmission references the ClusterReleaseStrategy", func() {
+			clusterReleaseStrategy := &ClusterReleaseStrategy{}
+			Expect(clusterReleaseStrategy.ValidateDelete()).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,296 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConversionDataAnnotation is the annotation used to keep the v1alpha2 status fields that have no v1alpha1
+// equivalent, so they survive v1alpha1 clients updating a Release.
+const ConversionDataAnnotation = "release.appstudio.openshift.io/conversion-data"
+
+// conversionData contains the v1alpha2 status fields stored in the ConversionDataAnnotation.
+type conversionData struct {
+	Snapshot             string                                 `json:"snapshot,omitempty"`
+	ReleasePlanAdmission *v1alpha2.ObjectReference              `json:"releasePlanAdmission,omitempty"`
+	Deployment           *v1alpha2.DeploymentStatus             `json:"deployment,omitempty"`
+	Environments         []v1alpha2.EnvironmentDeploymentStatus `json:"environments,omitempty"`
+	Verification         *v1alpha2.VerificationStatus           `json:"verification,omitempty"`
+}
+
+// ConvertTo converts this Release to the Hub version (v1alpha2). The status fields that only exist in v1alpha2 are
+// restored from the ConversionDataAnnotation, which is removed from the converted Release.
+func (r *Release) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1alpha2.Release)
+
+	dst.ObjectMeta = *r.ObjectMeta.DeepCopy()
+	value, found := dst.Annotations[ConversionDataAnnotation]
+	if found {
+		delete(dst.Annotations, ConversionDataAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	dst.Spec = v1alpha2.ReleaseSpec{
+		Snapshot:            r.Spec.Snapshot,
+		ReleasePlan:         r.Spec.ReleasePlan,
+		Params:              convertSlice(r.Spec.Params, convertParamsTo),
+		RollbackTo:          r.Spec.RollbackTo,
+		SkipReleasePipeline: r.Spec.SkipReleasePipeline,
+	}
+	dst.Status = v1alpha2.ReleaseStatus{
+		StartTime:                  r.Status.StartTime,
+		CompletionTime:             r.Status.CompletionTime,
+		DeploymentStartTime:        r.Status.DeploymentStartTime,
+		DeploymentCompletionTime:   r.Status.DeploymentCompletionTime,
+		Conditions:                 r.Status.Conditions,
+		ObservedGeneration:         r.Status.ObservedGeneration,
+		Phase:                      v1alpha2.ReleasePhase(r.Status.Phase),
+		SnapshotEnvironmentBinding: stringToReference(r.Status.SnapshotEnvironmentBinding),
+		ReleasePipelineRun:         stringToReference(r.Status.ReleasePipelineRun),
+		ReleaseStrategy:            stringToReference(r.Status.ReleaseStrategy),
+		EffectiveReleaseStrategy:   r.Status.EffectiveReleaseStrategy.ConvertToV1alpha2(),
+		Target:                     r.Status.Target,
+	}
+	if r.Status.Requester != nil {
+		requester := v1alpha2.Requester(*r.Status.Requester)
+		dst.Status.Requester = &requester
+	}
+
+	if found {
+		data := &conversionData{}
+		if err := json.Unmarshal([]byte(value), data); err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", ConversionDataAnnotation, err)
+		}
+
+		dst.Status.Snapshot = data.Snapshot
+		dst.Status.ReleasePlanAdmission = data.ReleasePlanAdmission
+		dst.Status.Deployment = data.Deployment
+		dst.Status.Environments = data.Environments
+		dst.Status.Verification = data.Verification
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha2) to this version. The status fields that only exist in v1alpha2
+// are stored in the ConversionDataAnnotation.
+func (r *Release) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1alpha2.Release)
+
+	r.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	r.Spec = ReleaseSpec{
+		Snapshot:            src.Spec.Snapshot,
+		ReleasePlan:         src.Spec.ReleasePlan,
+		Params:              ConvertParamsFromV1alpha2(src.Spec.Params),
+		RollbackTo:          src.Spec.RollbackTo,
+		SkipReleasePipeline: src.Spec.SkipReleasePipeline,
+	}
+	r.Status = ReleaseStatus{
+		StartTime:                  src.Status.StartTime,
+		CompletionTime:             src.Status.CompletionTime,
+		DeploymentStartTime:        src.Status.DeploymentStartTime,
+		DeploymentCompletionTime:   src.Status.DeploymentCompletionTime,
+		Conditions:                 src.Status.Conditions,
+		ObservedGeneration:         src.Status.ObservedGeneration,
+		Phase:                      ReleasePhase(src.Status.Phase),
+		SnapshotEnvironmentBinding: referenceToString(src.Status.SnapshotEnvironmentBinding),
+		ReleasePipelineRun:         referenceToString(src.Status.ReleasePipelineRun),
+		ReleaseStrategy:            referenceToString(src.Status.ReleaseStrategy),
+		Target:                     src.Status.Target,
+	}
+	if src.Status.EffectiveReleaseStrategy != nil {
+		r.Status.EffectiveReleaseStrategy = convertReleaseStrategySpecFrom(src.Status.EffectiveReleaseStrategy)
+	}
+	if src.Status.Requester != nil {
+		requester := Requester(*src.Status.Requester)
+		r.Status.Requester = &requester
+	}
+
+	data := conversionData{
+		Snapshot:             src.Status.Snapshot,
+		ReleasePlanAdmission: src.Status.ReleasePlanAdmission,
+		Deployment:           src.Status.Deployment,
+		Environments:         src.Status.Environments,
+		Verification:         src.Status.Verification,
+	}
+	if !reflect.DeepEqual(data, conversionData{}) {
+		value, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+
+		if r.Annotations == nil {
+			r.Annotations = map[string]string{}
+		}
+		r.Annotations[ConversionDataAnnotation] = string(value)
+	}
+
+	return nil
+}
+
+// ConvertToV1alpha2 returns the v1alpha2 representation of the ReleaseStrategySpec, which is the one Releases use to
+// record the strategy they were processed with. Nil is returned if the ReleaseStrategySpec is nil.
+func (rss *ReleaseStrategySpec) ConvertToV1alpha2() *v1alpha2.ReleaseStrategySpec {
+	if rss == nil {
+		return nil
+	}
+
+	spec := &v1alpha2.ReleaseStrategySpec{
+		ClusterReleaseStrategy: rss.ClusterReleaseStrategy,
+		Pipeline:               rss.Pipeline,
+		Bundle:                 rss.Bundle,
+		Params:                 convertSlice(rss.Params, convertParamsTo),
+		Policy:                 rss.Policy,
+		PersistentVolumeClaim:  rss.PersistentVolumeClaim,
+		Workspaces: convertSlice(rss.Workspaces, func(workspace Workspace) v1alpha2.Workspace {
+			return v1alpha2.Workspace(workspace)
+		}),
+		ServiceAccount: rss.ServiceAccount,
+		TaskRunSpecs: convertSlice(rss.TaskRunSpecs, func(taskRunSpec TaskRunSpec) v1alpha2.TaskRunSpec {
+			return v1alpha2.TaskRunSpec(taskRunSpec)
+		}),
+	}
+	if rss.PipelineRef != nil {
+		spec.PipelineRef = &v1alpha2.PipelineRef{
+			Resolver: rss.PipelineRef.Resolver,
+			Params: convertSlice(rss.PipelineRef.Params, func(param ResolverParam) v1alpha2.ResolverParam {
+				return v1alpha2.ResolverParam(param)
+			}),
+		}
+	}
+	if rss.PodTemplate != nil {
+		podTemplate := v1alpha2.PodTemplate(*rss.PodTemplate)
+		spec.PodTemplate = &podTemplate
+	}
+
+	return spec
+}
+
+// ConvertParamsFromV1alpha2 returns the v1alpha1 representation of the given v1alpha2 params.
+func ConvertParamsFromV1alpha2(params []v1alpha2.Params) []Params {
+	return convertSlice(params, func(param v1alpha2.Params) Params {
+		converted := Params{
+			Name:   param.Name,
+			Value:  param.Value,
+			Values: param.Values,
+		}
+		if param.ValueFrom != nil {
+			valueFrom := ParamValueSource(*param.ValueFrom)
+			converted.ValueFrom = &valueFrom
+		}
+
+		return converted
+	})
+}
+
+// convertParamsTo returns the v1alpha2 representation of the given param.
+func convertParamsTo(param Params) v1alpha2.Params {
+	converted := v1alpha2.Params{
+		Name:   param.Name,
+		Value:  param.Value,
+		Values: param.Values,
+	}
+	if param.ValueFrom != nil {
+		valueFrom := v1alpha2.ParamValueSource(*param.ValueFrom)
+		converted.ValueFrom = &valueFrom
+	}
+
+	return converted
+}
+
+// convertReleaseStrategySpecFrom returns the v1alpha1 representation of the given v1alpha2 ReleaseStrategySpec.
+func convertReleaseStrategySpecFrom(src *v1alpha2.ReleaseStrategySpec) *ReleaseStrategySpec {
+	spec := &ReleaseStrategySpec{
+		ClusterReleaseStrategy: src.ClusterReleaseStrategy,
+		Pipeline:               src.Pipeline,
+		Bundle:                 src.Bundle,
+		Params:                 ConvertParamsFromV1alpha2(src.Params),
+		Policy:                 src.Policy,
+		PersistentVolumeClaim:  src.PersistentVolumeClaim,
+		Workspaces: convertSlice(src.Workspaces, func(workspace v1alpha2.Workspace) Workspace {
+			return Workspace(workspace)
+		}),
+		ServiceAccount: src.ServiceAccount,
+		TaskRunSpecs: convertSlice(src.TaskRunSpecs, func(taskRunSpec v1alpha2.TaskRunSpec) TaskRunSpec {
+			return TaskRunSpec(taskRunSpec)
+		}),
+	}
+	if src.PipelineRef != nil {
+		spec.PipelineRef = &PipelineRef{
+			Resolver: src.PipelineRef.Resolver,
+			Params: convertSlice(src.PipelineRef.Params, func(param v1alpha2.ResolverParam) ResolverParam {
+				return ResolverParam(param)
+			}),
+		}
+	}
+	if src.PodTemplate != nil {
+		podTemplate := PodTemplate(*src.PodTemplate)
+		spec.PodTemplate = &podTemplate
+	}
+
+	return spec
+}
+
+// convertSlice returns a slice with the result of calling the given function on each of the elements of the source
+// slice. Nil is returned if the source slice is nil.
+func convertSlice[S, D any](src []S, convert func(S) D) []D {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]D, len(src))
+	for i := range src {
+		dst[i] = convert(src[i])
+	}
+
+	return dst
+}
+
+// referenceToString returns the v1alpha1 representation of the given reference, which is its namespace and name
+// separated by a slash. An empty string is returned if the reference is nil.
+func referenceToString(reference *v1alpha2.ObjectReference) string {
+	if reference == nil {
+		return ""
+	}
+
+	return reference.String()
+}
+
+// stringToReference parses the v1alpha1 representation of a reference. A string without a slash is considered to
+// reference a cluster-scoped object. Nil is returned if the string is empty.
+func stringToReference(reference string) *v1alpha2.ObjectReference {
+	if reference == "" {
+		return nil
+	}
+
+	parts := strings.SplitN(reference, string(types.Separator), 2)
+	if len(parts) == 1 {
+		return v1alpha2.NewObjectReference("", parts[0])
+	}
+
+	return v1alpha2.NewObjectReference(parts[0], parts[1])
+}
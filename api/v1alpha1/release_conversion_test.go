@@ -0,0 +1,159 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"math/rand"
+	"strings"
+
+	fuzz "github.com/google/gofuzz"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Release conversion", func() {
+
+	var fuzzer *fuzz.Fuzzer
+
+	BeforeEach(func() {
+		fuzzer = fuzz.New().NilChance(0.2).NumElements(0, 3).Funcs(
+			func(status *ReleaseStatus, c fuzz.Continue) {
+				c.FuzzNoCustom(status)
+				status.SnapshotEnvironmentBinding = randomReference(c)
+				status.ReleasePipelineRun = randomReference(c)
+				status.ReleaseStrategy = randomReference(c)
+			},
+		)
+	})
+
+	It("should not lose data in a spoke-hub-spoke round trip", func() {
+		for i := 0; i < 100; i++ {
+			spoke := &Release{}
+			fuzzer.Fuzz(spoke)
+			spoke.TypeMeta = metav1.TypeMeta{}
+
+			hub := &v1alpha2.Release{}
+			Expect(spoke.ConvertTo(hub)).To(Succeed())
+			convertedSpoke := &Release{}
+			Expect(convertedSpoke.ConvertFrom(hub)).To(Succeed())
+
+			Expect(convertedSpoke).To(Equal(spoke))
+		}
+	})
+
+	It("should not lose data in a hub-spoke-hub round trip", func() {
+		hubFuzzer := fuzz.New().NilChance(0.2).NumElements(1, 3).Funcs(
+			func(reference *v1alpha2.ObjectReference, c fuzz.Continue) {
+				reference.Name = randomName(c.Rand)
+				if c.RandBool() {
+					reference.Namespace = randomName(c.Rand)
+				}
+			},
+			func(component *applicationapiv1alpha1.BindingComponent, c fuzz.Continue) {
+				component.Name = randomName(c.Rand)
+				component.Configuration.Replicas = c.Intn(5)
+			},
+			// Times are serialized to the annotation with a precision of seconds
+			func(t *metav1.Time, c fuzz.Continue) {
+				*t = metav1.Unix(1+c.Int63n(1e9), 0)
+			},
+		)
+
+		for i := 0; i < 100; i++ {
+			hub := &v1alpha2.Release{}
+			hubFuzzer.Fuzz(hub)
+			hub.TypeMeta = metav1.TypeMeta{}
+
+			spoke := &Release{}
+			Expect(spoke.ConvertFrom(hub)).To(Succeed())
+			convertedHub := &v1alpha2.Release{}
+			Expect(spoke.ConvertTo(convertedHub)).To(Succeed())
+
+			Expect(convertedHub).To(Equal(hub))
+		}
+	})
+
+	It("should fail to convert a Release with an invalid conversion data annotation", func() {
+		spoke := &Release{}
+		spoke.Annotations = map[string]string{ConversionDataAnnotation: "{"}
+		Expect(spoke.ConvertTo(&v1alpha2.Release{})).NotTo(Succeed())
+	})
+
+	It("should convert the references to and from the namespace/name format", func() {
+		hub := &v1alpha2.Release{
+			Status: v1alpha2.ReleaseStatus{
+				ReleasePipelineRun: v1alpha2.NewObjectReference("default", "pipeline-run"),
+				ReleaseStrategy:    v1alpha2.NewObjectReference("", "cluster-strategy"),
+			},
+		}
+
+		spoke := &Release{}
+		Expect(spoke.ConvertFrom(hub)).To(Succeed())
+		Expect(spoke.Status.ReleasePipelineRun).To(Equal("default/pipeline-run"))
+		Expect(spoke.Status.ReleaseStrategy).To(Equal("cluster-strategy"))
+		Expect(spoke.Status.SnapshotEnvironmentBinding).To(BeEmpty())
+
+		convertedHub := &v1alpha2.Release{}
+		Expect(spoke.ConvertTo(convertedHub)).To(Succeed())
+		Expect(convertedHub.Status.ReleasePipelineRun).To(Equal(hub.Status.ReleasePipelineRun))
+		Expect(convertedHub.Status.ReleaseStrategy).To(Equal(hub.Status.ReleaseStrategy))
+	})
+
+	It("should convert the spec of the effective ReleaseStrategy", func() {
+		spec := &ReleaseStrategySpec{
+			Pipeline:    "release",
+			PipelineRef: &PipelineRef{Resolver: "bundles", Params: []ResolverParam{{Name: "name", Value: "release"}}},
+			Params:      []Params{{Name: "foo", Value: "bar"}},
+			PodTemplate: &PodTemplate{PriorityClassName: "high"},
+		}
+
+		converted := spec.ConvertToV1alpha2()
+		Expect(converted.Pipeline).To(Equal("release"))
+		Expect(converted.PipelineRef.Params).To(Equal([]v1alpha2.ResolverParam{{Name: "name", Value: "release"}}))
+		Expect(converted.Params).To(Equal([]v1alpha2.Params{{Name: "foo", Value: "bar"}}))
+		Expect(converted.PodTemplate.PriorityClassName).To(Equal("high"))
+		Expect((*ReleaseStrategySpec)(nil).ConvertToV1alpha2()).To(BeNil())
+	})
+})
+
+// randomName returns a random lowercase alphanumeric name.
+func randomName(r *rand.Rand) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	var name strings.Builder
+	for i := 0; i < 1+r.Intn(10); i++ {
+		name.WriteByte(letters[r.Intn(len(letters))])
+	}
+
+	return name.String()
+}
+
+// randomReference returns an empty string or a reference in the namespace/name or name formats.
+func randomReference(c fuzz.Continue) string {
+	switch c.Intn(3) {
+	case 0:
+		return ""
+	case 1:
+		return randomName(c.Rand)
+	default:
+		return randomName(c.Rand) + "/" + randomName(c.Rand)
+	}
+}
@@ -17,62 +17,191 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"time"
+	"strings"
 
-	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
-	"github.com/redhat-appstudio/release-service/metrics"
-	"k8s.io/apimachinery/pkg/api/meta"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ReleaseSpec defines the desired state of Release.
 type ReleaseSpec struct {
-	// Snapshot to be released
+	// Snapshot to be released. It can't be set along with RollbackTo, as rollbacks release the Snapshot of the Release
+	// they roll back to
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
-	// +required
-	Snapshot string `json:"snapshot"`
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
 
 	// ReleasePlan to use for this particular Release
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +required
 	ReleasePlan string `json:"releasePlan"`
+
+	// Params to pass to the release Pipeline. They override the ones set in the ReleasePlan and need to be allowed by
+	// the ReleasePlanAdmission in the target
+	// +optional
+	Params []Params `json:"params,omitempty"`
+
+	// RollbackTo is the name of a previous Release in the same namespace to roll back to. That Release has to use the
+	// same ReleasePlan and its deployment has to have succeeded
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	RollbackTo string `json:"rollbackTo,omitempty"`
+
+	// SkipReleasePipeline skips the release Pipeline of a rollback, so the Snapshot is deployed again right away. It
+	// can only be set along with RollbackTo
+	// +optional
+	SkipReleasePipeline bool `json:"skipReleasePipeline,omitempty"`
 }
 
-// ReleaseReason represents a reason for the release "Succeeded" condition.
+// ReleaseReason represents a reason for the release status conditions.
 type ReleaseReason string
 
 const (
-	// releaseConditionType is the type used when setting a release status condition
-	releaseConditionType string = "Succeeded"
+	// ValidatedConditionType is the type of the condition indicating whether the Release and the resources it
+	// references are valid
+	ValidatedConditionType string = "Validated"
+
+	// ReleasedConditionType is the type of the condition indicating whether the release PipelineRun has succeeded
+	ReleasedConditionType string = "Released"
+
+	// DeployedConditionType is the type of the condition indicating whether the released Snapshot has been deployed
+	DeployedConditionType string = "Deployed"
+
+	// RolledBackConditionType is the type of the condition indicating whether the Environment whose deployment failed
+	// was rolled back to the Snapshot it was running before the Release
+	RolledBackConditionType string = "RolledBack"
+
+	// ReadyConditionType is the type of the condition aggregating the rest of conditions. It's True once the Release
+	// is released and deployed, False if any of the steps failed and Unknown otherwise
+	ReadyConditionType string = "Ready"
+
+	// ReleaseReasonValidated is the reason set when the Release passes the validation
+	ReleaseReasonValidated ReleaseReason = "Validated"
 
 	// ReleaseReasonValidationError is the reason set when the Release validation failed
 	ReleaseReasonValidationError ReleaseReason = "ReleaseValidationError"
 
+	// ReleaseReasonParamsValidationError is the reason set when the params passed by the tenant are not allowed
+	ReleaseReasonParamsValidationError ReleaseReason = "ParamsValidationError"
+
 	// ReleaseReasonPipelineFailed is the reason set when the release PipelineRun failed
 	ReleaseReasonPipelineFailed ReleaseReason = "ReleasePipelineFailed"
 
 	// ReleaseReasonReleasePlanValidationError is the reason set when there is a validation error with the ReleasePlan
 	ReleaseReasonReleasePlanValidationError ReleaseReason = "ReleasePlanValidationError"
 
+	// ReleaseReasonRollbackValidationError is the reason set when the Release to roll back to is not valid
+	ReleaseReasonRollbackValidationError ReleaseReason = "RollbackValidationError"
+
 	// ReleaseReasonTargetDisabledError is the reason set when releases to the target are disabled
 	ReleaseReasonTargetDisabledError ReleaseReason = "ReleaseTargetDisabledError"
 
 	// ReleaseReasonRunning is the reason set when the release PipelineRun starts running
 	ReleaseReasonRunning ReleaseReason = "Running"
 
+	// ReleaseReasonReleasePipelineSkipped is the reason set when a rollback skips the release Pipeline
+	ReleaseReasonReleasePipelineSkipped ReleaseReason = "ReleasePipelineSkipped"
+
 	// ReleaseReasonSucceeded is the reason set when the release PipelineRun has succeeded
 	ReleaseReasonSucceeded ReleaseReason = "Succeeded"
+
+	// ReleaseReasonDeploymentPending is the reason set when the release PipelineRun has succeeded but the deployment
+	// has not started yet
+	ReleaseReasonDeploymentPending ReleaseReason = "DeploymentPending"
+
+	// ReleaseReasonDeploymentSkipped is the reason set when there is no environment to deploy the Release to
+	ReleaseReasonDeploymentSkipped ReleaseReason = "DeploymentSkipped"
+
+	// ReleaseReasonDeploymentFailed is the reason set when the SnapshotEnvironmentBinding reports an error that won't
+	// be recovered from without changes to the deployment
+	ReleaseReasonDeploymentFailed ReleaseReason = "DeploymentFailed"
+
+	// ReleaseReasonDeploymentTimedOut is the reason set when the deployment doesn't finish in the time set in the
+	// ReleasePlanAdmission
+	ReleaseReasonDeploymentTimedOut ReleaseReason = "DeploymentTimedOut"
+
+	// ReleaseReasonVerifying is the reason set when all the components are deployed and the verification PipelineRun
+	// is running
+	ReleaseReasonVerifying ReleaseReason = "Verifying"
+
+	// ReleaseReasonVerified is the reason set when the verification PipelineRun has succeeded
+	ReleaseReasonVerified ReleaseReason = "Verified"
+
+	// ReleaseReasonVerificationFailed is the reason set when the verification PipelineRun failed
+	ReleaseReasonVerificationFailed ReleaseReason = "VerificationFailed"
+
+	// ReleaseReasonRolledBack is the reason set when the Environment whose deployment failed was rolled back
+	ReleaseReasonRolledBack ReleaseReason = "RolledBack"
+
+	// ReleaseReasonRollbackSkipped is the reason set when the Environment whose deployment failed can't be rolled back
+	// as another Release has deployed to it since
+	ReleaseReasonRollbackSkipped ReleaseReason = "RollbackSkipped"
 )
 
 func (rr ReleaseReason) String() string {
 	return string(rr)
 }
 
+// ReleasePhase represents the phase of a Release. It summarizes the Release conditions using values that match the
+// states reported by kstatus compatible tools.
+type ReleasePhase string
+
+const (
+	// ReleasePhasePending is the phase of a Release that hasn't been validated yet
+	ReleasePhasePending ReleasePhase = "Pending"
+
+	// ReleasePhaseReleasing is the phase of a Release whose release PipelineRun is running
+	ReleasePhaseReleasing ReleasePhase = "Releasing"
+
+	// ReleasePhaseDeploying is the phase of a released Release whose deployment hasn't finished yet
+	ReleasePhaseDeploying ReleasePhase = "Deploying"
+
+	// ReleasePhaseSucceeded is the phase of a Release that has been released and deployed
+	ReleasePhaseSucceeded ReleasePhase = "Succeeded"
+
+	// ReleasePhaseFailed is the phase of a Release that is invalid or whose release PipelineRun failed
+	ReleasePhaseFailed ReleasePhase = "Failed"
+)
+
 const (
 	// AutoReleaseLabel is the label name for the auto-release setting
 	AutoReleaseLabel = "release.appstudio.openshift.io/auto-release"
+
+	// RequesterAnnotation is the annotation used to record the username of the user who created the Release
+	RequesterAnnotation = "release.appstudio.openshift.io/requester"
+
+	// RequesterGroupsAnnotation is the annotation used to record the comma separated list of groups of the user who
+	// created the Release
+	RequesterGroupsAnnotation = "release.appstudio.openshift.io/requester-groups"
+
+	// RequesterServiceAccountAnnotation is the annotation used to record whether the Release was created by a
+	// service account
+	RequesterServiceAccountAnnotation = "release.appstudio.openshift.io/requester-service-account"
+
+	// serviceAccountUsernamePrefix is the prefix of the usernames assigned to service accounts
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
 )
 
+// RequesterAnnotations is the list of annotations recording who created a Release. They are set by the Release
+// webhook and can't be modified afterwards.
+var RequesterAnnotations = []string{RequesterAnnotation, RequesterGroupsAnnotation, RequesterServiceAccountAnnotation}
+
+// Requester defines the identity of the user who created a Release.
+type Requester struct {
+	// Username is the name of the user who created the Release
+	// +required
+	Username string `json:"username"`
+
+	// Groups is the list of groups the user belonged to when the Release was created
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// ServiceAccount indicates whether the Release was created by a service account, as it happens when the Release
+	// is created automatically
+	// +optional
+	ServiceAccount bool `json:"serviceAccount,omitempty"`
+}
+
 // ReleaseStatus defines the observed state of Release.
 type ReleaseStatus struct {
 	// StartTime is the time when the Release PipelineRun was created and set to run
@@ -95,6 +224,15 @@ type ReleaseStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions"`
 
+	// ObservedGeneration is the generation of the Release observed when its conditions were last updated
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is a summary of the state of the Release derived from its conditions
+	// +kubebuilder:validation:Enum=Pending;Releasing;Deploying;Succeeded;Failed
+	// +optional
+	Phase ReleasePhase `json:"phase,omitempty"`
+
 	// SnapshotEnvironmentBinding contains the namespaced name of the SnapshotEnvironmentBinding created as part of
 	// this release
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?\/[a-z0-9]([-a-z0-9]*[a-z0-9])?$
@@ -106,22 +244,34 @@ type ReleaseStatus struct {
 	// +optional
 	ReleasePipelineRun string `json:"releasePipelineRun,omitempty"`
 
-	// ReleaseStrategy contains the namespaced name of the ReleaseStrategy used for this release
-	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?\/[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// ReleaseStrategy contains the namespaced name of the ReleaseStrategy used for this release or the name of the
+	// ClusterReleaseStrategy if the ReleasePlanAdmission references one
+	// +kubebuilder:validation:Pattern=^([a-z0-9]([-a-z0-9]*[a-z0-9])?\/)?[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +optional
 	ReleaseStrategy string `json:"releaseStrategy,omitempty"`
 
+	// EffectiveReleaseStrategy contains the spec of the strategy used for this release once the settings inherited
+	// from a ClusterReleaseStrategy are resolved
+	// +optional
+	EffectiveReleaseStrategy *ReleaseStrategySpec `json:"effectiveReleaseStrategy,omitempty"`
+
 	// Target references where this release is intended to be released to
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +optional
 	Target string `json:"target,omitempty"`
+
+	// Requester contains the identity of the user who created the Release
+	// +optional
+	Requester *Requester `json:"requester,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Snapshot",type=string,JSONPath=`.spec.snapshot`
-// +kubebuilder:printcolumn:name="Succeeded",type=string,JSONPath=`.status.conditions[?(@.type=="Succeeded")].status`
-// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Succeeded")].reason`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Requester",type=string,priority=1,JSONPath=`.status.requester.username`
 // +kubebuilder:printcolumn:name="PipelineRun",type=string,priority=1,JSONPath=`.status.releasePipelineRun`
 // +kubebuilder:printcolumn:name="Start Time",type=date,priority=1,JSONPath=`.status.startTime`
 // +kubebuilder:printcolumn:name="Completion Time",type=date,priority=1,JSONPath=`.status.completionTime`
@@ -138,128 +288,149 @@ type Release struct {
 	Status ReleaseStatus `json:"status,omitempty"`
 }
 
+// GetRequester returns the identity of the user who created the Release as recorded in its annotations. If the
+// Release has no requester annotations, nil is returned.
+func (r *Release) GetRequester() *Requester {
+	annotations := r.GetAnnotations()
+	username, found := annotations[RequesterAnnotation]
+	if !found {
+		return nil
+	}
+
+	requester := &Requester{
+		Username:       username,
+		ServiceAccount: annotations[RequesterServiceAccountAnnotation] == "true",
+	}
+	if groups := annotations[RequesterGroupsAnnotation]; groups != "" {
+		requester.Groups = strings.Split(groups, ",")
+	}
+
+	return requester
+}
+
 // HasStarted checks whether the Release has a valid start time set in its status.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
 func (r *Release) HasStarted() bool {
-	return r.Status.StartTime != nil && !r.Status.StartTime.IsZero()
+	return r.toHub().HasStarted()
 }
 
-// HasSucceeded checks whether the Release has succeeded or not.
+// HasSucceeded checks whether the release PipelineRun of the Release has succeeded or not.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
 func (r *Release) HasSucceeded() bool {
-	return meta.IsStatusConditionTrue(r.Status.Conditions, releaseConditionType)
+	return r.toHub().HasSucceeded()
 }
 
-// IsDeployed checks whether the Release has been successfully deployed via GitOps.
+// IsDeployed checks whether the Release has been successfully deployed via GitOps or doesn't need to be deployed.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
 func (r *Release) IsDeployed() bool {
-	condition := meta.FindStatusCondition(r.Status.Conditions, applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed)
-	return condition != nil && condition.Status == metav1.ConditionTrue
+	return r.toHub().IsDeployed()
 }
 
 // IsDeploying checks whether the Release has a valid start time for the deployment set in its status.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
 func (r *Release) IsDeploying() bool {
-	return r.Status.DeploymentStartTime != nil && !r.Status.DeploymentStartTime.IsZero()
+	return r.toHub().IsDeploying()
 }
 
 // IsDone returns a boolean indicating whether the Release's status indicates that it is done or not.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
 func (r *Release) IsDone() bool {
-	condition := meta.FindStatusCondition(r.Status.Conditions, releaseConditionType)
-	return condition != nil && condition.Status != metav1.ConditionUnknown
+	return r.toHub().IsDone()
 }
 
-// MarkDeployed registers the deployment completion time and sets the AllComponentsDeployed status in the
-// Release to True with the provided reason and message.
-func (r *Release) MarkDeployed(reason, message string) {
-	if !r.IsDeploying() || (r.IsDeployed() && r.Status.DeploymentCompletionTime != nil) {
-		return
-	}
-
-	r.Status.DeploymentCompletionTime = &metav1.Time{Time: time.Now()}
-	r.setStatusConditionWithMessage(applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed,
-		metav1.ConditionTrue, ReleaseReason(reason), message)
+// IsReady checks whether the Release has been released and deployed.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
+func (r *Release) IsReady() bool {
+	return r.toHub().IsReady()
+}
 
-	go metrics.RegisterDeployedRelease(reason, r.Status.Target, string(metav1.ConditionTrue),
-		r.Status.DeploymentStartTime, r.Status.DeploymentCompletionTime)
+// MarkDeployed registers the deployment completion time and sets the Deployed condition in the Release to True.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
+func (r *Release) MarkDeployed(reason, message string) {
+	r.updateStatus(func(hub *v1alpha2.Release) {
+		hub.MarkDeployed(reason, message)
+	})
 }
 
-// MarkDeploying registers the deployment start time and sets the AllComponentsDeployed status in the Release to Unknown
-// or False with the provided reason and message.
-// Note: The binding condition should treat False and True as the final states and Unknown as the transient status. However, it
-// currently treats False as a transient status, so we accept False (temporarily) as a status here until it is changed.
+// MarkDeploying registers the deployment start time and sets the Deployed condition in the Release to Unknown or
+// False.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
 func (r *Release) MarkDeploying(status metav1.ConditionStatus, reason, message string) {
-	if status != metav1.ConditionTrue {
-		r.setStatusConditionWithMessage(applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed,
-			status, ReleaseReason(reason), message)
+	r.updateStatus(func(hub *v1alpha2.Release) {
+		hub.MarkDeploying(status, reason, message)
+	})
+}
 
-		if r.Status.DeploymentStartTime == nil {
-			r.Status.DeploymentStartTime = &metav1.Time{Time: time.Now()}
-		}
-	}
+// MarkDeploymentSkipped sets the Deployed condition in the Release to True when there is nothing to deploy.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
+func (r *Release) MarkDeploymentSkipped(message string) {
+	r.updateStatus(func(hub *v1alpha2.Release) {
+		hub.MarkDeploymentSkipped(message)
+	})
 }
 
-// MarkFailed registers the completion time and changes the Succeeded condition to False with
-// the provided reason and message.
+// MarkFailed registers the completion time and changes the Released condition to False.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
 func (r *Release) MarkFailed(reason ReleaseReason, message string) {
-	if r.IsDone() && r.Status.CompletionTime != nil {
-		return
-	}
-
-	r.Status.CompletionTime = &metav1.Time{Time: time.Now()}
-	r.setStatusConditionWithMessage(releaseConditionType, metav1.ConditionFalse, reason, message)
-
-	go metrics.RegisterCompletedRelease(reason.String(), r.Status.ReleaseStrategy, r.Status.Target,
-		r.Status.StartTime, r.Status.CompletionTime, false)
+	r.updateStatus(func(hub *v1alpha2.Release) {
+		hub.MarkFailed(v1alpha2.ReleaseReason(reason), message)
+	})
 }
 
-// MarkInvalid changes the Succeeded condition to False with the provided reason and message.
+// MarkInvalid changes the Validated condition to False with the provided reason and message.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
 func (r *Release) MarkInvalid(reason ReleaseReason, message string) {
-	if r.IsDone() {
-		return
-	}
-
-	r.setStatusConditionWithMessage(releaseConditionType, metav1.ConditionFalse, reason, message)
-
-	go metrics.RegisterInvalidRelease(reason.String())
+	r.updateStatus(func(hub *v1alpha2.Release) {
+		hub.MarkInvalid(v1alpha2.ReleaseReason(reason), message)
+	})
 }
 
-// MarkRunning registers the start time and changes the Succeeded condition to Unknown.
+// MarkRunning registers the start time and changes the Validated condition to True and the Released condition to
+// Unknown.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
 func (r *Release) MarkRunning() {
-	if r.HasStarted() && r.Status.StartTime != nil {
-		return
-	}
-
-	r.Status.StartTime = &metav1.Time{Time: time.Now()}
-	r.setStatusCondition(releaseConditionType, metav1.ConditionUnknown, ReleaseReasonRunning)
-
-	go metrics.RegisterNewRelease(r.GetCreationTimestamp(), r.Status.StartTime)
+	r.updateStatus(func(hub *v1alpha2.Release) {
+		hub.MarkRunning()
+	})
 }
 
-// MarkSucceeded registers the completion time and changes the Succeeded condition to True.
+// MarkSucceeded registers the completion time and changes the Released condition to True.
+//
+// Deprecated: use the v1alpha2 Release instead. This wrapper will be removed in a future release.
 func (r *Release) MarkSucceeded() {
-	if !r.HasStarted() || (r.IsDone() && r.Status.CompletionTime != nil) {
-		return
-	}
-
-	r.Status.CompletionTime = &metav1.Time{Time: time.Now()}
-	r.setStatusCondition(releaseConditionType, metav1.ConditionTrue, ReleaseReasonSucceeded)
-
-	go metrics.RegisterCompletedRelease(ReleaseReasonSucceeded.String(), r.Status.ReleaseStrategy, r.Status.Target,
-		r.Status.StartTime, r.Status.CompletionTime, true)
+	r.updateStatus(func(hub *v1alpha2.Release) {
+		hub.MarkSucceeded()
+	})
 }
 
-// SetCondition creates a new condition with the given conditionType, status and reason. Then, it sets this new condition,
-// unsetting previous conditions with the same type as necessary.
-func (r *Release) setStatusCondition(conditionType string, status metav1.ConditionStatus, reason ReleaseReason) {
-	r.setStatusConditionWithMessage(conditionType, status, reason, "")
+// toHub returns the v1alpha2 version of the Release, where the Release state machine is implemented.
+func (r *Release) toHub() *v1alpha2.Release {
+	hub := &v1alpha2.Release{}
+	// The conversion only fails on a corrupted conversion data annotation, in which case the v1alpha2 status
+	// fields it holds are left empty
+	_ = r.ConvertTo(hub)
+
+	return hub
 }
 
-// SetCondition creates a new condition with the given conditionType, status, reason and message. Then, it sets this new condition,
-// unsetting previous conditions with the same type as necessary.
-func (r *Release) setStatusConditionWithMessage(conditionType string, status metav1.ConditionStatus, reason ReleaseReason, message string) {
-	meta.SetStatusCondition(&r.Status.Conditions, metav1.Condition{
-		Type:    conditionType,
-		Status:  status,
-		Reason:  reason.String(),
-		Message: message,
-	})
+// updateStatus applies the given change to the v1alpha2 version of the Release and stores the result back in the
+// Release, so the deprecated helpers share the v1alpha2 state machine.
+func (r *Release) updateStatus(change func(hub *v1alpha2.Release)) {
+	hub := r.toHub()
+	change(hub)
+	_ = r.ConvertFrom(hub)
 }
 
 // +kubebuilder:object:root=true
@@ -17,34 +17,152 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"fmt"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"reflect"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"strconv"
+	"strings"
 )
 
-func (r *Release) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// SetupWebhookWithManager registers the Release webhook in the Manager. If a strict validator is given, new Releases
+// are also validated using it. That validator resolves the resources referenced by the Releases using the loader
+// package, which can't be imported from here, so it's created by the webhooks package.
+func (r *Release) SetupWebhookWithManager(mgr ctrl.Manager, strictValidator admission.CustomValidator) error {
+	w := &releaseWebhook{
+		client:          mgr.GetClient(),
+		strictValidator: strictValidator,
+	}
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(w).
+		WithValidator(w).
 		Complete()
 }
 
+// releaseWebhook wraps the Release Validator implementation and records the authorization decision and the requester
+// identity, so the admission request can be used to authorize and identify the user creating the Release.
+type releaseWebhook struct {
+	// client is used to read the ReleasePlans, to issue SubjectAccessReviews and to read the target namespaces
+	client client.Client
+
+	// strictValidator is used to resolve the resources referenced by new Releases when strict mode is enabled
+	strictValidator admission.CustomValidator
+}
+
+//+kubebuilder:webhook:path=/mutate-appstudio-redhat-com-v1alpha1-release,mutating=true,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=releases,verbs=create,versions=v1alpha1,name=mrelease.kb.io,admissionReviewVersions=v1
+
+var _ admission.CustomDefaulter = &releaseWebhook{}
+var _ admission.CustomValidator = &releaseWebhook{}
+
+// Default implements admission.CustomDefaulter. The identity of the user creating the Release is recorded in the
+// requester annotations and the authorization decision in the AuthorizationAnnotation.
+func (w *releaseWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	r := obj.(*Release)
+	recordRequester(ctx, r)
+
+	target, err := w.getTarget(ctx, r)
+	if err != nil {
+		return err
+	}
+	recordAuthorization(ctx, w.client, r, r.Namespace, target)
+
+	return nil
+}
+
+// ValidateCreate implements admission.CustomValidator. Besides validating the Release, the user creating it has to be
+// authorized to create Releases in its namespace and the target of its ReleasePlan has to allow releases from it. In
+// strict mode, the Release is also validated by the strict validator.
+func (w *releaseWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	r := obj.(*Release)
+	if err := r.ValidateCreate(); err != nil {
+		return err
+	}
+
+	target, err := w.getTarget(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	if _, err = authorize(ctx, w.client, r.Namespace, target); err != nil {
+		return err
+	}
+
+	if w.strictValidator != nil {
+		return w.strictValidator.ValidateCreate(ctx, obj)
+	}
+
+	return nil
+}
+
+// ValidateUpdate implements admission.CustomValidator. The authorization is not checked again on updates as the spec
+// of a Release can't be modified.
+func (w *releaseWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return newObj.(*Release).ValidateUpdate(oldObj)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (w *releaseWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return obj.(*Release).ValidateDelete()
+}
+
+// getTarget returns the target of the ReleasePlan referenced by the given Release. If the ReleasePlan doesn't exist, an
+// empty string is returned as the target is unknown.
+func (w *releaseWebhook) getTarget(ctx context.Context, r *Release) (string, error) {
+	if w.client == nil {
+		return "", nil
+	}
+
+	releasePlan := &ReleasePlan{}
+	err := w.client.Get(ctx, types.NamespacedName{
+		Name:      r.Spec.ReleasePlan,
+		Namespace: r.Namespace,
+	}, releasePlan)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return releasePlan.Spec.Target, nil
+}
+
 //+kubebuilder:webhook:path=/validate-appstudio-redhat-com-v1alpha1-release,mutating=false,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=releases,verbs=create;update,versions=v1alpha1,name=vrelease.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Release{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *Release) ValidateCreate() error {
-	return nil
+	if err := r.validateRollback(); err != nil {
+		return err
+	}
+
+	return validateTenantParams(r.Spec.Params)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *Release) ValidateUpdate(old runtime.Object) error {
-	if !reflect.DeepEqual(r.Spec, old.(*Release).Spec) {
+	oldRelease := old.(*Release)
+	if !reflect.DeepEqual(r.Spec, oldRelease.Spec) {
 		return fmt.Errorf("release resources spec cannot be updated")
 	}
 
+	for _, annotation := range append(RequesterAnnotations, AuthorizationAnnotation) {
+		value, found := r.GetAnnotations()[annotation]
+		oldValue, oldFound := oldRelease.GetAnnotations()[annotation]
+		if value != oldValue || found != oldFound {
+			return fmt.Errorf("release annotation '%s' cannot be updated", annotation)
+		}
+	}
+
 	return nil
 }
 
@@ -52,3 +170,50 @@ func (r *Release) ValidateUpdate(old runtime.Object) error {
 func (r *Release) ValidateDelete() error {
 	return nil
 }
+
+// validateRollback throws an error if the release Pipeline is skipped without rolling back or the Release rolls back
+// to itself. As rollbacks release the Snapshot of the Release they roll back to, the Snapshot has to be set unless the
+// Release is a rollback, and it can't be set if it is.
+func (r *Release) validateRollback() error {
+	if r.Spec.RollbackTo == "" {
+		if r.Spec.SkipReleasePipeline {
+			return fmt.Errorf("skipReleasePipeline can only be set along with rollbackTo")
+		}
+
+		if r.Spec.Snapshot == "" {
+			return fmt.Errorf("snapshot is required unless rollbackTo is set")
+		}
+
+		return nil
+	}
+
+	if r.Spec.RollbackTo == r.Name {
+		return fmt.Errorf("a Release can't roll back to itself")
+	}
+
+	if r.Spec.Snapshot != "" {
+		return fmt.Errorf("snapshot can't be set along with rollbackTo, as the Snapshot of the Release to roll back " +
+			"to is released")
+	}
+
+	return nil
+}
+
+// recordRequester sets the requester annotations in the given Release to the identity of the user making the admission
+// request stored in the given context, replacing any value set by the user.
+func recordRequester(ctx context.Context, r *Release) {
+	request, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return
+	}
+
+	annotations := r.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[RequesterAnnotation] = request.UserInfo.Username
+	annotations[RequesterGroupsAnnotation] = strings.Join(request.UserInfo.Groups, ",")
+	annotations[RequesterServiceAccountAnnotation] = strconv.FormatBool(
+		strings.HasPrefix(request.UserInfo.Username, serviceAccountUsernamePrefix))
+	r.SetAnnotations(annotations)
+}
@@ -69,14 +69,124 @@ var _ = Describe("Release validation webhook", func() {
 			Expect(k8sClient.Create(ctx, release)).Should(Succeed())
 
 			// Try to update the Release annotations
-			release.ObjectMeta.Annotations = map[string]string{
-				"foo": "bar",
-			}
+			release.ObjectMeta.Annotations["foo"] = "bar"
 
 			Expect(k8sClient.Update(ctx, release)).ShouldNot(HaveOccurred())
 		})
 	})
 
+	Context("Create Release CR with params", func() {
+		It("Should error out when a param uses valueFrom", func() {
+			release.Spec.Params = []Params{
+				{Name: "foo", ValueFrom: &ParamValueSource{}},
+			}
+
+			err := k8sClient.Create(ctx, release)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("param 'foo' cannot use valueFrom"))
+		})
+	})
+
+	Context("Create rollback Release CR", func() {
+		It("Should error out when skipping the release Pipeline without rolling back", func() {
+			release.Spec.SkipReleasePipeline = true
+
+			err := k8sClient.Create(ctx, release)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("skipReleasePipeline can only be set along with rollbackTo"))
+		})
+
+		It("Should error out when rolling back to itself", func() {
+			release.GenerateName = ""
+			release.Name = "test-release"
+			release.Spec.RollbackTo = release.Name
+
+			err := k8sClient.Create(ctx, release)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("a Release can't roll back to itself"))
+		})
+
+		It("Should error out when setting the Snapshot", func() {
+			release.Spec.RollbackTo = "previous-release"
+
+			err := k8sClient.Create(ctx, release)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("snapshot can't be set along with rollbackTo"))
+		})
+
+		It("Should create the rollback without a Snapshot", func() {
+			release.Spec.Snapshot = ""
+			release.Spec.RollbackTo = "previous-release"
+
+			Expect(k8sClient.Create(ctx, release)).Should(Succeed())
+		})
+	})
+
+	Context("Create Release CR without a Snapshot", func() {
+		It("Should error out when not rolling back", func() {
+			release.Spec.Snapshot = ""
+
+			err := k8sClient.Create(ctx, release)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("snapshot is required unless rollbackTo is set"))
+		})
+	})
+
+	Context("Create Release CR", func() {
+		It("Should get the authorization decision recorded", func() {
+			Expect(k8sClient.Create(ctx, release)).Should(Succeed())
+			Expect(release.GetAnnotations()[AuthorizationAnnotation]).To(HavePrefix("allowed:"))
+		})
+
+		It("Should get the requester recorded replacing the values set by the user", func() {
+			release.Annotations = map[string]string{
+				RequesterAnnotation:               "someone-else",
+				RequesterServiceAccountAnnotation: "true",
+			}
+			Expect(k8sClient.Create(ctx, release)).Should(Succeed())
+
+			requester := release.GetRequester()
+			Expect(requester).NotTo(BeNil())
+			Expect(requester.Username).NotTo(BeEmpty())
+			Expect(requester.Username).NotTo(Equal("someone-else"))
+			Expect(requester.ServiceAccount).To(BeFalse())
+		})
+
+		It("Should error out when updating the requester annotations", func() {
+			Expect(k8sClient.Create(ctx, release)).Should(Succeed())
+
+			release.Annotations[RequesterAnnotation] = "someone-else"
+			err := k8sClient.Update(ctx, release)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("release annotation '%s' cannot be updated", RequesterAnnotation))
+
+			delete(release.Annotations, RequesterAnnotation)
+			err = k8sClient.Update(ctx, release)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("Should error out when updating the authorization annotation", func() {
+			Expect(k8sClient.Create(ctx, release)).Should(Succeed())
+
+			release.Annotations[AuthorizationAnnotation] = "allowed: by someone else"
+			err := k8sClient.Update(ctx, release)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("release annotation '%s' cannot be updated", AuthorizationAnnotation))
+		})
+	})
+
+	Describe("When ValidateUpdate method is called", func() {
+		It("should error out if the authorization annotation is removed", func() {
+			oldRelease := &Release{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{AuthorizationAnnotation: "allowed: by the webhook"},
+				},
+			}
+			release := &Release{}
+			Expect(release.ValidateUpdate(oldRelease)).To(HaveOccurred())
+		})
+	})
+
 	Describe("When ValidateDelete method is called", func() {
 		It("should return nil", func() {
 			release := &Release{}
@@ -35,6 +35,10 @@ type ReleasePlanSpec struct {
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +required
 	Target string `json:"target"`
+
+	// Params to pass to the release Pipeline. They need to be allowed by the ReleasePlanAdmission in the target
+	// +optional
+	Params []Params `json:"params,omitempty"`
 }
 
 // ReleasePlanStatus defines the observed state of ReleasePlan.
@@ -17,19 +17,90 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"fmt"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func (rp *ReleasePlan) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	w := &releasePlanWebhook{client: mgr.GetClient()}
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(rp).
+		WithDefaulter(w).
+		WithValidator(w).
 		Complete()
 }
 
-// +kubebuilder:webhook:path=/mutate-appstudio-redhat-com-v1alpha1-releaseplan,mutating=true,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=releaseplans,verbs=create,versions=v1alpha1,name=mreleaseplan.kb.io,admissionReviewVersions=v1
+// releasePlanWebhook wraps the ReleasePlan Defaulter and Validator implementations, so the admission request can be
+// used to authorize the user creating or updating the ReleasePlan.
+type releasePlanWebhook struct {
+	// client is used to read the ReleasePlanAdmissions in the target, to issue SubjectAccessReviews and to read the
+	// origin and target namespaces
+	client client.Client
+}
+
+var _ admission.CustomDefaulter = &releasePlanWebhook{}
+var _ admission.CustomValidator = &releasePlanWebhook{}
+
+// Default implements admission.CustomDefaulter. Besides defaulting the ReleasePlan, the authorization decision is
+// recorded in the AuthorizationAnnotation.
+func (w *releasePlanWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	rp := obj.(*ReleasePlan)
+	if request, err := admission.RequestFromContext(ctx); err == nil && request.Operation == admissionv1.Create {
+		rp.Default()
+	}
+	recordAuthorization(ctx, w.client, rp, rp.Namespace, rp.Spec.Target)
+
+	return nil
+}
+
+// ValidateCreate implements admission.CustomValidator. Besides validating the ReleasePlan, the user creating it has
+// to be authorized to create Releases in its namespace and the target has to allow releases from it.
+func (w *releasePlanWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	rp := obj.(*ReleasePlan)
+	if err := rp.ValidateCreate(); err != nil {
+		return err
+	}
+
+	if err := w.validateParams(ctx, rp); err != nil {
+		return err
+	}
+
+	_, err := authorize(ctx, w.client, rp.Namespace, rp.Spec.Target)
+	return err
+}
+
+// ValidateUpdate implements admission.CustomValidator. Besides validating the ReleasePlan, the user updating it has
+// to be authorized to create Releases in its namespace and the target has to allow releases from it.
+func (w *releasePlanWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	rp := newObj.(*ReleasePlan)
+	if err := rp.ValidateUpdate(oldObj); err != nil {
+		return err
+	}
+
+	if err := w.validateParams(ctx, rp); err != nil {
+		return err
+	}
+
+	_, err := authorize(ctx, w.client, rp.Namespace, rp.Spec.Target)
+	return err
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (w *releasePlanWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return obj.(*ReleasePlan).ValidateDelete()
+}
+
+// +kubebuilder:webhook:path=/mutate-appstudio-redhat-com-v1alpha1-releaseplan,mutating=true,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=releaseplans,verbs=create;update,versions=v1alpha1,name=mreleaseplan.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Defaulter = &ReleasePlan{}
 
@@ -50,12 +121,20 @@ var _ webhook.Validator = &ReleasePlan{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (rp *ReleasePlan) ValidateCreate() error {
-	return rp.validateAutoReleaseLabel()
+	if err := rp.validateAutoReleaseLabel(); err != nil {
+		return err
+	}
+
+	return validateTenantParams(rp.Spec.Params)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (rp *ReleasePlan) ValidateUpdate(old runtime.Object) error {
-	return rp.validateAutoReleaseLabel()
+	if err := rp.validateAutoReleaseLabel(); err != nil {
+		return err
+	}
+
+	return validateTenantParams(rp.Spec.Params)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -72,3 +151,54 @@ func (rp *ReleasePlan) validateAutoReleaseLabel() error {
 	}
 	return nil
 }
+
+// validateParams throws an error if the params of the given ReleasePlan are not allowed by the ReleasePlanAdmissions
+// matching it in the target. If no matching ReleasePlanAdmission exists yet, the params will be validated once a
+// Release is created.
+func (w *releasePlanWebhook) validateParams(ctx context.Context, rp *ReleasePlan) error {
+	if len(rp.Spec.Params) == 0 || w.client == nil {
+		return nil
+	}
+
+	releasePlanAdmissions := &ReleasePlanAdmissionList{}
+	err := w.client.List(ctx, releasePlanAdmissions, client.InNamespace(rp.Spec.Target))
+	if err != nil {
+		return err
+	}
+
+	// the origin namespace labels are only needed by the ReleasePlanAdmissions selecting their origins by label
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: rp.Namespace}}
+	for _, releasePlanAdmission := range releasePlanAdmissions.Items {
+		if releasePlanAdmission.Spec.OriginSelector != nil {
+			err = w.client.Get(ctx, types.NamespacedName{Name: rp.Namespace}, namespace)
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	for _, releasePlanAdmission := range releasePlanAdmissions.Items {
+		if !releasePlanAdmission.MatchesOrigin(namespace) || !releasePlanAdmission.MatchesApplication(rp.Spec.Application) {
+			continue
+		}
+
+		if err := releasePlanAdmission.ValidateParams(rp.Spec.Params); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateTenantParams throws an error if any of the given params gets its value from a ConfigMap or a Secret, as
+// tenants are not allowed to reference resources in the managed namespace.
+func validateTenantParams(params []Params) error {
+	for _, param := range params {
+		if param.ValueFrom != nil {
+			return fmt.Errorf("param '%s' cannot use valueFrom", param.Name)
+		}
+	}
+
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	//+kubebuilder:scaffold:imports
 )
@@ -122,6 +123,106 @@ var _ = Describe("ReleasePlan webhook", func() {
 		})
 	})
 
+	Context("When a ReleasePlan is created with a param using valueFrom", func() {
+		It("should get rejected", func() {
+			releasePlan.Spec.Params = []Params{
+				{Name: "foo", ValueFrom: &ParamValueSource{}},
+			}
+			err := k8sClient.Create(ctx, releasePlan)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("param 'foo' cannot use valueFrom"))
+		})
+	})
+
+	Context("When a ReleasePlan is created with params not allowed by a matching ReleasePlanAdmission", func() {
+		var releasePlanAdmission *ReleasePlanAdmission
+
+		BeforeEach(func() {
+			releasePlanAdmission = &ReleasePlanAdmission{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "releaseplanadmission-params",
+					Namespace: "default",
+				},
+				Spec: ReleasePlanAdmissionSpec{
+					Application:     "application",
+					Origin:          "default",
+					Environment:     "environment",
+					ReleaseStrategy: "strategy",
+					AllowedParams: []AllowedParam{
+						{Name: "foo", Values: []string{"bar"}},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, releasePlanAdmission)).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			err := k8sClient.Delete(ctx, releasePlanAdmission)
+			Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should get rejected if the param is not in the allowlist", func() {
+			releasePlan.Spec.Params = []Params{{Name: "baz", Value: "bar"}}
+			Eventually(func() error {
+				return k8sClient.Create(ctx, releasePlan)
+			}, timeout).Should(MatchError(ContainSubstring("param 'baz' is not allowed")))
+		})
+
+		It("should get rejected if the param value is not allowed", func() {
+			releasePlan.Spec.Params = []Params{{Name: "foo", Value: "baz"}}
+			Eventually(func() error {
+				return k8sClient.Create(ctx, releasePlan)
+			}, timeout).Should(MatchError(ContainSubstring("value 'baz' is not one of the allowed values")))
+		})
+
+		It("should be accepted if the param is allowed", func() {
+			releasePlan.Spec.Params = []Params{{Name: "foo", Value: "bar"}}
+			Expect(k8sClient.Create(ctx, releasePlan)).Should(Succeed())
+		})
+	})
+
+	Context("When a ReleasePlan is created", func() {
+		It("should get the authorization decision recorded", func() {
+			Expect(k8sClient.Create(ctx, releasePlan)).Should(Succeed())
+			Expect(releasePlan.GetAnnotations()[AuthorizationAnnotation]).To(HavePrefix("allowed:"))
+		})
+	})
+
+	Context("When a ReleasePlan targets a different namespace", func() {
+		var targetNamespace *corev1.Namespace
+
+		BeforeEach(func() {
+			targetNamespace = &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "releaseplan-target",
+				},
+			}
+			Expect(k8sClient.Create(ctx, targetNamespace)).Should(Succeed())
+			releasePlan.Spec.Target = targetNamespace.Name
+		})
+
+		AfterEach(func() {
+			err := k8sClient.Delete(ctx, targetNamespace)
+			Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should get rejected if the target namespace doesn't allow releases from the origin", func() {
+			Eventually(func() error {
+				return k8sClient.Create(ctx, releasePlan)
+			}, timeout).Should(MatchError(ContainSubstring(
+				"namespace 'releaseplan-target' doesn't allow releases from namespace 'default'")))
+		})
+
+		It("should be accepted once the target namespace allows releases from the origin", func() {
+			targetNamespace.Annotations = map[string]string{AllowedOriginsAnnotation: "foo, default"}
+			Expect(k8sClient.Update(ctx, targetNamespace)).Should(Succeed())
+
+			Eventually(func() error {
+				return k8sClient.Create(ctx, releasePlan.DeepCopy())
+			}, timeout).Should(Succeed())
+		})
+	})
+
 	Describe("When ValidateDelete method is called", func() {
 		It("should return nil", func() {
 			releaseplan := &ReleasePlan{}
@@ -17,7 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"regexp"
+
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // ReleasePlanAdmissionSpec defines the desired state of ReleasePlanAdmission.
@@ -28,27 +35,214 @@ type ReleasePlanAdmissionSpec struct {
 
 	// Application is a reference to the application to be released in the managed namespace
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
-	// +required
-	Application string `json:"application"`
+	// +optional
+	Application string `json:"application,omitempty"`
+
+	// Applications is a list of references to applications to be released in the managed namespace. It's merged
+	// with Application
+	// +kubebuilder:validation:items:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	Applications []string `json:"applications,omitempty"`
 
 	// Origin references where the release requests should come from
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
-	// +required
-	Origin string `json:"origin"`
+	// +optional
+	Origin string `json:"origin,omitempty"`
+
+	// Origins is a list of namespaces the release requests can come from. It's merged with Origin
+	// +kubebuilder:validation:items:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	Origins []string `json:"origins,omitempty"`
+
+	// OriginSelector selects by label the namespaces the release requests can come from, in addition to the ones
+	// listed in Origin and Origins
+	// +optional
+	OriginSelector *metav1.LabelSelector `json:"originSelector,omitempty"`
 
 	// Environment defines which Environment will be used to release the application
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +optional
 	Environment string `json:"environment,omitempty"`
 
+	// Environments is an ordered list of Environments the application is deployed to one after the other. It's merged
+	// with Environment, which is deployed to first
+	// +optional
+	Environments []EnvironmentStep `json:"environments,omitempty"`
+
 	// Release Strategy defines which strategy will be used to release the application
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +required
 	ReleaseStrategy string `json:"releaseStrategy"`
+
+	// ReleaseStrategyKind is the kind of the strategy referenced in ReleaseStrategy
+	// +kubebuilder:validation:Enum=ReleaseStrategy;ClusterReleaseStrategy
+	// +kubebuilder:default=ReleaseStrategy
+	// +optional
+	ReleaseStrategyKind string `json:"releaseStrategyKind,omitempty"`
+
+	// Priority is used to choose between ReleasePlanAdmissions admitting releases of the same application from the
+	// same origin. The enabled ReleasePlanAdmission with the highest priority is used
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
+	// AllowedParams is the list of params tenants are allowed to set in their ReleasePlans and Releases
+	// +optional
+	AllowedParams []AllowedParam `json:"allowedParams,omitempty"`
+
+	// DeploymentTimeout is the maximum time the deployment of a Release to the Environment can take before the
+	// Release is marked as failed. Deployments never time out if it's not set
+	// +optional
+	DeploymentTimeout *metav1.Duration `json:"deploymentTimeout,omitempty"`
+
+	// DefaultComponentConfiguration overrides the configuration of every component deployed to the Environment.
+	// Replicas are only overridden when set to a value greater than zero
+	// +optional
+	DefaultComponentConfiguration *applicationapiv1alpha1.BindingComponentConfiguration `json:"defaultComponentConfiguration,omitempty"`
+
+	// ComponentConfigurations is a list of configuration overrides for specific components deployed to the
+	// Environment. They take precedence over DefaultComponentConfiguration
+	// +optional
+	ComponentConfigurations []ComponentConfiguration `json:"componentConfigurations,omitempty"`
+
+	// MissingComponentsPolicy defines what happens to the components of the application that are not part of the
+	// released Snapshot. Keep leaves them deployed with their previous configuration, while Report removes them from
+	// the deployment and lists them in the Release status
+	// +kubebuilder:validation:Enum=Keep;Report
+	// +kubebuilder:default=Keep
+	// +optional
+	MissingComponentsPolicy MissingComponentsPolicy `json:"missingComponentsPolicy,omitempty"`
+
+	// VerificationPipeline is the Pipeline run to verify the application once it's deployed to the last Environment.
+	// Releases are only marked as deployed once it succeeds
+	// +optional
+	VerificationPipeline *VerificationPipeline `json:"verificationPipeline,omitempty"`
+
+	// AutoRollback restores the Snapshot the Environment was running before the Release if its deployment fails or
+	// times out
+	// +optional
+	AutoRollback bool `json:"autoRollback,omitempty"`
+}
+
+// VerificationPipeline defines the Pipeline used to verify the deployment of a Release, e.g. running smoke tests.
+type VerificationPipeline struct {
+	// PipelineRef references the verification Pipeline through a Tekton resolver
+	// +required
+	PipelineRef PipelineRef `json:"pipelineRef"`
+
+	// Params is the list of params to pass to the verification Pipeline. The environment and snapshot params are
+	// always passed, with the name of the Environment and the Snapshot as a json string
+	// +optional
+	Params []Params `json:"params,omitempty"`
+
+	// ServiceAccount is the name of the service account to use in the verification PipelineRun
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+}
+
+// MissingComponentsPolicy defines how to handle the components of the application not included in a Snapshot
+type MissingComponentsPolicy string
+
+const (
+	// MissingComponentsPolicyKeep keeps the components missing from the Snapshot bound with their previous configuration
+	MissingComponentsPolicyKeep MissingComponentsPolicy = "Keep"
+
+	// MissingComponentsPolicyReport leaves the components missing from the Snapshot out of the binding and reports them
+	// in the Release status
+	MissingComponentsPolicyReport MissingComponentsPolicy = "Report"
+)
+
+// EnvironmentStep defines an Environment the application is deployed to and the gate to pass before moving to the next
+// one.
+type EnvironmentStep struct {
+	// Name is the name of the Environment
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +required
+	Name string `json:"name"`
+
+	// Gate defines the conditions to meet before deploying to the next Environment. The next Environment is deployed
+	// to right after the SnapshotEnvironmentBinding is created if it's not set
+	// +optional
+	Gate *EnvironmentGate `json:"gate,omitempty"`
+}
+
+// EnvironmentGate defines the conditions to meet before deploying to the next Environment.
+type EnvironmentGate struct {
+	// WaitForDeployment requires all the components to be deployed to the Environment
+	// +optional
+	WaitForDeployment bool `json:"waitForDeployment,omitempty"`
+
+	// SoakDuration is the time to wait once all the components are deployed to the Environment. Setting it implies
+	// WaitForDeployment
+	// +optional
+	SoakDuration *metav1.Duration `json:"soakDuration,omitempty"`
+
+	// RequireApproval requires the SnapshotEnvironmentBinding of the Environment to be annotated with the
+	// ApprovedReleaseAnnotation, set to the namespaced name of the Release
+	// +optional
+	RequireApproval bool `json:"requireApproval,omitempty"`
+}
+
+// ComponentConfiguration defines the configuration overrides of a component deployed to the Environment
+type ComponentConfiguration struct {
+	// Name is the name of the component the configuration applies to
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +required
+	Name string `json:"name"`
+
+	// Configuration is the configuration overriding the one of the component. Replicas are only overridden when
+	// set to a value greater than zero
+	// +required
+	Configuration applicationapiv1alpha1.BindingComponentConfiguration `json:"configuration"`
+}
+
+// AllowedParam defines a param that tenants are allowed to pass to the release Pipeline
+type AllowedParam struct {
+	// Name is the name of the param
+	// +required
+	Name string `json:"name"`
+
+	// Values is the list of values the param can be set to. If empty, any value is allowed
+	// +optional
+	Values []string `json:"values,omitempty"`
+
+	// Pattern is a regular expression the values of the param must fully match
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// ReleasePlanAdmissionReason represents a reason for the ReleasePlanAdmission "Valid" condition.
+type ReleasePlanAdmissionReason string
+
+const (
+	// releasePlanAdmissionConditionType is the type used when setting a ReleasePlanAdmission status condition
+	releasePlanAdmissionConditionType string = "Valid"
+
+	// ReleasePlanAdmissionReasonConflict is the reason set when other ReleasePlanAdmissions share the same origin,
+	// application and priority
+	ReleasePlanAdmissionReasonConflict ReleasePlanAdmissionReason = "Conflict"
+
+	// ReleasePlanAdmissionReasonValid is the reason set when the ReleasePlanAdmission is found to be valid
+	ReleasePlanAdmissionReasonValid ReleasePlanAdmissionReason = "Valid"
+
+	// ApprovedReleaseAnnotation is the annotation set in a SnapshotEnvironmentBinding to approve the deployment of a
+	// Release to the next Environment. Its value is the namespaced name of the Release
+	ApprovedReleaseAnnotation string = "release.appstudio.openshift.io/approved-release"
+
+	// OriginSelectorIndexValue is the value used to index ReleasePlanAdmissions selecting their origins by label.
+	// It can't clash with the name of a namespace
+	OriginSelectorIndexValue string = "*"
+)
+
+func (rr ReleasePlanAdmissionReason) String() string {
+	return string(rr)
 }
 
 // ReleasePlanAdmissionStatus defines the observed state of ReleasePlanAdmission.
 type ReleasePlanAdmissionStatus struct {
+	// Conditions represent the latest available observations for the ReleasePlanAdmission
+	// +optional
+	Conditions []metav1.Condition `json:"conditions"`
 }
 
 // +kubebuilder:object:root=true
@@ -58,6 +252,11 @@ type ReleasePlanAdmissionStatus struct {
 // +kubebuilder:printcolumn:name="Environment",type=string,JSONPath=`.spec.environment`
 // +kubebuilder:printcolumn:name="Strategy",type=string,JSONPath=`.spec.releaseStrategy`
 // +kubebuilder:printcolumn:name="Origin",type=string,JSONPath=`.spec.origin`
+// +kubebuilder:printcolumn:name="Applications",type=string,priority=1,JSONPath=`.spec.applications`
+// +kubebuilder:printcolumn:name="Origins",type=string,priority=1,JSONPath=`.spec.origins`
+// +kubebuilder:printcolumn:name="Priority",type=integer,priority=1,JSONPath=`.spec.priority`
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].reason`
 
 // ReleasePlanAdmission is the Schema for the ReleasePlanAdmissions API.
 type ReleasePlanAdmission struct {
@@ -68,6 +267,250 @@ type ReleasePlanAdmission struct {
 	Status ReleasePlanAdmissionStatus `json:"status,omitempty"`
 }
 
+// ConflictsWith checks whether the given ReleasePlanAdmission is a different ReleasePlanAdmission in the same
+// namespace admitting releases of any of the same applications from any of the same origins with the same priority.
+// Origins selected by label can't be known in advance, so only the origins listed by name are compared.
+func (rpa *ReleasePlanAdmission) ConflictsWith(releasePlanAdmission *ReleasePlanAdmission) bool {
+	return rpa.Name != releasePlanAdmission.Name &&
+		rpa.Namespace == releasePlanAdmission.Namespace &&
+		rpa.Spec.Priority == releasePlanAdmission.Spec.Priority &&
+		intersects(rpa.GetOrigins(), releasePlanAdmission.GetOrigins()) &&
+		intersects(rpa.GetApplications(), releasePlanAdmission.GetApplications())
+}
+
+// GetApplications returns the names of all the applications admitted by the ReleasePlanAdmission, merging the
+// Application and Applications fields.
+func (rpa *ReleasePlanAdmission) GetApplications() []string {
+	return mergeNames(rpa.Spec.Application, rpa.Spec.Applications)
+}
+
+// GetEnvironments returns all the Environments the ReleasePlanAdmission deploys to in order, merging the Environment
+// and Environments fields.
+func (rpa *ReleasePlanAdmission) GetEnvironments() []EnvironmentStep {
+	var environments []EnvironmentStep
+	seen := map[string]bool{}
+	for _, environment := range append([]EnvironmentStep{{Name: rpa.Spec.Environment}}, rpa.Spec.Environments...) {
+		if environment.Name == "" || seen[environment.Name] {
+			continue
+		}
+		seen[environment.Name] = true
+		environments = append(environments, environment)
+	}
+
+	return environments
+}
+
+// GetNextEnvironment returns the Environment to deploy to after the one with the given name. If the given Environment
+// is the last one or it's not deployed to by the ReleasePlanAdmission, nil is returned.
+func (rpa *ReleasePlanAdmission) GetNextEnvironment(name string) *EnvironmentStep {
+	environments := rpa.GetEnvironments()
+	for i := range environments {
+		if environments[i].Name == name && i+1 < len(environments) {
+			return &environments[i+1]
+		}
+	}
+
+	return nil
+}
+
+// GetEnvironmentGate returns the gate of the Environment with the given name or nil if it doesn't have one.
+func (rpa *ReleasePlanAdmission) GetEnvironmentGate(name string) *EnvironmentGate {
+	for _, environment := range rpa.GetEnvironments() {
+		if environment.Name == name {
+			return environment.Gate
+		}
+	}
+
+	return nil
+}
+
+// GetOrigins returns the names of all the origins listed by the ReleasePlanAdmission, merging the Origin and Origins
+// fields. Origins selected by label are not included.
+func (rpa *ReleasePlanAdmission) GetOrigins() []string {
+	return mergeNames(rpa.Spec.Origin, rpa.Spec.Origins)
+}
+
+// GetOriginIndexValues returns the values used to index the ReleasePlanAdmission by origin. Along with the names of
+// the origins, OriginSelectorIndexValue is included if the ReleasePlanAdmission selects origins by label, so
+// those ReleasePlanAdmissions can also be found through the index.
+func (rpa *ReleasePlanAdmission) GetOriginIndexValues() []string {
+	values := rpa.GetOrigins()
+	if rpa.Spec.OriginSelector != nil {
+		values = append(values, OriginSelectorIndexValue)
+	}
+
+	return values
+}
+
+// MatchesApplication checks whether the ReleasePlanAdmission admits releases of the given application.
+func (rpa *ReleasePlanAdmission) MatchesApplication(application string) bool {
+	for _, name := range rpa.GetApplications() {
+		if name == application {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchesOrigin checks whether the ReleasePlanAdmission admits releases coming from the given namespace, either
+// because it's listed by name or because its labels match the origin selector.
+func (rpa *ReleasePlanAdmission) MatchesOrigin(namespace *corev1.Namespace) bool {
+	for _, name := range rpa.GetOrigins() {
+		if name == namespace.Name {
+			return true
+		}
+	}
+
+	if rpa.Spec.OriginSelector == nil {
+		return false
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(rpa.Spec.OriginSelector)
+	if err != nil {
+		return false
+	}
+
+	return selector.Matches(labels.Set(namespace.Labels))
+}
+
+// IsEnabled checks whether the ReleasePlanAdmission has the auto-release label set to true or missing, which is
+// treated the same as having the label set to true.
+func (rpa *ReleasePlanAdmission) IsEnabled() bool {
+	return rpa.GetLabels()[AutoReleaseLabel] != "false"
+}
+
+// IsValid checks whether the ReleasePlanAdmission has been found to be valid.
+func (rpa *ReleasePlanAdmission) IsValid() bool {
+	return meta.IsStatusConditionTrue(rpa.Status.Conditions, releasePlanAdmissionConditionType)
+}
+
+// MarkInvalid changes the Valid condition to False with the provided reason and message.
+func (rpa *ReleasePlanAdmission) MarkInvalid(reason ReleasePlanAdmissionReason, message string) {
+	meta.SetStatusCondition(&rpa.Status.Conditions, metav1.Condition{
+		Type:               releasePlanAdmissionConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason.String(),
+		Message:            message,
+		ObservedGeneration: rpa.Generation,
+	})
+}
+
+// MarkValid changes the Valid condition to True.
+func (rpa *ReleasePlanAdmission) MarkValid() {
+	meta.SetStatusCondition(&rpa.Status.Conditions, metav1.Condition{
+		Type:               releasePlanAdmissionConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReleasePlanAdmissionReasonValid.String(),
+		ObservedGeneration: rpa.Generation,
+	})
+}
+
+// ValidateParams checks that every given param is allowed by the ReleasePlanAdmission and that its values are within
+// the allowed values and match the allowed pattern. An error describing the first offending param is returned otherwise.
+func (rpa *ReleasePlanAdmission) ValidateParams(params []Params) error {
+	for _, param := range params {
+		allowedParam := rpa.getAllowedParam(param.Name)
+		if allowedParam == nil {
+			return fmt.Errorf("param '%s' is not allowed by ReleasePlanAdmission '%s'", param.Name, rpa.Name)
+		}
+
+		values := param.Values
+		if len(values) == 0 {
+			values = []string{param.Value}
+		}
+
+		for _, value := range values {
+			if err := allowedParam.validateValue(value); err != nil {
+				return fmt.Errorf("param '%s' is not allowed by ReleasePlanAdmission '%s': %w", param.Name, rpa.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetComponentConfiguration returns the configuration overrides of the component with the given name or nil if
+// there are none.
+func (rpa *ReleasePlanAdmission) GetComponentConfiguration(name string) *applicationapiv1alpha1.BindingComponentConfiguration {
+	for i := range rpa.Spec.ComponentConfigurations {
+		if rpa.Spec.ComponentConfigurations[i].Name == name {
+			return &rpa.Spec.ComponentConfigurations[i].Configuration
+		}
+	}
+
+	return nil
+}
+
+// getAllowedParam returns the AllowedParam with the given name or nil if the param is not allowed.
+func (rpa *ReleasePlanAdmission) getAllowedParam(name string) *AllowedParam {
+	for i := range rpa.Spec.AllowedParams {
+		if rpa.Spec.AllowedParams[i].Name == name {
+			return &rpa.Spec.AllowedParams[i]
+		}
+	}
+
+	return nil
+}
+
+// validateValue throws an error if the given value is not in the list of allowed values or doesn't match the pattern.
+func (ap *AllowedParam) validateValue(value string) error {
+	if len(ap.Values) > 0 {
+		found := false
+		for _, allowedValue := range ap.Values {
+			if value == allowedValue {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("value '%s' is not one of the allowed values", value)
+		}
+	}
+
+	if ap.Pattern != "" {
+		matched, err := regexp.MatchString(fmt.Sprintf("^(?:%s)$", ap.Pattern), value)
+		if err != nil {
+			return err
+		}
+
+		if !matched {
+			return fmt.Errorf("value '%s' doesn't match the pattern '%s'", value, ap.Pattern)
+		}
+	}
+
+	return nil
+}
+
+// intersects checks whether the given lists share any element.
+func intersects(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// mergeNames returns a list containing the given name, if set, followed by the given names that are not duplicated.
+func mergeNames(name string, names []string) []string {
+	var merged []string
+	seen := map[string]bool{}
+	for _, value := range append([]string{name}, names...) {
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		merged = append(merged, value)
+	}
+
+	return merged
+}
+
 // +kubebuilder:object:root=true
 
 // ReleasePlanAdmissionList contains a list of ReleasePlanAdmission.
@@ -0,0 +1,207 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ReleasePlanAdmission type", func() {
+
+	var rpa *ReleasePlanAdmission
+
+	BeforeEach(func() {
+		rpa = &ReleasePlanAdmission{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "rpa",
+			},
+			Spec: ReleasePlanAdmissionSpec{
+				AllowedParams: []AllowedParam{
+					{Name: "any"},
+					{Name: "values", Values: []string{"foo", "bar"}},
+					{Name: "pattern", Pattern: "v[0-9]+"},
+				},
+			},
+		}
+	})
+
+	Context("When ValidateParams method is called", func() {
+		It("should return nil when no params are passed", func() {
+			Expect(rpa.ValidateParams(nil)).To(Succeed())
+		})
+
+		It("should return nil when all the params are allowed", func() {
+			Expect(rpa.ValidateParams([]Params{
+				{Name: "any", Value: "baz"},
+				{Name: "values", Values: []string{"foo", "bar"}},
+				{Name: "pattern", Value: "v1"},
+			})).To(Succeed())
+		})
+
+		It("should fail when a param is not in the allowlist", func() {
+			err := rpa.ValidateParams([]Params{{Name: "unknown", Value: "foo"}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("param 'unknown' is not allowed by ReleasePlanAdmission 'rpa'"))
+		})
+
+		It("should fail when a param value is not one of the allowed values", func() {
+			err := rpa.ValidateParams([]Params{{Name: "values", Values: []string{"foo", "baz"}}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("value 'baz' is not one of the allowed values"))
+		})
+
+		It("should fail when a param value only partially matches the pattern", func() {
+			err := rpa.ValidateParams([]Params{{Name: "pattern", Value: "v1-beta"}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("value 'v1-beta' doesn't match the pattern 'v[0-9]+'"))
+		})
+	})
+
+	Context("When ConflictsWith method is called", func() {
+		var other *ReleasePlanAdmission
+
+		BeforeEach(func() {
+			rpa.Spec.Application = "app"
+			rpa.Spec.Origins = []string{"foo", "bar"}
+			other = &ReleasePlanAdmission{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "other",
+				},
+				Spec: ReleasePlanAdmissionSpec{
+					Applications: []string{"other-app", "app"},
+					Origin:       "bar",
+				},
+			}
+		})
+
+		It("should return true when applications and origins overlap with the same priority", func() {
+			Expect(rpa.ConflictsWith(other)).To(BeTrue())
+		})
+
+		It("should return false when comparing with itself", func() {
+			Expect(rpa.ConflictsWith(rpa)).To(BeFalse())
+		})
+
+		It("should return false when the priority is different", func() {
+			other.Spec.Priority = 1
+			Expect(rpa.ConflictsWith(other)).To(BeFalse())
+		})
+
+		It("should return false when the origins don't overlap", func() {
+			other.Spec.Origin = "baz"
+			Expect(rpa.ConflictsWith(other)).To(BeFalse())
+		})
+	})
+
+	Context("When GetApplications and GetOrigins methods are called", func() {
+		It("should merge the single and multi-valued fields without duplicates", func() {
+			rpa.Spec.Application = "app"
+			rpa.Spec.Applications = []string{"app", "other-app"}
+			rpa.Spec.Origins = []string{"foo"}
+			Expect(rpa.GetApplications()).To(Equal([]string{"app", "other-app"}))
+			Expect(rpa.GetOrigins()).To(Equal([]string{"foo"}))
+		})
+	})
+
+	Context("When GetOriginIndexValues method is called", func() {
+		It("should include the origin selector index value if origins are selected by label", func() {
+			rpa.Spec.Origin = "foo"
+			rpa.Spec.OriginSelector = &metav1.LabelSelector{}
+			Expect(rpa.GetOriginIndexValues()).To(Equal([]string{"foo", OriginSelectorIndexValue}))
+		})
+	})
+
+	Context("When MatchesOrigin method is called", func() {
+		var namespace *corev1.Namespace
+
+		BeforeEach(func() {
+			namespace = &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "tenant",
+					Labels: map[string]string{"team": "a"},
+				},
+			}
+		})
+
+		It("should return true when the namespace is listed", func() {
+			rpa.Spec.Origins = []string{"foo", "tenant"}
+			Expect(rpa.MatchesOrigin(namespace)).To(BeTrue())
+		})
+
+		It("should return true when the namespace labels match the selector", func() {
+			rpa.Spec.OriginSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+			Expect(rpa.MatchesOrigin(namespace)).To(BeTrue())
+		})
+
+		It("should return false when the namespace is neither listed nor selected", func() {
+			rpa.Spec.Origin = "foo"
+			rpa.Spec.OriginSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}
+			Expect(rpa.MatchesOrigin(namespace)).To(BeFalse())
+		})
+	})
+
+	Context("When GetComponentConfiguration method is called", func() {
+		BeforeEach(func() {
+			rpa.Spec.ComponentConfigurations = []ComponentConfiguration{
+				{Name: "foo", Configuration: applicationapiv1alpha1.BindingComponentConfiguration{Replicas: 3}},
+			}
+		})
+
+		It("should return the configuration of the component", func() {
+			Expect(rpa.GetComponentConfiguration("foo")).To(Equal(&rpa.Spec.ComponentConfigurations[0].Configuration))
+		})
+
+		It("should return nil when the component has no configuration", func() {
+			Expect(rpa.GetComponentConfiguration("bar")).To(BeNil())
+		})
+	})
+
+	Context("When the Environments are retrieved", func() {
+		BeforeEach(func() {
+			rpa.Spec.Environment = "dev"
+			rpa.Spec.Environments = []EnvironmentStep{
+				{Name: "stage", Gate: &EnvironmentGate{WaitForDeployment: true}},
+				{Name: "dev"},
+				{Name: "prod"},
+			}
+		})
+
+		It("should merge Environment and Environments in order when GetEnvironments is called", func() {
+			Expect(rpa.GetEnvironments()).To(Equal([]EnvironmentStep{
+				{Name: "dev"},
+				{Name: "stage", Gate: &EnvironmentGate{WaitForDeployment: true}},
+				{Name: "prod"},
+			}))
+		})
+
+		It("should return the following Environment when GetNextEnvironment is called", func() {
+			Expect(rpa.GetNextEnvironment("stage").Name).To(Equal("prod"))
+			Expect(rpa.GetNextEnvironment("prod")).To(BeNil())
+			Expect(rpa.GetNextEnvironment("unknown")).To(BeNil())
+		})
+
+		It("should return the gate of the Environment when GetEnvironmentGate is called", func() {
+			Expect(rpa.GetEnvironmentGate("stage")).To(Equal(&EnvironmentGate{WaitForDeployment: true}))
+			Expect(rpa.GetEnvironmentGate("dev")).To(BeNil())
+		})
+	})
+})
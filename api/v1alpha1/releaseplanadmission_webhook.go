@@ -17,13 +17,25 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"fmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"reflect"
+	"regexp"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"strings"
 )
 
+// releasePlanAdmissionClient is the client used by the ReleasePlanAdmission webhook to look for other
+// ReleasePlanAdmissions sharing the same origin and application. It relies on the "spec.origin" cache index.
+var releasePlanAdmissionClient client.Reader
+
 func (rp *ReleasePlanAdmission) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	releasePlanAdmissionClient = mgr.GetClient()
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(rp).
 		Complete()
@@ -50,12 +62,29 @@ var _ webhook.Validator = &ReleasePlanAdmission{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (rp *ReleasePlanAdmission) ValidateCreate() error {
-	return rp.validateAutoReleaseLabel()
+	if err := rp.validate(); err != nil {
+		return err
+	}
+
+	return rp.validateUniqueness()
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type. The uniqueness is only
+// validated when the origins, the applications or the priority change, so already conflicting ReleasePlanAdmissions can
+// still be updated to fix the conflict.
 func (rp *ReleasePlanAdmission) ValidateUpdate(old runtime.Object) error {
-	return rp.validateAutoReleaseLabel()
+	if err := rp.validate(); err != nil {
+		return err
+	}
+
+	oldReleasePlanAdmission, ok := old.(*ReleasePlanAdmission)
+	if ok && reflect.DeepEqual(oldReleasePlanAdmission.GetOrigins(), rp.GetOrigins()) &&
+		reflect.DeepEqual(oldReleasePlanAdmission.GetApplications(), rp.GetApplications()) &&
+		oldReleasePlanAdmission.Spec.Priority == rp.Spec.Priority {
+		return nil
+	}
+
+	return rp.validateUniqueness()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -63,6 +92,39 @@ func (rp *ReleasePlanAdmission) ValidateDelete() error {
 	return nil
 }
 
+// validate throws an error if any of the checks shared by the creation and the update of a ReleasePlanAdmission fails.
+func (rp *ReleasePlanAdmission) validate() error {
+	if err := rp.validateAutoReleaseLabel(); err != nil {
+		return err
+	}
+
+	if err := rp.validateApplicationsAndOrigins(); err != nil {
+		return err
+	}
+
+	if err := rp.validateAllowedParams(); err != nil {
+		return err
+	}
+
+	if err := rp.validateDeploymentTimeout(); err != nil {
+		return err
+	}
+
+	if err := rp.validateComponentConfigurations(); err != nil {
+		return err
+	}
+
+	if err := rp.validateEnvironments(); err != nil {
+		return err
+	}
+
+	if err := rp.validateVerificationPipeline(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // validateAutoReleaseLabel throws an error if the auto-release label value is set to anything besides true or false.
 func (rp *ReleasePlanAdmission) validateAutoReleaseLabel() error {
 	if value, found := rp.GetLabels()[AutoReleaseLabel]; found {
@@ -72,3 +134,148 @@ func (rp *ReleasePlanAdmission) validateAutoReleaseLabel() error {
 	}
 	return nil
 }
+
+// validateApplicationsAndOrigins throws an error if no application is admitted, if no origin is listed or selected
+// or if the origin selector is not valid.
+func (rp *ReleasePlanAdmission) validateApplicationsAndOrigins() error {
+	if len(rp.GetApplications()) == 0 {
+		return fmt.Errorf("either application or applications has to be set")
+	}
+
+	if len(rp.GetOrigins()) == 0 && rp.Spec.OriginSelector == nil {
+		return fmt.Errorf("either origin, origins or originSelector has to be set")
+	}
+
+	if rp.Spec.OriginSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(rp.Spec.OriginSelector); err != nil {
+			return fmt.Errorf("originSelector is not valid: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateAllowedParams throws an error if an allowed param is defined more than once or its pattern is not a valid
+// regular expression.
+func (rp *ReleasePlanAdmission) validateAllowedParams() error {
+	names := map[string]bool{}
+	for _, allowedParam := range rp.Spec.AllowedParams {
+		if names[allowedParam.Name] {
+			return fmt.Errorf("allowed param '%s' is defined more than once", allowedParam.Name)
+		}
+		names[allowedParam.Name] = true
+
+		if _, err := regexp.Compile(allowedParam.Pattern); err != nil {
+			return fmt.Errorf("allowed param '%s' has an invalid pattern: %w", allowedParam.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateDeploymentTimeout throws an error if the deployment timeout is set but it's not a positive duration.
+func (rp *ReleasePlanAdmission) validateDeploymentTimeout() error {
+	if rp.Spec.DeploymentTimeout != nil && rp.Spec.DeploymentTimeout.Duration <= 0 {
+		return fmt.Errorf("deploymentTimeout has to be a positive duration")
+	}
+
+	return nil
+}
+
+// validateComponentConfigurations throws an error if the configuration of a component is defined more than once or
+// any of the configuration overrides sets a negative number of replicas.
+func (rp *ReleasePlanAdmission) validateComponentConfigurations() error {
+	if rp.Spec.DefaultComponentConfiguration != nil && rp.Spec.DefaultComponentConfiguration.Replicas < 0 {
+		return fmt.Errorf("defaultComponentConfiguration can't set a negative number of replicas")
+	}
+
+	names := map[string]bool{}
+	for _, componentConfiguration := range rp.Spec.ComponentConfigurations {
+		if names[componentConfiguration.Name] {
+			return fmt.Errorf("the configuration of the component '%s' is defined more than once", componentConfiguration.Name)
+		}
+		names[componentConfiguration.Name] = true
+
+		if componentConfiguration.Configuration.Replicas < 0 {
+			return fmt.Errorf("the configuration of the component '%s' can't set a negative number of replicas",
+				componentConfiguration.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateEnvironments throws an error if an Environment is deployed to more than once or the soak duration of its gate
+// is negative.
+func (rp *ReleasePlanAdmission) validateEnvironments() error {
+	names := map[string]bool{rp.Spec.Environment: rp.Spec.Environment != ""}
+	for _, environment := range rp.Spec.Environments {
+		if names[environment.Name] {
+			return fmt.Errorf("environment '%s' is listed more than once", environment.Name)
+		}
+		names[environment.Name] = true
+
+		if environment.Gate != nil && environment.Gate.SoakDuration != nil && environment.Gate.SoakDuration.Duration < 0 {
+			return fmt.Errorf("the soakDuration of the environment '%s' can't be negative", environment.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateVerificationPipeline throws an error if a verification Pipeline is set without any Environment to deploy to
+// or any of its params takes its value from another resource, as only literal values can be passed to it.
+func (rp *ReleasePlanAdmission) validateVerificationPipeline() error {
+	if rp.Spec.VerificationPipeline == nil {
+		return nil
+	}
+
+	if len(rp.GetEnvironments()) == 0 {
+		return fmt.Errorf("verificationPipeline can't be set without an environment to deploy to")
+	}
+
+	for _, param := range rp.Spec.VerificationPipeline.Params {
+		if param.ValueFrom != nil {
+			return fmt.Errorf("the verificationPipeline param '%s' can't take its value from another resource", param.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateUniqueness throws an error if other ReleasePlanAdmissions in the same namespace admit releases of any of
+// the same applications from any of the same origins with the same priority, as it wouldn't be possible to determine
+// which one should be used.
+func (rp *ReleasePlanAdmission) validateUniqueness() error {
+	if releasePlanAdmissionClient == nil {
+		return nil
+	}
+
+	var names []string
+	found := map[string]bool{}
+	for _, origin := range rp.GetOrigins() {
+		releasePlanAdmissions := &ReleasePlanAdmissionList{}
+		err := releasePlanAdmissionClient.List(context.Background(), releasePlanAdmissions,
+			client.InNamespace(rp.Namespace),
+			client.MatchingFields{"spec.origin": origin})
+		if err != nil {
+			return err
+		}
+
+		for i := range releasePlanAdmissions.Items {
+			name := releasePlanAdmissions.Items[i].Name
+			if !found[name] && rp.ConflictsWith(&releasePlanAdmissions.Items[i]) {
+				found[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	if len(names) > 0 {
+		return fmt.Errorf("the ReleasePlanAdmissions %s already admit releases of the applications '%s' from the "+
+			"origins '%s' with priority %d", strings.Join(names, ", "), strings.Join(rp.GetApplications(), ", "),
+			strings.Join(rp.GetOrigins(), ", "), rp.Spec.Priority)
+	}
+
+	return nil
+}
@@ -16,11 +16,15 @@
 package v1alpha1
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	//+kubebuilder:scaffold:imports
 )
@@ -124,6 +128,210 @@ var _ = Describe("ReleasePlanAdmission webhook", func() {
 		})
 	})
 
+	Context("When a ReleasePlanAdmission is created with an allowed param defined twice", func() {
+		It("should get rejected", func() {
+			releasePlanAdmission.Spec.AllowedParams = []AllowedParam{{Name: "foo"}, {Name: "foo"}}
+			err := k8sClient.Create(ctx, releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("allowed param 'foo' is defined more than once"))
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created with an allowed param using an invalid pattern", func() {
+		It("should get rejected", func() {
+			releasePlanAdmission.Spec.AllowedParams = []AllowedParam{{Name: "foo", Pattern: "[a-z"}}
+			err := k8sClient.Create(ctx, releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("allowed param 'foo' has an invalid pattern"))
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created with a negative deployment timeout", func() {
+		It("should get rejected", func() {
+			releasePlanAdmission.Spec.DeploymentTimeout = &metav1.Duration{Duration: -time.Minute}
+			err := k8sClient.Create(ctx, releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("deploymentTimeout has to be a positive duration"))
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created with the configuration of a component defined twice", func() {
+		It("should get rejected", func() {
+			releasePlanAdmission.Spec.ComponentConfigurations = []ComponentConfiguration{
+				{Name: "component", Configuration: applicationapiv1alpha1.BindingComponentConfiguration{Replicas: 2}},
+				{Name: "component", Configuration: applicationapiv1alpha1.BindingComponentConfiguration{Replicas: 3}},
+			}
+			err := k8sClient.Create(ctx, releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the configuration of the component 'component' is defined more than once"))
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created with a component configuration setting negative replicas", func() {
+		It("should get rejected", func() {
+			releasePlanAdmission.Spec.ComponentConfigurations = []ComponentConfiguration{
+				{Name: "component", Configuration: applicationapiv1alpha1.BindingComponentConfiguration{Replicas: -1}},
+			}
+			err := k8sClient.Create(ctx, releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the configuration of the component 'component' can't set a negative number of replicas"))
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created with an environment listed twice", func() {
+		It("should get rejected", func() {
+			releasePlanAdmission.Spec.Environment = "stage"
+			releasePlanAdmission.Spec.Environments = []EnvironmentStep{{Name: "stage"}, {Name: "prod"}}
+			err := k8sClient.Create(ctx, releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("environment 'stage' is listed more than once"))
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created with a negative soak duration", func() {
+		It("should get rejected", func() {
+			releasePlanAdmission.Spec.Environments = []EnvironmentStep{
+				{Name: "stage", Gate: &EnvironmentGate{SoakDuration: &metav1.Duration{Duration: -time.Minute}}},
+			}
+			err := k8sClient.Create(ctx, releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the soakDuration of the environment 'stage' can't be negative"))
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created with a verification Pipeline and no environment", func() {
+		It("should get rejected", func() {
+			releasePlanAdmission.Spec.Environment = ""
+			releasePlanAdmission.Spec.VerificationPipeline = &VerificationPipeline{
+				PipelineRef: PipelineRef{Resolver: "cluster"},
+			}
+			err := k8sClient.Create(ctx, releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("verificationPipeline can't be set without an environment to deploy to"))
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created with a verification Pipeline param taking its value from a ConfigMap", func() {
+		It("should get rejected", func() {
+			releasePlanAdmission.Spec.VerificationPipeline = &VerificationPipeline{
+				PipelineRef: PipelineRef{Resolver: "cluster"},
+				Params: []Params{
+					{
+						Name: "url",
+						ValueFrom: &ParamValueSource{
+							ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "config"},
+								Key:                  "url",
+							},
+						},
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the verificationPipeline param 'url' can't take its value from another resource"))
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created without applications", func() {
+		It("should get rejected", func() {
+			releasePlanAdmission.Spec.Application = ""
+			err := k8sClient.Create(ctx, releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("either application or applications has to be set"))
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created without origins", func() {
+		It("should get rejected", func() {
+			releasePlanAdmission.Spec.Origin = ""
+			err := k8sClient.Create(ctx, releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("either origin, origins or originSelector has to be set"))
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created with multiple applications and an origin selector", func() {
+		It("should be admitted", func() {
+			releasePlanAdmission.Spec.Application = ""
+			releasePlanAdmission.Spec.Applications = []string{"application", "other-application"}
+			releasePlanAdmission.Spec.Origin = ""
+			releasePlanAdmission.Spec.OriginSelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"tenant": "true"},
+			}
+			Expect(k8sClient.Create(ctx, releasePlanAdmission)).Should(Succeed())
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created with an invalid origin selector", func() {
+		It("should get rejected", func() {
+			releasePlanAdmission.Spec.OriginSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Foo"}},
+			}
+			err := k8sClient.Create(ctx, releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("originSelector is not valid"))
+		})
+	})
+
+	Context("When a ReleasePlanAdmission is created with the same origin and application as an existing one", func() {
+		var conflictingReleasePlanAdmission *ReleasePlanAdmission
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, releasePlanAdmission)).Should(Succeed())
+
+			conflictingReleasePlanAdmission = releasePlanAdmission.DeepCopy()
+			conflictingReleasePlanAdmission.ObjectMeta = metav1.ObjectMeta{
+				Name:      "conflicting-releaseplanadmission",
+				Namespace: releasePlanAdmission.Namespace,
+			}
+		})
+
+		AfterEach(func() {
+			err := k8sClient.Delete(ctx, conflictingReleasePlanAdmission)
+			Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should get rejected", func() {
+			Eventually(func() error {
+				return k8sClient.Create(ctx, conflictingReleasePlanAdmission)
+			}, timeout).Should(MatchError(ContainSubstring("the ReleasePlanAdmissions releaseplanadmission already " +
+				"admit releases of the applications 'application' from the origins 'default'")))
+		})
+
+		It("should be admitted if the application is different", func() {
+			conflictingReleasePlanAdmission.Spec.Application = "other-application"
+			Expect(k8sClient.Create(ctx, conflictingReleasePlanAdmission)).Should(Succeed())
+		})
+
+		It("should be admitted if the priority is different", func() {
+			conflictingReleasePlanAdmission.Spec.Priority = releasePlanAdmission.Spec.Priority + 1
+			Expect(k8sClient.Create(ctx, conflictingReleasePlanAdmission)).Should(Succeed())
+		})
+
+		It("should get rejected when an update makes it conflict", func() {
+			conflictingReleasePlanAdmission.Spec.Application = "other-application"
+			Expect(k8sClient.Create(ctx, conflictingReleasePlanAdmission)).Should(Succeed())
+
+			conflictingReleasePlanAdmission.Spec.Application = releasePlanAdmission.Spec.Application
+			Eventually(func() error {
+				return k8sClient.Update(ctx, conflictingReleasePlanAdmission)
+			}, timeout).Should(MatchError(ContainSubstring("already admit releases of the applications 'application'")))
+		})
+
+		It("should get rejected if any of its applications and origins overlap", func() {
+			conflictingReleasePlanAdmission.Spec.Application = ""
+			conflictingReleasePlanAdmission.Spec.Applications = []string{"other-application", "application"}
+			conflictingReleasePlanAdmission.Spec.Origin = ""
+			conflictingReleasePlanAdmission.Spec.Origins = []string{"other-origin", "default"}
+			Eventually(func() error {
+				return k8sClient.Create(ctx, conflictingReleasePlanAdmission)
+			}, timeout).Should(MatchError(ContainSubstring("the ReleasePlanAdmissions releaseplanadmission already " +
+				"admit releases of the applications 'other-application, application' from the origins " +
+				"'other-origin, default'")))
+		})
+	})
+
 	Describe("When ValidateDelete method is called", func() {
 		It("should return nil", func() {
 			releaseplanadmission := &ReleasePlanAdmission{}
@@ -17,39 +17,129 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ReleaseStrategySpec defines the desired state of ReleaseStrategy
 type ReleaseStrategySpec struct {
-	// Release Tekton Pipeline to execute
+	// ClusterReleaseStrategy is the name of a ClusterReleaseStrategy to inherit from. The fields set in the
+	// ReleaseStrategy override the inherited ones, with params, workspaces and task overrides merged by name
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
-	// +required
-	Pipeline string `json:"pipeline"`
+	// +optional
+	ClusterReleaseStrategy string `json:"clusterReleaseStrategy,omitempty"`
 
-	// Bundle is a reference to the Tekton bundle where to find the pipeline
+	// Release Tekton Pipeline to execute. Either Pipeline or PipelineRef has to be set unless inherited
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	Pipeline string `json:"pipeline,omitempty"`
+
+	// Bundle is a reference to the Tekton bundle where to find the pipeline. Deprecated: use PipelineRef with the
+	// bundles resolver instead
 	// +optional
 	Bundle string `json:"bundle,omitempty"`
 
+	// PipelineRef is a reference to the release Tekton Pipeline to be fetched by a Tekton remote resolver
+	// +optional
+	PipelineRef *PipelineRef `json:"pipelineRef,omitempty"`
+
 	// Params to pass to the pipeline
 	// +optional
 	Params []Params `json:"params,omitempty"`
 
-	// Policy to validate before releasing an artifact
+	// Policy to validate before releasing an artifact. It has to be set unless inherited
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
-	// +required
-	Policy string `json:"policy"`
+	// +optional
+	Policy string `json:"policy,omitempty"`
 
-	// PersistentVolumeClaim is the pvc to use in the Release pipeline namespace
+	// PersistentVolumeClaim is the pvc to use in the Release pipeline namespace. It will be bound to a workspace
+	// named release-workspace. Deprecated: use Workspaces instead
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +optional
 	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
 
+	// Workspaces is the list of workspaces to bind to the release PipelineRun
+	// +optional
+	Workspaces []Workspace `json:"workspaces,omitempty"`
+
 	// ServiceAccount is the name of the service account to use in the
 	// release PipelineRun to gain elevated privileges
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +optional
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// PodTemplate holds the scheduling and security settings for the pods of the release PipelineRun
+	// +optional
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+
+	// TaskRunSpecs holds the settings to override for specific tasks of the release Pipeline
+	// +optional
+	TaskRunSpecs []TaskRunSpec `json:"taskRunSpecs,omitempty"`
+}
+
+// PodTemplate holds the settings used to schedule and run the pods of the release PipelineRun
+type PodTemplate struct {
+	// NodeSelector is a selector which must be true for the pods to fit on a node
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are the pods' tolerations
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity holds the pods' scheduling constraints
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// SecurityContext holds the pod-level security attributes and common container settings
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass to assign to the pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// TaskRunSpec holds the settings to override for a specific task of the release Pipeline
+type TaskRunSpec struct {
+	// PipelineTaskName is the name of the task in the release Pipeline
+	// +required
+	PipelineTaskName string `json:"pipelineTaskName"`
+
+	// ServiceAccount is the name of the service account to use for the task instead of the PipelineRun one
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// ComputeResources are the compute resources to request for the task
+	// +optional
+	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
+}
+
+// PipelineRef holds the information needed by a Tekton remote resolver to fetch the release Pipeline
+type PipelineRef struct {
+	// Resolver is the name of the Tekton resolver to be used (e.g. bundles, git, cluster, hub)
+	// +kubebuilder:validation:Enum=bundles;git;cluster;hub
+	// +required
+	Resolver string `json:"resolver"`
+
+	// Params is the list of parameters passed to the resolver to locate the release Pipeline
+	// +optional
+	Params []ResolverParam `json:"params,omitempty"`
+}
+
+// ResolverParam holds the name and value of a parameter passed to a Tekton remote resolver
+type ResolverParam struct {
+	// Name is the name of the parameter
+	// +required
+	Name string `json:"name"`
+
+	// Value is the value of the parameter
+	// +required
+	Value string `json:"value"`
 }
 
 // Params holds the definition of a parameter that should be passed to the release Pipeline
@@ -62,14 +152,138 @@ type Params struct {
 
 	// Values is a list of values for the parameter
 	Values []string `json:"values,omitempty"`
+
+	// ValueFrom is a reference to a source in the ReleaseStrategy namespace the value of the parameter
+	// should be taken from
+	// +optional
+	ValueFrom *ParamValueSource `json:"valueFrom,omitempty"`
+}
+
+// ParamValueSource represents a source for the value of a parameter. Only one of its fields may be set.
+type ParamValueSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap. The value of that key will be passed to the release Pipeline
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret. To avoid exposing its content in the PipelineRun, the value of the
+	// key is never passed to the release Pipeline. The name of the Secret is passed instead, so it can be mounted
+	// by the Pipeline tasks
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// Workspace defines a workspace to be bound to the release PipelineRun. Only one of its volume sources may be set.
+type Workspace struct {
+	// Name is the name of the workspace declared by the release Pipeline
+	// +required
+	Name string `json:"name"`
+
+	// SubPath is a directory within the volume to use as the root of the workspace
+	// +optional
+	SubPath string `json:"subPath,omitempty"`
+
+	// PersistentVolumeClaim is a reference to an existing PersistentVolumeClaim in the release Pipeline namespace
+	// +optional
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+
+	// VolumeClaimTemplate is a template for a PersistentVolumeClaim that will be created for each release PipelineRun
+	// and deleted along with it
+	// +optional
+	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
+
+	// EmptyDir is a temporary directory that shares the release PipelineRun lifetime
+	// +optional
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+
+	// ConfigMap is a ConfigMap in the release Pipeline namespace to be mounted as a read-only workspace
+	// +optional
+	ConfigMap *corev1.ConfigMapVolumeSource `json:"configMap,omitempty"`
+
+	// Secret is a Secret in the release Pipeline namespace to be mounted as a read-only workspace
+	// +optional
+	Secret *corev1.SecretVolumeSource `json:"secret,omitempty"`
+
+	// CSI is a volume provided by a CSI driver
+	// +optional
+	CSI *corev1.CSIVolumeSource `json:"csi,omitempty"`
+}
+
+// Validate throws an error if the workspace doesn't set exactly one volume source.
+func (w *Workspace) Validate() error {
+	sources := 0
+	for _, isSet := range []bool{
+		w.PersistentVolumeClaim != nil,
+		w.VolumeClaimTemplate != nil,
+		w.EmptyDir != nil,
+		w.ConfigMap != nil,
+		w.Secret != nil,
+		w.CSI != nil,
+	} {
+		if isSet {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		return fmt.Errorf("workspace '%s' must set exactly one volume source, found %d", w.Name, sources)
+	}
+
+	return nil
+}
+
+// ReleaseStrategyReason represents a reason for the ReleaseStrategy "Valid" condition.
+type ReleaseStrategyReason string
+
+const (
+	// DefaultReleaseWorkspaceName is the name of the workspace the deprecated PersistentVolumeClaim field is bound to
+	DefaultReleaseWorkspaceName string = "release-workspace"
+
+	// releaseStrategyConditionType is the type used when setting a ReleaseStrategy status condition
+	releaseStrategyConditionType string = "Valid"
+
+	// ReleaseStrategyReasonInheritanceError is the reason set when the ClusterReleaseStrategy to inherit from can't be found
+	ReleaseStrategyReasonInheritanceError ReleaseStrategyReason = "InheritanceError"
+
+	// ReleaseStrategyReasonParamMismatchError is the reason set when the params don't match the ones declared by the
+	// release Pipeline
+	ReleaseStrategyReasonParamMismatchError ReleaseStrategyReason = "ParamMismatchError"
+
+	// ReleaseStrategyReasonParamReferenceError is the reason set when a param references a missing source
+	ReleaseStrategyReasonParamReferenceError ReleaseStrategyReason = "ParamReferenceError"
+
+	// ReleaseStrategyReasonPipelineError is the reason set when the release Pipeline can't be resolved
+	ReleaseStrategyReasonPipelineError ReleaseStrategyReason = "PipelineError"
+
+	// ReleaseStrategyReasonPolicyError is the reason set when the EnterpriseContractPolicy can't be found
+	ReleaseStrategyReasonPolicyError ReleaseStrategyReason = "PolicyError"
+
+	// ReleaseStrategyReasonValidating is the reason set while the release Pipeline is being resolved
+	ReleaseStrategyReasonValidating ReleaseStrategyReason = "Validating"
+
+	// ReleaseStrategyReasonWorkspaceError is the reason set when a workspace is not properly defined
+	ReleaseStrategyReasonWorkspaceError ReleaseStrategyReason = "WorkspaceError"
+
+	// ReleaseStrategyReasonValid is the reason set when the ReleaseStrategy is found to be valid
+	ReleaseStrategyReasonValid ReleaseStrategyReason = "Valid"
+)
+
+func (rr ReleaseStrategyReason) String() string {
+	return string(rr)
 }
 
 // ReleaseStrategyStatus defines the observed state of ReleaseStrategy
 type ReleaseStrategyStatus struct {
+	// Conditions represent the latest available observations for the ReleaseStrategy
+	// +optional
+	Conditions []metav1.Condition `json:"conditions"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Pipeline",type=string,JSONPath=`.spec.pipeline`
+//+kubebuilder:printcolumn:name="Resolver",type=string,JSONPath=`.spec.pipelineRef.resolver`
+//+kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].reason`
 
 // ReleaseStrategy is the Schema for the releasestrategies API
 type ReleaseStrategy struct {
@@ -80,6 +294,135 @@ type ReleaseStrategy struct {
 	Status ReleaseStrategyStatus `json:"status,omitempty"`
 }
 
+// GetWorkspaces returns the workspaces to bind to the release PipelineRun. For backwards compatibility, if the
+// deprecated PersistentVolumeClaim field is set, it will be returned as a workspace named release-workspace unless
+// a workspace with that name is already declared.
+func (rs *ReleaseStrategy) GetWorkspaces() []Workspace {
+	workspaces := rs.Spec.Workspaces
+	if rs.Spec.PersistentVolumeClaim == "" {
+		return workspaces
+	}
+
+	if containsWorkspace(workspaces, DefaultReleaseWorkspaceName) {
+		return workspaces
+	}
+
+	return append(append([]Workspace{}, workspaces...), Workspace{
+		Name: DefaultReleaseWorkspaceName,
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: rs.Spec.PersistentVolumeClaim,
+		},
+	})
+}
+
+// ValidateWorkspaces throws an error if any of the workspaces is declared more than once or doesn't set exactly one
+// volume source.
+func (rs *ReleaseStrategy) ValidateWorkspaces() error {
+	names := map[string]bool{}
+	for _, workspace := range rs.GetWorkspaces() {
+		if names[workspace.Name] {
+			return fmt.Errorf("workspace '%s' is declared more than once", workspace.Name)
+		}
+		names[workspace.Name] = true
+
+		if err := workspace.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InheritFrom returns the effective ReleaseStrategy resulting from overriding the spec of the given
+// ClusterReleaseStrategy with the fields set in this ReleaseStrategy. The Pipeline reference is overridden as a
+// whole, while params, workspaces and task overrides are merged by name.
+func (rs *ReleaseStrategy) InheritFrom(clusterReleaseStrategy *ClusterReleaseStrategy) *ReleaseStrategy {
+	effectiveReleaseStrategy := rs.DeepCopy()
+	spec := &effectiveReleaseStrategy.Spec
+	parentSpec := clusterReleaseStrategy.Spec.DeepCopy()
+
+	spec.ClusterReleaseStrategy = clusterReleaseStrategy.Name
+
+	if spec.Pipeline == "" && spec.PipelineRef == nil {
+		spec.Pipeline = parentSpec.Pipeline
+		spec.Bundle = parentSpec.Bundle
+		spec.PipelineRef = parentSpec.PipelineRef
+	}
+
+	if spec.Policy == "" {
+		spec.Policy = parentSpec.Policy
+	}
+
+	if spec.PersistentVolumeClaim == "" {
+		spec.PersistentVolumeClaim = parentSpec.PersistentVolumeClaim
+	}
+
+	if spec.ServiceAccount == "" {
+		spec.ServiceAccount = parentSpec.ServiceAccount
+	}
+
+	if spec.PodTemplate == nil {
+		spec.PodTemplate = parentSpec.PodTemplate
+	}
+
+	for _, param := range parentSpec.Params {
+		if !containsParam(spec.Params, param.Name) {
+			spec.Params = append(spec.Params, param)
+		}
+	}
+
+	for _, workspace := range parentSpec.Workspaces {
+		if !containsWorkspace(spec.Workspaces, workspace.Name) {
+			spec.Workspaces = append(spec.Workspaces, workspace)
+		}
+	}
+
+	for _, taskRunSpec := range parentSpec.TaskRunSpecs {
+		if !containsTaskRunSpec(spec.TaskRunSpecs, taskRunSpec.PipelineTaskName) {
+			spec.TaskRunSpecs = append(spec.TaskRunSpecs, taskRunSpec)
+		}
+	}
+
+	return effectiveReleaseStrategy
+}
+
+// IsValid checks whether the ReleaseStrategy has been found to be valid.
+func (rs *ReleaseStrategy) IsValid() bool {
+	return meta.IsStatusConditionTrue(rs.Status.Conditions, releaseStrategyConditionType)
+}
+
+// MarkInvalid changes the Valid condition to False with the provided reason and message.
+func (rs *ReleaseStrategy) MarkInvalid(reason ReleaseStrategyReason, message string) {
+	meta.SetStatusCondition(&rs.Status.Conditions, metav1.Condition{
+		Type:               releaseStrategyConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason.String(),
+		Message:            message,
+		ObservedGeneration: rs.Generation,
+	})
+}
+
+// MarkValidating changes the Valid condition to Unknown, so it's known that the validation hasn't finished yet.
+func (rs *ReleaseStrategy) MarkValidating(message string) {
+	meta.SetStatusCondition(&rs.Status.Conditions, metav1.Condition{
+		Type:               releaseStrategyConditionType,
+		Status:             metav1.ConditionUnknown,
+		Reason:             ReleaseStrategyReasonValidating.String(),
+		Message:            message,
+		ObservedGeneration: rs.Generation,
+	})
+}
+
+// MarkValid changes the Valid condition to True.
+func (rs *ReleaseStrategy) MarkValid() {
+	meta.SetStatusCondition(&rs.Status.Conditions, metav1.Condition{
+		Type:               releaseStrategyConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReleaseStrategyReasonValid.String(),
+		ObservedGeneration: rs.Generation,
+	})
+}
+
 //+kubebuilder:object:root=true
 
 // ReleaseStrategyList contains a list of ReleaseStrategy
@@ -92,3 +435,36 @@ type ReleaseStrategyList struct {
 func init() {
 	SchemeBuilder.Register(&ReleaseStrategy{}, &ReleaseStrategyList{})
 }
+
+// containsParam returns a boolean indicating whether a param with the given name is in the list.
+func containsParam(params []Params, name string) bool {
+	for _, param := range params {
+		if param.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsTaskRunSpec returns a boolean indicating whether a TaskRunSpec for the given task is in the list.
+func containsTaskRunSpec(taskRunSpecs []TaskRunSpec, pipelineTaskName string) bool {
+	for _, taskRunSpec := range taskRunSpecs {
+		if taskRunSpec.PipelineTaskName == pipelineTaskName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsWorkspace returns a boolean indicating whether a workspace with the given name is in the list.
+func containsWorkspace(workspaces []Workspace, name string) bool {
+	for _, workspace := range workspaces {
+		if workspace.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
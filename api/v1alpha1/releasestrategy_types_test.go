@@ -0,0 +1,187 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ReleaseStrategy type", func() {
+
+	var rs *ReleaseStrategy
+
+	BeforeEach(func() {
+		rs = &ReleaseStrategy{}
+		rs.Generation = 2
+	})
+
+	Context("When ReleaseStrategyReason.String method is called", func() {
+		It("should return the string representation", func() {
+			Expect(ReleaseStrategyReasonValid.String()).To(Equal("Valid"))
+		})
+	})
+
+	Context("When IsValid method is called", func() {
+		It("should return false when the Valid condition is missing", func() {
+			Expect(rs.IsValid()).To(BeFalse())
+		})
+
+		It("should return true when the Valid condition status is True", func() {
+			rs.MarkValid()
+			Expect(rs.IsValid()).To(BeTrue())
+		})
+	})
+
+	Context("When MarkInvalid method is called", func() {
+		It("should set the Valid condition to False with the given reason and message", func() {
+			rs.MarkInvalid(ReleaseStrategyReasonParamReferenceError, "foo")
+			Expect(rs.IsValid()).To(BeFalse())
+
+			condition := meta.FindStatusCondition(rs.Status.Conditions, releaseStrategyConditionType)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(ReleaseStrategyReasonParamReferenceError.String()))
+			Expect(condition.Message).To(Equal("foo"))
+			Expect(condition.ObservedGeneration).To(Equal(rs.Generation))
+		})
+	})
+
+	Context("When MarkValid method is called", func() {
+		It("should replace a previous False condition", func() {
+			rs.MarkInvalid(ReleaseStrategyReasonParamReferenceError, "foo")
+			rs.MarkValid()
+
+			Expect(rs.Status.Conditions).To(HaveLen(1))
+			Expect(rs.IsValid()).To(BeTrue())
+		})
+	})
+
+	Context("When GetWorkspaces method is called", func() {
+		It("should return the declared workspaces", func() {
+			rs.Spec.Workspaces = []Workspace{{Name: "foo", EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+			Expect(rs.GetWorkspaces()).To(Equal(rs.Spec.Workspaces))
+		})
+
+		It("should add the deprecated PersistentVolumeClaim as the default workspace", func() {
+			rs.Spec.PersistentVolumeClaim = "pvc"
+			rs.Spec.Workspaces = []Workspace{{Name: "foo", EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+
+			workspaces := rs.GetWorkspaces()
+			Expect(workspaces).To(HaveLen(2))
+			Expect(workspaces[1].Name).To(Equal(DefaultReleaseWorkspaceName))
+			Expect(workspaces[1].PersistentVolumeClaim.ClaimName).To(Equal("pvc"))
+			Expect(rs.Spec.Workspaces).To(HaveLen(1))
+		})
+
+		It("should not override a declared workspace with the default name", func() {
+			rs.Spec.PersistentVolumeClaim = "pvc"
+			rs.Spec.Workspaces = []Workspace{{Name: DefaultReleaseWorkspaceName, EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+			Expect(rs.GetWorkspaces()).To(Equal(rs.Spec.Workspaces))
+		})
+	})
+
+	Context("When ValidateWorkspaces method is called", func() {
+		It("should return nil if all the workspaces are well defined", func() {
+			rs.Spec.Workspaces = []Workspace{
+				{Name: "foo", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				{Name: "bar", VolumeClaimTemplate: &corev1.PersistentVolumeClaim{}},
+			}
+			Expect(rs.ValidateWorkspaces()).To(Succeed())
+		})
+
+		It("should fail if a workspace is declared more than once", func() {
+			rs.Spec.Workspaces = []Workspace{
+				{Name: "foo", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				{Name: "foo", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}
+			Expect(rs.ValidateWorkspaces()).To(MatchError("workspace 'foo' is declared more than once"))
+		})
+
+		It("should fail if a workspace doesn't set any volume source", func() {
+			rs.Spec.Workspaces = []Workspace{{Name: "foo"}}
+			Expect(rs.ValidateWorkspaces()).To(MatchError("workspace 'foo' must set exactly one volume source, found 0"))
+		})
+
+		It("should fail if a workspace sets more than one volume source", func() {
+			rs.Spec.Workspaces = []Workspace{
+				{Name: "foo", EmptyDir: &corev1.EmptyDirVolumeSource{}, Secret: &corev1.SecretVolumeSource{}},
+			}
+			Expect(rs.ValidateWorkspaces()).To(MatchError("workspace 'foo' must set exactly one volume source, found 2"))
+		})
+	})
+
+	Context("When InheritFrom method is called", func() {
+		var crs *ClusterReleaseStrategy
+
+		BeforeEach(func() {
+			crs = &ClusterReleaseStrategy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-strategy"},
+				Spec: ReleaseStrategySpec{
+					Pipeline:       "pipeline",
+					Bundle:         "quay.io/foo/bar:baz",
+					Policy:         "policy",
+					ServiceAccount: "service-account",
+					Params:         []Params{{Name: "foo", Value: "bar"}, {Name: "bar", Value: "bar"}},
+					Workspaces:     []Workspace{{Name: "foo", EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					TaskRunSpecs:   []TaskRunSpec{{PipelineTaskName: "push", ServiceAccount: "foo"}},
+				},
+			}
+		})
+
+		It("should inherit all the fields not set in the ReleaseStrategy", func() {
+			effective := rs.InheritFrom(crs)
+			Expect(effective.Spec.ClusterReleaseStrategy).To(Equal(crs.Name))
+			Expect(effective.Spec.Pipeline).To(Equal(crs.Spec.Pipeline))
+			Expect(effective.Spec.Bundle).To(Equal(crs.Spec.Bundle))
+			Expect(effective.Spec.Policy).To(Equal(crs.Spec.Policy))
+			Expect(effective.Spec.ServiceAccount).To(Equal(crs.Spec.ServiceAccount))
+			Expect(effective.Spec.Params).To(Equal(crs.Spec.Params))
+			Expect(effective.Spec.Workspaces).To(Equal(crs.Spec.Workspaces))
+			Expect(effective.Spec.TaskRunSpecs).To(Equal(crs.Spec.TaskRunSpecs))
+		})
+
+		It("should override the Pipeline reference as a whole", func() {
+			rs.Spec.PipelineRef = &PipelineRef{Resolver: "cluster"}
+			effective := rs.InheritFrom(crs)
+			Expect(effective.Spec.Pipeline).To(BeEmpty())
+			Expect(effective.Spec.Bundle).To(BeEmpty())
+			Expect(effective.Spec.PipelineRef).To(Equal(rs.Spec.PipelineRef))
+		})
+
+		It("should merge params, workspaces and task overrides by name", func() {
+			rs.Spec.Params = []Params{{Name: "foo", Value: "baz"}}
+			rs.Spec.Workspaces = []Workspace{{Name: "bar", EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+			rs.Spec.TaskRunSpecs = []TaskRunSpec{{PipelineTaskName: "push", ServiceAccount: "bar"}}
+			effective := rs.InheritFrom(crs)
+			Expect(effective.Spec.Params).To(Equal([]Params{{Name: "foo", Value: "baz"}, {Name: "bar", Value: "bar"}}))
+			Expect(effective.Spec.Workspaces).To(HaveLen(2))
+			Expect(effective.Spec.TaskRunSpecs).To(Equal(rs.Spec.TaskRunSpecs))
+		})
+
+		It("should not modify the original objects", func() {
+			rs.Spec.Params = []Params{{Name: "foo", Value: "baz"}}
+			rs.InheritFrom(crs)
+			Expect(rs.Spec.Params).To(HaveLen(1))
+			Expect(rs.Spec.Pipeline).To(BeEmpty())
+		})
+	})
+})
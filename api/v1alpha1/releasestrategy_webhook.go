@@ -0,0 +1,204 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// resolverRequiredParams maps each supported Tekton resolver to the groups of params it requires. At least one
+// param of each group has to be set.
+var resolverRequiredParams = map[string][][]string{
+	"bundles": {{"bundle"}, {"name"}},
+	"cluster": {{"name"}},
+	"git":     {{"url", "repo"}, {"pathInRepo"}},
+	"hub":     {{"name"}},
+}
+
+func (rs *ReleaseStrategy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	w := &releaseStrategyWebhook{client: mgr.GetClient()}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(rs).
+		WithValidator(w).
+		Complete()
+}
+
+// releaseStrategyWebhook wraps the ReleaseStrategy Validator implementation and rejects the deletion of
+// ReleaseStrategies still referenced by ReleasePlanAdmissions.
+type releaseStrategyWebhook struct {
+	// client is used to read the ReleasePlanAdmissions referencing the ReleaseStrategy
+	client client.Reader
+}
+
+var _ admission.CustomValidator = &releaseStrategyWebhook{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (w *releaseStrategyWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return obj.(*ReleaseStrategy).ValidateCreate()
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (w *releaseStrategyWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return newObj.(*ReleaseStrategy).ValidateUpdate(oldObj)
+}
+
+// ValidateDelete implements admission.CustomValidator. The deletion will be rejected if any ReleasePlanAdmission
+// still references the ReleaseStrategy.
+func (w *releaseStrategyWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	rs := obj.(*ReleaseStrategy)
+	if w.client == nil {
+		return nil
+	}
+
+	releasePlanAdmissions := &ReleasePlanAdmissionList{}
+	err := w.client.List(ctx, releasePlanAdmissions, client.InNamespace(rs.Namespace))
+	if err != nil {
+		return err
+	}
+
+	var referencingReleasePlanAdmissions []ReleasePlanAdmission
+	for _, releasePlanAdmission := range releasePlanAdmissions.Items {
+		if releasePlanAdmission.Spec.ReleaseStrategy == rs.Name &&
+			releasePlanAdmission.Spec.ReleaseStrategyKind != ClusterReleaseStrategyKind {
+			referencingReleasePlanAdmissions = append(referencingReleasePlanAdmissions, releasePlanAdmission)
+		}
+	}
+
+	return NewReferencedError(ReleaseStrategyKind, rs.Name, referencingReleasePlanAdmissions)
+}
+
+//+kubebuilder:webhook:path=/validate-appstudio-redhat-com-v1alpha1-releasestrategy,mutating=false,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=releasestrategies,verbs=create;update;delete,versions=v1alpha1,name=vreleasestrategy.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ReleaseStrategy{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (rs *ReleaseStrategy) ValidateCreate() error {
+	return rs.Spec.validate(rs.Spec.ClusterReleaseStrategy != "")
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (rs *ReleaseStrategy) ValidateUpdate(old runtime.Object) error {
+	return rs.Spec.validate(rs.Spec.ClusterReleaseStrategy != "")
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type. The ReleasePlanAdmissions
+// referencing the ReleaseStrategy are checked by the releaseStrategyWebhook, as they have to be read from the cluster.
+func (rs *ReleaseStrategy) ValidateDelete() error {
+	return nil
+}
+
+// validate throws an error if the spec is not valid. If the spec inherits from a ClusterReleaseStrategy, the Pipeline
+// reference and the policy are not required, as they can be inherited.
+func (rss *ReleaseStrategySpec) validate(inherited bool) error {
+	if err := rss.validatePipelineReference(inherited); err != nil {
+		return err
+	}
+
+	if rss.Policy == "" && !inherited {
+		return fmt.Errorf("policy has to be set")
+	}
+
+	return rss.validateTaskRunSpecs()
+}
+
+// validatePipelineReference throws an error if the spec doesn't reference the release Pipeline either by name or
+// through a resolver or if the resolver params required to locate the Pipeline are missing.
+func (rss *ReleaseStrategySpec) validatePipelineReference(inherited bool) error {
+	if rss.PipelineRef == nil {
+		if rss.Pipeline == "" && !inherited {
+			return fmt.Errorf("either pipeline or pipelineRef has to be set")
+		}
+
+		if rss.Pipeline == "" && rss.Bundle != "" {
+			return fmt.Errorf("bundle cannot be set without pipeline")
+		}
+
+		return nil
+	}
+
+	if rss.Pipeline != "" || rss.Bundle != "" {
+		return fmt.Errorf("pipelineRef cannot be set along with pipeline or bundle")
+	}
+
+	requiredParams, ok := resolverRequiredParams[rss.PipelineRef.Resolver]
+	if !ok {
+		return fmt.Errorf("resolver '%s' is not supported", rss.PipelineRef.Resolver)
+	}
+
+	params := map[string]bool{}
+	for _, param := range rss.PipelineRef.Params {
+		if params[param.Name] {
+			return fmt.Errorf("resolver param '%s' is defined more than once", param.Name)
+		}
+		params[param.Name] = true
+	}
+
+	for _, group := range requiredParams {
+		found := false
+		for _, name := range group {
+			if params[name] {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("resolver '%s' requires the param '%s'", rss.PipelineRef.Resolver,
+				strings.Join(group, "' or '"))
+		}
+	}
+
+	return nil
+}
+
+// validateTaskRunSpecs throws an error if the settings of a task are overridden more than once.
+func (rss *ReleaseStrategySpec) validateTaskRunSpecs() error {
+	pipelineTaskNames := map[string]bool{}
+	for _, taskRunSpec := range rss.TaskRunSpecs {
+		if pipelineTaskNames[taskRunSpec.PipelineTaskName] {
+			return fmt.Errorf("task '%s' is overridden more than once", taskRunSpec.PipelineTaskName)
+		}
+		pipelineTaskNames[taskRunSpec.PipelineTaskName] = true
+	}
+
+	return nil
+}
+
+// NewReferencedError returns an error listing the given ReleasePlanAdmissions if there is any, so resources still
+// referenced by them can't be deleted. If the list is empty, nil will be returned.
+func NewReferencedError(kind, name string, releasePlanAdmissions []ReleasePlanAdmission) error {
+	if len(releasePlanAdmissions) == 0 {
+		return nil
+	}
+
+	var names []string
+	for _, releasePlanAdmission := range releasePlanAdmissions {
+		names = append(names, fmt.Sprintf("%s/%s", releasePlanAdmission.Namespace, releasePlanAdmission.Name))
+	}
+
+	return fmt.Errorf("%s '%s' cannot be deleted as it's referenced by the ReleasePlanAdmissions: %s",
+		kind, name, strings.Join(names, ", "))
+}
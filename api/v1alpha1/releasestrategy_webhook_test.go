@@ -0,0 +1,205 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ReleaseStrategy webhook", func() {
+	var releaseStrategy *ReleaseStrategy
+
+	BeforeEach(func() {
+		releaseStrategy = &ReleaseStrategy{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "appstudio.redhat.com/v1alpha1",
+				Kind:       "ReleaseStrategy",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "releasestrategy",
+				Namespace: "default",
+			},
+			Spec: ReleaseStrategySpec{
+				Policy: "policy",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		err := k8sClient.Delete(ctx, releaseStrategy)
+		Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	Context("When a ReleaseStrategy is created using the pipeline name", func() {
+		It("should be accepted", func() {
+			releaseStrategy.Spec.Pipeline = "pipeline"
+			releaseStrategy.Spec.Bundle = "quay.io/foo/bar:baz"
+			Expect(k8sClient.Create(ctx, releaseStrategy)).Should(Succeed())
+		})
+	})
+
+	Context("When a ReleaseStrategy is created using a resolver", func() {
+		It("should be accepted if all the required params are set", func() {
+			releaseStrategy.Spec.PipelineRef = &PipelineRef{
+				Resolver: "bundles",
+				Params: []ResolverParam{
+					{Name: "bundle", Value: "quay.io/foo/bar@sha256:0123"},
+					{Name: "name", Value: "pipeline"},
+					{Name: "kind", Value: "pipeline"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, releaseStrategy)).Should(Succeed())
+		})
+
+		It("should get rejected if a required param is missing", func() {
+			releaseStrategy.Spec.PipelineRef = &PipelineRef{
+				Resolver: "git",
+				Params:   []ResolverParam{{Name: "pathInRepo", Value: "pipeline.yaml"}},
+			}
+			err := k8sClient.Create(ctx, releaseStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("resolver 'git' requires the param 'url' or 'repo'"))
+		})
+
+		It("should get rejected if a param is defined more than once", func() {
+			releaseStrategy.Spec.PipelineRef = &PipelineRef{
+				Resolver: "cluster",
+				Params: []ResolverParam{
+					{Name: "name", Value: "foo"},
+					{Name: "name", Value: "bar"},
+				},
+			}
+			err := k8sClient.Create(ctx, releaseStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("resolver param 'name' is defined more than once"))
+		})
+
+		It("should get rejected if the pipeline name is also set", func() {
+			releaseStrategy.Spec.Pipeline = "pipeline"
+			releaseStrategy.Spec.PipelineRef = &PipelineRef{
+				Resolver: "hub",
+				Params:   []ResolverParam{{Name: "name", Value: "pipeline"}},
+			}
+			err := k8sClient.Create(ctx, releaseStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("pipelineRef cannot be set along with pipeline or bundle"))
+		})
+	})
+
+	Context("When a ReleaseStrategy is created overriding the settings of a task more than once", func() {
+		It("should get rejected", func() {
+			releaseStrategy.Spec.Pipeline = "pipeline"
+			releaseStrategy.Spec.TaskRunSpecs = []TaskRunSpec{
+				{PipelineTaskName: "push", ServiceAccount: "foo"},
+				{PipelineTaskName: "push", ServiceAccount: "bar"},
+			}
+			err := k8sClient.Create(ctx, releaseStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("task 'push' is overridden more than once"))
+		})
+	})
+
+	Context("When a ReleaseStrategy is created without referencing a pipeline", func() {
+		It("should get rejected", func() {
+			err := k8sClient.Create(ctx, releaseStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("either pipeline or pipelineRef has to be set"))
+		})
+	})
+
+	Context("When a ReleaseStrategy is created without a policy", func() {
+		It("should get rejected", func() {
+			releaseStrategy.Spec.Pipeline = "pipeline"
+			releaseStrategy.Spec.Policy = ""
+			err := k8sClient.Create(ctx, releaseStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("policy has to be set"))
+		})
+	})
+
+	Context("When a ReleaseStrategy inheriting from a ClusterReleaseStrategy is created", func() {
+		It("should be accepted without a pipeline or a policy", func() {
+			releaseStrategy.Spec.ClusterReleaseStrategy = "cluster-release-strategy"
+			releaseStrategy.Spec.Policy = ""
+			Expect(k8sClient.Create(ctx, releaseStrategy)).Should(Succeed())
+		})
+	})
+
+	Context("When a ReleaseStrategy referenced by a ReleasePlanAdmission is deleted", func() {
+		It("should get rejected until the ReleasePlanAdmission stops referencing it", func() {
+			releaseStrategy.Spec.Pipeline = "pipeline"
+			Expect(k8sClient.Create(ctx, releaseStrategy)).Should(Succeed())
+
+			releasePlanAdmission := &ReleasePlanAdmission{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "releasestrategy-deletion",
+					Namespace: "default",
+				},
+				Spec: ReleasePlanAdmissionSpec{
+					Application:     "releasestrategy-deletion",
+					Origin:          "default",
+					Environment:     "environment",
+					ReleaseStrategy: releaseStrategy.Name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, releasePlanAdmission)).Should(Succeed())
+
+			Eventually(func() error {
+				return k8sClient.Delete(ctx, releaseStrategy)
+			}).Should(MatchError(ContainSubstring("ReleaseStrategy 'releasestrategy' cannot be deleted as it's " +
+				"referenced by the ReleasePlanAdmissions: default/releasestrategy-deletion")))
+
+			Expect(k8sClient.Delete(ctx, releasePlanAdmission)).Should(Succeed())
+			Eventually(func() error {
+				return k8sClient.Delete(ctx, releaseStrategy)
+			}).Should(Succeed())
+		})
+	})
+
+	Describe("When ValidateDelete method is called", func() {
+		It("should return nil if no ReleasePlanAdmission references the ReleaseStrategy", func() {
+			releaseStrategy := &ReleaseStrategy{}
+			Expect(releaseStrategy.ValidateDelete()).To(BeNil())
+		})
+	})
+
+	Describe("When the releaseStrategyWebhook ValidateDelete method is called", func() {
+		It("should return nil if there is no client to read the ReleasePlanAdmissions", func() {
+			Expect((&releaseStrategyWebhook{}).ValidateDelete(ctx, &ReleaseStrategy{})).To(Succeed())
+		})
+	})
+
+	Describe("When NewReferencedError is called", func() {
+		It("should return nil if no ReleasePlanAdmission is given", func() {
+			Expect(NewReferencedError(ReleaseStrategyKind, "releasestrategy", nil)).To(BeNil())
+		})
+
+		It("should list the given ReleasePlanAdmissions along with their namespaces", func() {
+			releasePlanAdmissions := []ReleasePlanAdmission{
+				{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "other"}},
+			}
+			Expect(NewReferencedError(ReleaseStrategyKind, "releasestrategy", releasePlanAdmissions)).To(
+				MatchError("ReleaseStrategy 'releasestrategy' cannot be deleted as it's referenced by the " +
+					"ReleasePlanAdmissions: default/foo, other/bar"))
+		})
+	})
+})
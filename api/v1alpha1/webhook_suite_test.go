@@ -29,6 +29,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
 	//+kubebuilder:scaffold:imports
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -77,6 +79,8 @@ var _ = BeforeSuite(func() {
 	scheme := runtime.NewScheme()
 	Expect(AddToScheme(scheme)).To(Succeed())
 	Expect(admissionv1beta1.AddToScheme(scheme)).To(Succeed())
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	Expect(authorizationv1.AddToScheme(scheme)).To(Succeed())
 
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme})
 	Expect(err).NotTo(HaveOccurred())
@@ -94,9 +98,16 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	Expect((&Release{}).SetupWebhookWithManager(mgr)).To(Succeed())
+	// the ReleasePlanAdmission webhook looks for conflicting ReleasePlanAdmissions using the origin index
+	Expect(mgr.GetCache().IndexField(ctx, &ReleasePlanAdmission{}, "spec.origin", func(obj client.Object) []string {
+		return obj.(*ReleasePlanAdmission).GetOriginIndexValues()
+	})).To(Succeed())
+
+	Expect((&Release{}).SetupWebhookWithManager(mgr, nil)).To(Succeed())
 	Expect((&ReleasePlanAdmission{}).SetupWebhookWithManager(mgr)).To(Succeed())
 	Expect((&ReleasePlan{}).SetupWebhookWithManager(mgr)).To(Succeed())
+	Expect((&ReleaseStrategy{}).SetupWebhookWithManager(mgr)).To(Succeed())
+	Expect((&ClusterReleaseStrategy{}).SetupWebhookWithManager(mgr)).To(Succeed())
 
 	//+kubebuilder:scaffold:webhook
 
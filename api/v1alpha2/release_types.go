@@ -0,0 +1,977 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/metrics"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ReleaseSpec defines the desired state of Release.
+type ReleaseSpec struct {
+	// Snapshot to be released. It can't be set along with RollbackTo, as rollbacks release the Snapshot of the Release
+	// they roll back to
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// ReleasePlan to use for this particular Release
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +required
+	ReleasePlan string `json:"releasePlan"`
+
+	// Params to pass to the release Pipeline. They override the ones set in the ReleasePlan and need to be allowed by
+	// the ReleasePlanAdmission in the target
+	// +optional
+	Params []Params `json:"params,omitempty"`
+
+	// RollbackTo is the name of a previous Release in the same namespace to roll back to. That Release has to use the
+	// same ReleasePlan and its deployment has to have succeeded
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	RollbackTo string `json:"rollbackTo,omitempty"`
+
+	// SkipReleasePipeline skips the release Pipeline of a rollback, so the Snapshot is deployed again right away. It
+	// can only be set along with RollbackTo
+	// +optional
+	SkipReleasePipeline bool `json:"skipReleasePipeline,omitempty"`
+}
+
+// ObjectReference references an object by its namespace and name.
+type ObjectReference struct {
+	// Namespace of the referenced object. It's empty for cluster-scoped objects
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the referenced object
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +required
+	Name string `json:"name"`
+}
+
+// NewObjectReference creates and returns an ObjectReference for the given namespace and name.
+func NewObjectReference(namespace, name string) *ObjectReference {
+	return &ObjectReference{
+		Namespace: namespace,
+		Name:      name,
+	}
+}
+
+// NamespacedName returns the types.NamespacedName of the referenced object.
+func (r *ObjectReference) NamespacedName() types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: r.Namespace,
+		Name:      r.Name,
+	}
+}
+
+// String returns the namespace and name of the referenced object separated by a slash, or just its name if it's a
+// cluster-scoped object.
+func (r *ObjectReference) String() string {
+	if r.Namespace == "" {
+		return r.Name
+	}
+
+	return fmt.Sprintf("%s%c%s", r.Namespace, types.Separator, r.Name)
+}
+
+// ReleaseStatus defines the observed state of Release.
+type ReleaseStatus struct {
+	// StartTime is the time when the Release PipelineRun was created and set to run
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the Release PipelineRun completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// DeploymentStartTime is the time when the SnapshotEnvironmentBinding was created
+	// +optional
+	DeploymentStartTime *metav1.Time `json:"deploymentStartTime,omitempty"`
+
+	// DeploymentCompletionTime is the time when the SnapshotEnvironmentBinding has all components deployed
+	// +optional
+	DeploymentCompletionTime *metav1.Time `json:"deploymentCompletionTime,omitempty"`
+
+	// Conditions represent the latest available observations for the release
+	// +optional
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// ObservedGeneration is the generation of the Release observed when its conditions were last updated
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is a summary of the state of the Release derived from its conditions
+	// +kubebuilder:validation:Enum=Pending;Releasing;Deploying;Succeeded;Failed
+	// +optional
+	Phase ReleasePhase `json:"phase,omitempty"`
+
+	// Snapshot is the Snapshot released by a rollback, which is the one of the Release it rolls back to
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// SnapshotEnvironmentBinding references the SnapshotEnvironmentBinding created as part of this release
+	// +optional
+	SnapshotEnvironmentBinding *ObjectReference `json:"snapshotEnvironmentBinding,omitempty"`
+
+	// ReleasePipelineRun references the release PipelineRun executed as part of this release
+	// +optional
+	ReleasePipelineRun *ObjectReference `json:"releasePipelineRun,omitempty"`
+
+	// ReleasePlanAdmission references the ReleasePlanAdmission used for this release
+	// +optional
+	ReleasePlanAdmission *ObjectReference `json:"releasePlanAdmission,omitempty"`
+
+	// ReleaseStrategy references the ReleaseStrategy used for this release. If the ReleasePlanAdmission references a
+	// ClusterReleaseStrategy, the reference has no namespace
+	// +optional
+	ReleaseStrategy *ObjectReference `json:"releaseStrategy,omitempty"`
+
+	// EffectiveReleaseStrategy contains the spec of the strategy used for this release once the settings inherited
+	// from a ClusterReleaseStrategy are resolved
+	// +optional
+	EffectiveReleaseStrategy *ReleaseStrategySpec `json:"effectiveReleaseStrategy,omitempty"`
+
+	// Target references where this release is intended to be released to
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	Target string `json:"target,omitempty"`
+
+	// Requester contains the identity of the user who created the Release
+	// +optional
+	Requester *Requester `json:"requester,omitempty"`
+
+	// Deployment contains the status of the deployment of the Release to the Environment
+	// +optional
+	Deployment *DeploymentStatus `json:"deployment,omitempty"`
+
+	// Environments contains the status of the deployment to each of the Environments the Release has reached, in the
+	// order they were deployed to. The last one is the Environment currently being deployed to
+	// +optional
+	Environments []EnvironmentDeploymentStatus `json:"environments,omitempty"`
+
+	// Verification contains the status of the verification of the deployment, if the ReleasePlanAdmission sets a
+	// verification Pipeline
+	// +optional
+	Verification *VerificationStatus `json:"verification,omitempty"`
+}
+
+// VerificationStatus defines the observed state of the verification of the deployment of a Release.
+type VerificationStatus struct {
+	// PipelineRun references the verification PipelineRun executed once the Release was deployed
+	// +optional
+	PipelineRun *ObjectReference `json:"pipelineRun,omitempty"`
+
+	// StartTime is the time when the verification PipelineRun was created
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the verification PipelineRun finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// EnvironmentDeploymentState represents the state of the deployment of a Release to an Environment.
+type EnvironmentDeploymentState string
+
+const (
+	// EnvironmentDeploymentStatePending is the state of an Environment whose SnapshotEnvironmentBinding is yet to be
+	// created or updated
+	EnvironmentDeploymentStatePending EnvironmentDeploymentState = "Pending"
+
+	// EnvironmentDeploymentStateDeploying is the state of an Environment being deployed to
+	EnvironmentDeploymentStateDeploying EnvironmentDeploymentState = "Deploying"
+
+	// EnvironmentDeploymentStateDeployed is the state of an Environment with all the components deployed
+	EnvironmentDeploymentStateDeployed EnvironmentDeploymentState = "Deployed"
+
+	// EnvironmentDeploymentStateFailed is the state of an Environment whose deployment failed
+	EnvironmentDeploymentStateFailed EnvironmentDeploymentState = "Failed"
+)
+
+// EnvironmentDeploymentStatus defines the observed state of the deployment of a Release to a single Environment.
+type EnvironmentDeploymentStatus struct {
+	// Name is the name of the Environment
+	// +required
+	Name string `json:"name"`
+
+	// State is the state of the deployment to the Environment
+	// +kubebuilder:validation:Enum=Pending;Deploying;Deployed;Failed
+	// +optional
+	State EnvironmentDeploymentState `json:"state,omitempty"`
+
+	// SnapshotEnvironmentBinding references the SnapshotEnvironmentBinding used to deploy to the Environment
+	// +optional
+	SnapshotEnvironmentBinding *ObjectReference `json:"snapshotEnvironmentBinding,omitempty"`
+
+	// PreviousSnapshot is the Snapshot the SnapshotEnvironmentBinding pointed to before the Release updated it. It's
+	// restored if the deployment fails and the ReleasePlanAdmission enables automatic rollbacks
+	// +optional
+	PreviousSnapshot string `json:"previousSnapshot,omitempty"`
+
+	// PreviousComponents are the components the SnapshotEnvironmentBinding bound before the Release updated it. They
+	// are restored along with the PreviousSnapshot, so components pruned by the Release are deployed again
+	// +optional
+	PreviousComponents []applicationapiv1alpha1.BindingComponent `json:"previousComponents,omitempty"`
+
+	// DeploymentStartTime is the time when the SnapshotEnvironmentBinding was created or updated
+	// +optional
+	DeploymentStartTime *metav1.Time `json:"deploymentStartTime,omitempty"`
+
+	// DeploymentCompletionTime is the time when the deployment to the Environment finished
+	// +optional
+	DeploymentCompletionTime *metav1.Time `json:"deploymentCompletionTime,omitempty"`
+
+	// Message is a human readable message describing the outcome of the deployment to the Environment
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DeploymentStatus defines the observed state of the deployment of a Release.
+type DeploymentStatus struct {
+	// Components contains the deployment status of each of the components bound to the Environment
+	// +optional
+	Components []ComponentDeploymentStatus `json:"components,omitempty"`
+
+	// MissingComponents contains the names of the components of the application that are not part of the Snapshot
+	// and were left out of the deployment
+	// +optional
+	MissingComponents []string `json:"missingComponents,omitempty"`
+}
+
+// ComponentDeploymentStatus defines the observed state of the deployment of a single component.
+type ComponentDeploymentStatus struct {
+	// Name is the name of the component
+	// +required
+	Name string `json:"name"`
+
+	// ContainerImage is the container image of the component being deployed
+	// +optional
+	ContainerImage string `json:"containerImage,omitempty"`
+
+	// GitOpsDeployment is the name of the GitOpsDeployment used to deploy the component
+	// +optional
+	GitOpsDeployment string `json:"gitopsDeployment,omitempty"`
+
+	// CommitID is the commit of the GitOps repository containing the resources of the component
+	// +optional
+	CommitID string `json:"commitID,omitempty"`
+
+	// HealthStatus is the health of the component as reported by its GitOpsDeployment
+	// +optional
+	HealthStatus string `json:"healthStatus,omitempty"`
+
+	// SyncStatus is the sync status of the component as reported by its GitOpsDeployment
+	// +optional
+	SyncStatus string `json:"syncStatus,omitempty"`
+}
+
+// ReleaseReason represents a reason for the release status conditions.
+type ReleaseReason string
+
+const (
+	// ValidatedConditionType is the type of the condition indicating whether the Release and the resources it
+	// references are valid
+	ValidatedConditionType string = "Validated"
+
+	// ReleasedConditionType is the type of the condition indicating whether the release PipelineRun has succeeded
+	ReleasedConditionType string = "Released"
+
+	// DeployedConditionType is the type of the condition indicating whether the released Snapshot has been deployed
+	DeployedConditionType string = "Deployed"
+
+	// RolledBackConditionType is the type of the condition indicating whether the Environment whose deployment failed
+	// was rolled back to the Snapshot it was running before the Release
+	RolledBackConditionType string = "RolledBack"
+
+	// ReadyConditionType is the type of the condition aggregating the rest of conditions. It's True once the Release
+	// is released and deployed, False if any of the steps failed and Unknown otherwise
+	ReadyConditionType string = "Ready"
+
+	// releaseConditionType is the type of the legacy condition summarizing the validation and the release PipelineRun.
+	// It's still set, along with the AllComponentsDeployed condition, so existing consumers keep working, but new ones
+	// should use the ReadyConditionType and the conditions it aggregates
+	releaseConditionType string = "Succeeded"
+
+	// ReleaseReasonValidated is the reason set when the Release passes the validation
+	ReleaseReasonValidated ReleaseReason = "Validated"
+
+	// ReleaseReasonValidationError is the reason set when the Release validation failed
+	ReleaseReasonValidationError ReleaseReason = "ReleaseValidationError"
+
+	// ReleaseReasonParamsValidationError is the reason set when the params passed by the tenant are not allowed
+	ReleaseReasonParamsValidationError ReleaseReason = "ParamsValidationError"
+
+	// ReleaseReasonPipelineFailed is the reason set when the release PipelineRun failed
+	ReleaseReasonPipelineFailed ReleaseReason = "ReleasePipelineFailed"
+
+	// ReleaseReasonReleasePlanValidationError is the reason set when there is a validation error with the ReleasePlan
+	ReleaseReasonReleasePlanValidationError ReleaseReason = "ReleasePlanValidationError"
+
+	// ReleaseReasonRollbackValidationError is the reason set when the Release to roll back to is not valid
+	ReleaseReasonRollbackValidationError ReleaseReason = "RollbackValidationError"
+
+	// ReleaseReasonTargetDisabledError is the reason set when releases to the target are disabled
+	ReleaseReasonTargetDisabledError ReleaseReason = "ReleaseTargetDisabledError"
+
+	// ReleaseReasonRunning is the reason set when the release PipelineRun starts running
+	ReleaseReasonRunning ReleaseReason = "Running"
+
+	// ReleaseReasonReleasePipelineSkipped is the reason set when a rollback skips the release Pipeline
+	ReleaseReasonReleasePipelineSkipped ReleaseReason = "ReleasePipelineSkipped"
+
+	// ReleaseReasonSucceeded is the reason set when the release PipelineRun has succeeded
+	ReleaseReasonSucceeded ReleaseReason = "Succeeded"
+
+	// ReleaseReasonDeploymentPending is the reason set when the release PipelineRun has succeeded but the deployment
+	// has not started yet
+	ReleaseReasonDeploymentPending ReleaseReason = "DeploymentPending"
+
+	// ReleaseReasonDeploymentSkipped is the reason set when there is no environment to deploy the Release to
+	ReleaseReasonDeploymentSkipped ReleaseReason = "DeploymentSkipped"
+
+	// ReleaseReasonDeploymentFailed is the reason set when the SnapshotEnvironmentBinding reports an error that won't
+	// be recovered from without changes to the deployment
+	ReleaseReasonDeploymentFailed ReleaseReason = "DeploymentFailed"
+
+	// ReleaseReasonDeploymentTimedOut is the reason set when the deployment doesn't finish in the time set in the
+	// ReleasePlanAdmission
+	ReleaseReasonDeploymentTimedOut ReleaseReason = "DeploymentTimedOut"
+
+	// ReleaseReasonVerifying is the reason set when all the components are deployed and the verification PipelineRun
+	// is running
+	ReleaseReasonVerifying ReleaseReason = "Verifying"
+
+	// ReleaseReasonVerified is the reason set when the verification PipelineRun has succeeded
+	ReleaseReasonVerified ReleaseReason = "Verified"
+
+	// ReleaseReasonVerificationFailed is the reason set when the verification PipelineRun failed
+	ReleaseReasonVerificationFailed ReleaseReason = "VerificationFailed"
+
+	// ReleaseReasonRolledBack is the reason set when the Environment whose deployment failed was rolled back
+	ReleaseReasonRolledBack ReleaseReason = "RolledBack"
+
+	// ReleaseReasonRollbackSkipped is the reason set when the Environment whose deployment failed can't be rolled back
+	// as another Release has deployed to it since
+	ReleaseReasonRollbackSkipped ReleaseReason = "RollbackSkipped"
+)
+
+func (rr ReleaseReason) String() string {
+	return string(rr)
+}
+
+// ReleasePhase represents the phase of a Release. It summarizes the Release conditions using values that match the
+// states reported by kstatus compatible tools.
+type ReleasePhase string
+
+const (
+	// ReleasePhasePending is the phase of a Release that hasn't been validated yet
+	ReleasePhasePending ReleasePhase = "Pending"
+
+	// ReleasePhaseReleasing is the phase of a Release whose release PipelineRun is running
+	ReleasePhaseReleasing ReleasePhase = "Releasing"
+
+	// ReleasePhaseDeploying is the phase of a released Release whose deployment hasn't finished yet
+	ReleasePhaseDeploying ReleasePhase = "Deploying"
+
+	// ReleasePhaseSucceeded is the phase of a Release that has been released and deployed
+	ReleasePhaseSucceeded ReleasePhase = "Succeeded"
+
+	// ReleasePhaseFailed is the phase of a Release that is invalid or whose release PipelineRun failed
+	ReleasePhaseFailed ReleasePhase = "Failed"
+)
+
+const (
+	// RequesterAnnotation is the annotation used to record the username of the user who created the Release
+	RequesterAnnotation = "release.appstudio.openshift.io/requester"
+
+	// RequesterGroupsAnnotation is the annotation used to record the comma separated list of groups of the user who
+	// created the Release
+	RequesterGroupsAnnotation = "release.appstudio.openshift.io/requester-groups"
+
+	// RequesterServiceAccountAnnotation is the annotation used to record whether the Release was created by a
+	// service account
+	RequesterServiceAccountAnnotation = "release.appstudio.openshift.io/requester-service-account"
+)
+
+// Requester defines the identity of the user who created a Release.
+type Requester struct {
+	// Username is the name of the user who created the Release
+	// +required
+	Username string `json:"username"`
+
+	// Groups is the list of groups the user belonged to when the Release was created
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// ServiceAccount indicates whether the Release was created by a service account, as it happens when the Release
+	// is created automatically
+	// +optional
+	ServiceAccount bool `json:"serviceAccount,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Snapshot",type=string,JSONPath=`.spec.snapshot`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Rollback To",type=string,priority=1,JSONPath=`.spec.rollbackTo`
+// +kubebuilder:printcolumn:name="Requester",type=string,priority=1,JSONPath=`.status.requester.username`
+// +kubebuilder:printcolumn:name="PipelineRun",type=string,priority=1,JSONPath=`.status.releasePipelineRun.name`
+// +kubebuilder:printcolumn:name="Start Time",type=date,priority=1,JSONPath=`.status.startTime`
+// +kubebuilder:printcolumn:name="Completion Time",type=date,priority=1,JSONPath=`.status.completionTime`
+// +kubebuilder:printcolumn:name="Deployment Start Time",type=date,priority=1,JSONPath=`.status.deploymentStartTime`
+// +kubebuilder:printcolumn:name="Deployment Completion Time",type=date,priority=1,JSONPath=`.status.deploymentCompletionTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Release is the Schema for the releases API
+type Release struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReleaseSpec   `json:"spec,omitempty"`
+	Status ReleaseStatus `json:"status,omitempty"`
+}
+
+// GetCurrentEnvironment returns the status of the Environment the Release is being deployed to, or nil if the
+// deployment to an Environment hasn't started yet.
+func (r *Release) GetCurrentEnvironment() *EnvironmentDeploymentStatus {
+	if len(r.Status.Environments) == 0 {
+		return nil
+	}
+
+	return &r.Status.Environments[len(r.Status.Environments)-1]
+}
+
+// GetSnapshotName returns the name of the Snapshot released by the Release. Rollbacks don't set a Snapshot in their
+// spec, so the one resolved from the Release they roll back to is returned for them.
+func (r *Release) GetSnapshotName() string {
+	if r.Spec.Snapshot != "" {
+		return r.Spec.Snapshot
+	}
+
+	return r.Status.Snapshot
+}
+
+// GetRequester returns the identity of the user who created the Release as recorded in its annotations. If the
+// Release has no requester annotations, nil is returned.
+func (r *Release) GetRequester() *Requester {
+	annotations := r.GetAnnotations()
+	username, found := annotations[RequesterAnnotation]
+	if !found {
+		return nil
+	}
+
+	requester := &Requester{
+		Username:       username,
+		ServiceAccount: annotations[RequesterServiceAccountAnnotation] == "true",
+	}
+	if groups := annotations[RequesterGroupsAnnotation]; groups != "" {
+		requester.Groups = strings.Split(groups, ",")
+	}
+
+	return requester
+}
+
+// HasStarted checks whether the Release has a valid start time set in its status.
+func (r *Release) HasStarted() bool {
+	return r.Status.StartTime != nil && !r.Status.StartTime.IsZero()
+}
+
+// HasSucceeded checks whether the release PipelineRun of the Release has succeeded or not.
+func (r *Release) HasSucceeded() bool {
+	condition := r.findCondition(ReleasedConditionType, releaseConditionType)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+// HasDeploymentFailed checks whether the deployment of the Release finished without all the components being deployed.
+func (r *Release) HasDeploymentFailed() bool {
+	return r.Status.DeploymentCompletionTime != nil &&
+		meta.IsStatusConditionFalse(r.Status.Conditions, DeployedConditionType)
+}
+
+// IsDeployed checks whether the Release has been successfully deployed via GitOps or doesn't need to be deployed.
+func (r *Release) IsDeployed() bool {
+	condition := r.findCondition(DeployedConditionType, applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+// IsDeploying checks whether the Release has a valid start time for the deployment set in its status.
+func (r *Release) IsDeploying() bool {
+	return r.Status.DeploymentStartTime != nil && !r.Status.DeploymentStartTime.IsZero()
+}
+
+// IsDeploymentSkipped checks whether the Release was marked as deployed because there was nothing to deploy.
+func (r *Release) IsDeploymentSkipped() bool {
+	condition := meta.FindStatusCondition(r.Status.Conditions, DeployedConditionType)
+	return condition != nil && condition.Reason == ReleaseReasonDeploymentSkipped.String()
+}
+
+// IsDone returns a boolean indicating whether the Release's status indicates that it is done or not. A Release is
+// done when it's invalid or its release PipelineRun has finished.
+func (r *Release) IsDone() bool {
+	if meta.IsStatusConditionFalse(r.Status.Conditions, ValidatedConditionType) {
+		return true
+	}
+
+	condition := r.findCondition(ReleasedConditionType, releaseConditionType)
+	return condition != nil && condition.Status != metav1.ConditionUnknown
+}
+
+// IsReleasePipelineSkipped checks whether the Release was released without running the release Pipeline.
+func (r *Release) IsReleasePipelineSkipped() bool {
+	condition := meta.FindStatusCondition(r.Status.Conditions, ReleasedConditionType)
+	return condition != nil && condition.Reason == ReleaseReasonReleasePipelineSkipped.String()
+}
+
+// IsRollbackDone checks whether the rollback of the Environment whose deployment failed was either performed or skipped.
+func (r *Release) IsRollbackDone() bool {
+	return meta.FindStatusCondition(r.Status.Conditions, RolledBackConditionType) != nil
+}
+
+// IsRolledBack checks whether the Environment whose deployment failed was rolled back to its previous Snapshot.
+func (r *Release) IsRolledBack() bool {
+	return meta.IsStatusConditionTrue(r.Status.Conditions, RolledBackConditionType)
+}
+
+// IsVerifying checks whether the verification PipelineRun of the Release has started but not finished yet.
+func (r *Release) IsVerifying() bool {
+	return r.Status.Verification != nil && r.Status.Verification.StartTime != nil &&
+		r.Status.Verification.CompletionTime == nil
+}
+
+// IsReady checks whether the Release has been released and deployed.
+func (r *Release) IsReady() bool {
+	return meta.IsStatusConditionTrue(r.Status.Conditions, ReadyConditionType)
+}
+
+// MarkDeployed registers the deployment completion time and sets the Deployed condition in the Release to True with
+// the provided reason and message.
+func (r *Release) MarkDeployed(reason, message string) {
+	if !r.IsDeploying() || r.HasDeploymentFailed() || (r.IsDeployed() && r.Status.DeploymentCompletionTime != nil) {
+		return
+	}
+
+	r.Status.DeploymentCompletionTime = &metav1.Time{Time: time.Now()}
+	r.setDeployedCondition(metav1.ConditionTrue, ReleaseReason(reason), message)
+	r.finishEnvironmentDeployment(reason, message, true)
+}
+
+// MarkDeploying registers the deployment start time and sets the Deployed condition in the Release to Unknown or
+// False with the provided reason and message.
+// Note: The binding condition treats False as a transient status, so False is accepted here and the Release is still
+// considered to be deploying. Deployments that won't recover are marked as failed with MarkDeploymentFailed.
+func (r *Release) MarkDeploying(status metav1.ConditionStatus, reason, message string) {
+	if r.HasDeploymentFailed() {
+		return
+	}
+
+	if status != metav1.ConditionTrue {
+		if r.Status.DeploymentStartTime == nil {
+			r.Status.DeploymentStartTime = &metav1.Time{Time: time.Now()}
+		}
+
+		r.setDeployedCondition(status, ReleaseReason(reason), message)
+	}
+}
+
+// MarkEnvironmentDeployed registers the deployment completion time of the current Environment and marks it as
+// deployed. The Deployed condition is not modified, as there might be other Environments to deploy to.
+func (r *Release) MarkEnvironmentDeployed(reason string) {
+	if r.GetCurrentEnvironment() == nil {
+		return
+	}
+
+	r.finishEnvironmentDeployment(reason, "", true)
+}
+
+// MarkEnvironmentDeploying registers the deployment start time of the Environment with the given name and the
+// SnapshotEnvironmentBinding used to deploy to it. If the Environment is not the current one, it's added to the list
+// of Environments the Release is deployed to.
+func (r *Release) MarkEnvironmentDeploying(name string, binding *ObjectReference) {
+	if r.IsDeployed() || r.HasDeploymentFailed() {
+		return
+	}
+
+	r.MarkEnvironmentPending(name)
+	environment := r.GetCurrentEnvironment()
+	environment.SnapshotEnvironmentBinding = binding
+	if environment.State == EnvironmentDeploymentStatePending {
+		environment.State = EnvironmentDeploymentStateDeploying
+		environment.DeploymentStartTime = &metav1.Time{Time: time.Now()}
+	}
+
+	r.Status.SnapshotEnvironmentBinding = binding
+	if r.Status.DeploymentStartTime == nil {
+		r.Status.DeploymentStartTime = environment.DeploymentStartTime
+	}
+}
+
+// MarkEnvironmentPending adds the Environment with the given name to the list of Environments the Release is deployed
+// to, so it becomes the current one. Nothing is done if it's already the current Environment.
+func (r *Release) MarkEnvironmentPending(name string) {
+	if r.IsDeployed() || r.HasDeploymentFailed() {
+		return
+	}
+
+	if environment := r.GetCurrentEnvironment(); environment != nil && environment.Name == name {
+		return
+	}
+
+	r.Status.Environments = append(r.Status.Environments, EnvironmentDeploymentStatus{
+		Name:  name,
+		State: EnvironmentDeploymentStatePending,
+	})
+}
+
+// MarkDeploymentFailed registers the deployment completion time and sets the Deployed condition in the Release to
+// False with the provided reason and message. Unlike a False status set by MarkDeploying, this is a terminal state.
+func (r *Release) MarkDeploymentFailed(reason ReleaseReason, message string) {
+	if !r.IsDeploying() || r.IsDeployed() || r.HasDeploymentFailed() {
+		return
+	}
+
+	r.Status.DeploymentCompletionTime = &metav1.Time{Time: time.Now()}
+	r.setDeployedCondition(metav1.ConditionFalse, reason, message)
+	r.finishEnvironmentDeployment(reason.String(), message, false)
+}
+
+// MarkDeploymentSkipped sets the Deployed condition in the Release to True with the provided message, so the Release
+// becomes ready when there is nothing to deploy.
+func (r *Release) MarkDeploymentSkipped(message string) {
+	if !r.HasSucceeded() || r.IsDeploying() || r.IsDeployed() {
+		return
+	}
+
+	r.setStatusConditionWithMessage(DeployedConditionType, metav1.ConditionTrue, ReleaseReasonDeploymentSkipped, message)
+	r.updateReadiness()
+}
+
+// MarkVerificationFailed registers the completion time of the verification and marks the deployment of the Release
+// as failed with the provided message.
+func (r *Release) MarkVerificationFailed(message string) {
+	if !r.IsVerifying() {
+		return
+	}
+
+	r.Status.Verification.CompletionTime = &metav1.Time{Time: time.Now()}
+	r.MarkDeploymentFailed(ReleaseReasonVerificationFailed, message)
+}
+
+// MarkVerified registers the completion time of the verification and marks the Release as deployed.
+func (r *Release) MarkVerified() {
+	if !r.IsVerifying() {
+		return
+	}
+
+	r.Status.Verification.CompletionTime = &metav1.Time{Time: time.Now()}
+	r.MarkDeployed(ReleaseReasonVerified.String(), "")
+}
+
+// MarkVerifying registers the verification PipelineRun and its start time and sets the Deployed condition in the
+// Release to Unknown, as the Release is not considered to be deployed until the verification succeeds.
+func (r *Release) MarkVerifying(pipelineRun *ObjectReference) {
+	if !r.IsDeploying() || r.IsDeployed() || r.HasDeploymentFailed() {
+		return
+	}
+
+	if r.Status.Verification == nil {
+		r.Status.Verification = &VerificationStatus{StartTime: &metav1.Time{Time: time.Now()}}
+	}
+	r.Status.Verification.PipelineRun = pipelineRun
+	r.setDeployedCondition(metav1.ConditionUnknown, ReleaseReasonVerifying, "")
+}
+
+// MarkFailed registers the completion time and changes the Released condition to False with the provided reason and
+// message.
+func (r *Release) MarkFailed(reason ReleaseReason, message string) {
+	if r.IsDone() && r.Status.CompletionTime != nil {
+		return
+	}
+
+	r.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	r.setReleasedCondition(metav1.ConditionFalse, reason, message)
+
+	go metrics.RegisterCompletedRelease(reason.String(), r.getReleaseStrategyName(), r.Status.Target,
+		r.Status.StartTime, r.Status.CompletionTime, false)
+}
+
+// MarkInvalid changes the Validated condition to False with the provided reason and message.
+func (r *Release) MarkInvalid(reason ReleaseReason, message string) {
+	if r.IsDone() {
+		return
+	}
+
+	r.setStatusConditionWithMessage(ValidatedConditionType, metav1.ConditionFalse, reason, message)
+	r.setStatusConditionWithMessage(releaseConditionType, metav1.ConditionFalse, reason, message)
+	r.updateReadiness()
+
+	go metrics.RegisterInvalidRelease(reason.String())
+}
+
+// MarkReleasePipelineSkipped registers the start and completion times and sets the Released condition to True, so
+// a rollback skipping the release Pipeline moves on to the deployment right away.
+func (r *Release) MarkReleasePipelineSkipped() {
+	if r.HasStarted() {
+		return
+	}
+
+	r.Status.StartTime = &metav1.Time{Time: time.Now()}
+	r.Status.CompletionTime = r.Status.StartTime
+	r.setStatusCondition(ValidatedConditionType, metav1.ConditionTrue, ReleaseReasonValidated)
+	r.setReleasedCondition(metav1.ConditionTrue, ReleaseReasonReleasePipelineSkipped, "")
+
+	go metrics.RegisterNewRelease(r.GetCreationTimestamp(), r.Status.StartTime)
+	go metrics.RegisterCompletedRelease(ReleaseReasonReleasePipelineSkipped.String(), r.getReleaseStrategyName(),
+		r.Status.Target, r.Status.StartTime, r.Status.CompletionTime, true)
+}
+
+// MarkRollbackSkipped sets the RolledBack condition in the Release to False with the provided message, so the
+// Environment whose deployment failed is left untouched.
+func (r *Release) MarkRollbackSkipped(message string) {
+	if !r.HasDeploymentFailed() || r.IsRollbackDone() {
+		return
+	}
+
+	r.setStatusConditionWithMessage(RolledBackConditionType, metav1.ConditionFalse,
+		ReleaseReasonRollbackSkipped, message)
+}
+
+// MarkRolledBack sets the RolledBack condition in the Release to True with the provided message once the Environment
+// whose deployment failed was restored to its previous Snapshot.
+func (r *Release) MarkRolledBack(message string) {
+	if !r.HasDeploymentFailed() || r.IsRollbackDone() {
+		return
+	}
+
+	r.setStatusConditionWithMessage(RolledBackConditionType, metav1.ConditionTrue,
+		ReleaseReasonRolledBack, message)
+
+	environment := ""
+	if currentEnvironment := r.GetCurrentEnvironment(); currentEnvironment != nil {
+		environment = currentEnvironment.Name
+	}
+	go metrics.RegisterRolledBackRelease(r.Status.Target, environment)
+}
+
+// MarkRunning registers the start time, changes the Validated condition to True, as a Release only starts once it's
+// validated, and the Released condition to Unknown.
+func (r *Release) MarkRunning() {
+	if r.HasStarted() && r.Status.StartTime != nil {
+		return
+	}
+
+	r.Status.StartTime = &metav1.Time{Time: time.Now()}
+	r.setStatusCondition(ValidatedConditionType, metav1.ConditionTrue, ReleaseReasonValidated)
+	r.setReleasedCondition(metav1.ConditionUnknown, ReleaseReasonRunning, "")
+
+	go metrics.RegisterNewRelease(r.GetCreationTimestamp(), r.Status.StartTime)
+}
+
+// MarkSucceeded registers the completion time and changes the Released condition to True.
+func (r *Release) MarkSucceeded() {
+	if !r.HasStarted() || (r.IsDone() && r.Status.CompletionTime != nil) {
+		return
+	}
+
+	r.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	r.setReleasedCondition(metav1.ConditionTrue, ReleaseReasonSucceeded, "")
+
+	go metrics.RegisterCompletedRelease(ReleaseReasonSucceeded.String(), r.getReleaseStrategyName(), r.Status.Target,
+		r.Status.StartTime, r.Status.CompletionTime, true)
+}
+
+// RecordPreviousDeployment registers the Snapshot and the components the given Environment was running before the
+// Release updated its SnapshotEnvironmentBinding, making the Environment the current one. The first deployment recorded
+// for the Environment is kept, as the binding already points to the Snapshot of the Release when the update is retried.
+func (r *Release) RecordPreviousDeployment(environmentName, snapshot string,
+	components []applicationapiv1alpha1.BindingComponent) {
+	r.MarkEnvironmentPending(environmentName)
+
+	environment := r.GetCurrentEnvironment()
+	if environment == nil || environment.Name != environmentName || environment.PreviousSnapshot != "" ||
+		snapshot == r.GetSnapshotName() {
+		return
+	}
+
+	environment.PreviousSnapshot = snapshot
+	environment.PreviousComponents = make([]applicationapiv1alpha1.BindingComponent, len(components))
+	for i := range components {
+		components[i].DeepCopyInto(&environment.PreviousComponents[i])
+	}
+}
+
+// ValidateRollbackTarget returns an error if the Release can't roll back to the given Release. Only Releases using the
+// same ReleasePlan whose deployment succeeded can be rolled back to.
+func (r *Release) ValidateRollbackTarget(target *Release) error {
+	if target.Spec.ReleasePlan != r.Spec.ReleasePlan {
+		return fmt.Errorf("the Release %s to roll back to uses the ReleasePlan %s instead of %s", target.Name,
+			target.Spec.ReleasePlan, r.Spec.ReleasePlan)
+	}
+
+	if !target.IsDeployed() || target.IsDeploymentSkipped() {
+		return fmt.Errorf("the Release %s to roll back to was never successfully deployed", target.Name)
+	}
+
+	return nil
+}
+
+// finishEnvironmentDeployment registers the deployment completion time of the current Environment, setting its state
+// depending on whether the deployment succeeded. Releases deployed before their Environments were tracked have no
+// current Environment, so their deployment is registered as a whole.
+func (r *Release) finishEnvironmentDeployment(reason, message string, succeeded bool) {
+	environment := r.GetCurrentEnvironment()
+	if environment == nil {
+		go metrics.RegisterDeployedRelease(reason, r.Status.Target, "", r.Status.DeploymentStartTime,
+			r.Status.DeploymentCompletionTime, succeeded)
+		return
+	}
+
+	if environment.State != EnvironmentDeploymentStateDeploying {
+		return
+	}
+
+	environment.DeploymentCompletionTime = &metav1.Time{Time: time.Now()}
+	environment.Message = message
+	environment.State = EnvironmentDeploymentStateFailed
+	if succeeded {
+		environment.State = EnvironmentDeploymentStateDeployed
+	}
+
+	go metrics.RegisterDeployedRelease(reason, r.Status.Target, environment.Name, environment.DeploymentStartTime,
+		environment.DeploymentCompletionTime, succeeded)
+}
+
+// findCondition returns the condition with the given type. If it's not set, the condition with the given legacy type
+// is returned instead, so Releases processed before the condition was introduced are still handled.
+func (r *Release) findCondition(conditionType, legacyConditionType string) *metav1.Condition {
+	if condition := meta.FindStatusCondition(r.Status.Conditions, conditionType); condition != nil {
+		return condition
+	}
+
+	return meta.FindStatusCondition(r.Status.Conditions, legacyConditionType)
+}
+
+// setDeployedCondition sets the Deployed condition and the legacy AllComponentsDeployed condition with the given
+// status, reason and message, updating the readiness of the Release.
+func (r *Release) setDeployedCondition(status metav1.ConditionStatus, reason ReleaseReason, message string) {
+	r.setStatusConditionWithMessage(DeployedConditionType, status, reason, message)
+	r.setStatusConditionWithMessage(applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed,
+		status, reason, message)
+	r.updateReadiness()
+}
+
+// setReleasedCondition sets the Released condition and the legacy Succeeded condition with the given status, reason
+// and message, updating the readiness of the Release.
+func (r *Release) setReleasedCondition(status metav1.ConditionStatus, reason ReleaseReason, message string) {
+	r.setStatusConditionWithMessage(ReleasedConditionType, status, reason, message)
+	r.setStatusConditionWithMessage(releaseConditionType, status, reason, message)
+	r.updateReadiness()
+}
+
+// SetCondition creates a new condition with the given conditionType, status and reason. Then, it sets this new condition,
+// unsetting previous conditions with the same type as necessary.
+func (r *Release) setStatusCondition(conditionType string, status metav1.ConditionStatus, reason ReleaseReason) {
+	r.setStatusConditionWithMessage(conditionType, status, reason, "")
+}
+
+// SetCondition creates a new condition with the given conditionType, status, reason and message. Then, it sets this new condition,
+// unsetting previous conditions with the same type as necessary.
+func (r *Release) setStatusConditionWithMessage(conditionType string, status metav1.ConditionStatus, reason ReleaseReason, message string) {
+	meta.SetStatusCondition(&r.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason.String(),
+		Message:            message,
+		ObservedGeneration: r.Generation,
+	})
+}
+
+// updateReadiness sets the Ready condition and the phase of the Release according to the Validated, Released and
+// Deployed conditions, registering the generation of the Release as observed.
+func (r *Release) updateReadiness() {
+	validated := meta.FindStatusCondition(r.Status.Conditions, ValidatedConditionType)
+	released := meta.FindStatusCondition(r.Status.Conditions, ReleasedConditionType)
+	deployed := meta.FindStatusCondition(r.Status.Conditions, DeployedConditionType)
+
+	switch {
+	case validated != nil && validated.Status == metav1.ConditionFalse:
+		r.Status.Phase = ReleasePhaseFailed
+		r.setStatusConditionWithMessage(ReadyConditionType, metav1.ConditionFalse,
+			ReleaseReason(validated.Reason), validated.Message)
+	case released == nil:
+		r.Status.Phase = ReleasePhasePending
+		r.setStatusCondition(ReadyConditionType, metav1.ConditionUnknown, ReleaseReason(ReleasePhasePending))
+	case released.Status == metav1.ConditionFalse:
+		r.Status.Phase = ReleasePhaseFailed
+		r.setStatusConditionWithMessage(ReadyConditionType, metav1.ConditionFalse,
+			ReleaseReason(released.Reason), released.Message)
+	case released.Status == metav1.ConditionUnknown:
+		r.Status.Phase = ReleasePhaseReleasing
+		r.setStatusConditionWithMessage(ReadyConditionType, metav1.ConditionUnknown,
+			ReleaseReason(released.Reason), released.Message)
+	case deployed == nil:
+		r.Status.Phase = ReleasePhaseDeploying
+		r.setStatusCondition(ReadyConditionType, metav1.ConditionUnknown, ReleaseReasonDeploymentPending)
+	case deployed.Status == metav1.ConditionTrue:
+		r.Status.Phase = ReleasePhaseSucceeded
+		r.setStatusCondition(ReadyConditionType, metav1.ConditionTrue, ReleaseReasonSucceeded)
+	case deployed.Status == metav1.ConditionFalse && r.Status.DeploymentCompletionTime != nil:
+		r.Status.Phase = ReleasePhaseFailed
+		r.setStatusConditionWithMessage(ReadyConditionType, metav1.ConditionFalse,
+			ReleaseReason(deployed.Reason), deployed.Message)
+	default:
+		r.Status.Phase = ReleasePhaseDeploying
+		r.setStatusConditionWithMessage(ReadyConditionType, metav1.ConditionUnknown,
+			ReleaseReason(deployed.Reason), deployed.Message)
+	}
+
+	r.Status.ObservedGeneration = r.Generation
+}
+
+// getReleaseStrategyName returns the namespace and name of the ReleaseStrategy used for this release, or an empty
+// string if it's not known yet.
+func (r *Release) getReleaseStrategyName() string {
+	if r.Status.ReleaseStrategy == nil {
+		return ""
+	}
+
+	return r.Status.ReleaseStrategy.String()
+}
+
+// +kubebuilder:object:root=true
+
+// ReleaseList contains a list of Release
+type ReleaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Release `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Release{}, &ReleaseList{})
+}
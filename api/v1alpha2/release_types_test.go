@@ -0,0 +1,867 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type conditionValues struct {
+	status  metav1.ConditionStatus
+	reason  ReleaseReason
+	message string
+}
+
+var _ = Describe("Release type", func() {
+
+	var r *Release
+
+	BeforeEach(func() {
+		r = &Release{}
+		r.Status.StartTime = &metav1.Time{Time: time.Now().Add(-time.Hour * 1)}
+		r.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+		r.Status.Conditions = []metav1.Condition{{}}
+	})
+
+	Context("When ObjectReference.NamespacedName method is called", func() {
+		It("should return the namespace and name of the referenced object", func() {
+			reference := NewObjectReference("default", "name")
+			Expect(reference.NamespacedName()).To(Equal(types.NamespacedName{Namespace: "default", Name: "name"}))
+		})
+	})
+
+	Context("When ObjectReference.String method is called", func() {
+		It("should return the namespace and name separated by a slash", func() {
+			Expect(NewObjectReference("default", "name").String()).To(Equal("default/name"))
+		})
+
+		It("should return just the name for cluster-scoped objects", func() {
+			Expect(NewObjectReference("", "name").String()).To(Equal("name"))
+		})
+	})
+
+	Context("When GetSnapshotName method is called", func() {
+		It("should return the Snapshot set in the spec", func() {
+			r.Spec.Snapshot = "snapshot"
+			r.Status.Snapshot = "other-snapshot"
+			Expect(r.GetSnapshotName()).To(Equal("snapshot"))
+		})
+
+		It("should return the Snapshot resolved in the status for rollbacks", func() {
+			r.Spec.Snapshot = ""
+			r.Spec.RollbackTo = "target"
+			r.Status.Snapshot = "snapshot"
+			Expect(r.GetSnapshotName()).To(Equal("snapshot"))
+		})
+	})
+
+	Context("When HasStarted method is called", func() {
+		It("should return false when Status.startTime is nil", func() {
+			r.Status.StartTime = nil
+			Expect(r.HasStarted()).To(BeFalse())
+		})
+
+		It("should return false when Status.startTime is zero", func() {
+			r.Status.StartTime = &metav1.Time{}
+			Expect(r.HasStarted()).To(BeFalse())
+		})
+
+		It("should return true when Status.startTime is not nil or zero", func() {
+			Expect(r.HasStarted()).To(BeTrue())
+		})
+	})
+
+	Context("When HasSucceeded method is called", func() {
+		It("should return false when condition.Status is ConditionUnknown", func() {
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:   releaseConditionType,
+				Status: metav1.ConditionUnknown,
+			}
+			Expect(r.HasSucceeded()).To(BeFalse())
+		})
+
+		It("should return true when condition.Status is ConditionTrue", func() {
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:   releaseConditionType,
+				Status: metav1.ConditionTrue,
+			}
+			Expect(r.HasSucceeded()).To(BeTrue())
+		})
+	})
+
+	Context("When IsDeployed method is called", func() {
+		It("should return true when AllComponentsDeployed condition status is True", func() {
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:   applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed,
+				Status: metav1.ConditionTrue,
+			}
+			Expect(r.IsDeployed()).To(BeTrue())
+		})
+
+		It("should return false when AllComponentsDeployed condition status is False", func() {
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:   applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed,
+				Status: metav1.ConditionFalse,
+			}
+			Expect(r.IsDeployed()).To(BeFalse())
+		})
+
+		It("should return false when AllComponentsDeployed condition status is Unknown", func() {
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:   applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed,
+				Status: metav1.ConditionUnknown,
+			}
+			Expect(r.IsDeployed()).To(BeFalse())
+		})
+	})
+
+	Context("When IsDeploying method is called", func() {
+		It("should return false when Status.deploymentStartTime is nil", func() {
+			r.Status.DeploymentStartTime = nil
+			Expect(r.IsDeploying()).To(BeFalse())
+		})
+
+		It("should return false when Status.deploymentStartTime is zero", func() {
+			r.Status.DeploymentStartTime = &metav1.Time{}
+			Expect(r.IsDeploying()).To(BeFalse())
+		})
+
+		It("should return true when Status.deploymentStartTime is not nil or zero", func() {
+			r.Status.DeploymentStartTime = &metav1.Time{Time: time.Now().Add(-time.Hour * 1)}
+			Expect(r.IsDeploying()).To(BeTrue())
+		})
+	})
+
+	Context("When IsDone method is called", func() {
+		It("should return false when condition struct is nil", func() {
+			Expect(r.IsDone()).To(BeFalse())
+		})
+
+		It("should return true when Release status is finished", func() {
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:   releaseConditionType,
+				Status: metav1.ConditionTrue,
+			}
+			Expect(r.IsDone()).To(BeTrue())
+		})
+
+		It("should return false when condition.Status is ConditionUnknown", func() {
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:   releaseConditionType,
+				Status: metav1.ConditionUnknown,
+			}
+			Expect(r.IsDone()).To(BeFalse())
+		})
+
+		It("should return true when the Release is not valid", func() {
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:   ValidatedConditionType,
+				Status: metav1.ConditionFalse,
+			}
+			Expect(r.IsDone()).To(BeTrue())
+		})
+
+		It("should use the Released condition over the legacy condition", func() {
+			r.Status.Conditions = []metav1.Condition{
+				{Type: releaseConditionType, Status: metav1.ConditionTrue},
+				{Type: ReleasedConditionType, Status: metav1.ConditionUnknown},
+			}
+			Expect(r.IsDone()).To(BeFalse())
+		})
+	})
+
+	Context("When IsReady method is called", func() {
+		It("should return false when the Ready condition is not set", func() {
+			Expect(r.IsReady()).To(BeFalse())
+		})
+
+		It("should return true when the Release has been released and deployed", func() {
+			r.Status.StartTime = nil
+			r.Status.CompletionTime = nil
+			r.MarkRunning()
+			r.MarkSucceeded()
+			r.MarkDeploying(metav1.ConditionUnknown, "CommitsUnsynced", "0 of 3 components deployed")
+			Expect(r.IsReady()).To(BeFalse())
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseDeploying))
+
+			r.MarkDeployed("CommitsSynced", "3 of 3 components deployed")
+			Expect(r.IsReady()).To(BeTrue())
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseSucceeded))
+		})
+	})
+
+	Context("When MarkDeployed method is called", func() {
+		It("should do nothing if the Release is already deployed", func() {
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:    applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed,
+				Status:  metav1.ConditionTrue,
+				Reason:  "test",
+				Message: "abc",
+			}
+			r.MarkDeployed("foo", "bar")
+			Expect(len(r.Status.Conditions)).To(Equal(1))
+			Expect(r.Status.Conditions[0]).To(MatchFields(IgnoreMissing|IgnoreExtras, Fields{
+				"Status":  Equal(metav1.ConditionTrue),
+				"Type":    Equal(applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed),
+				"Reason":  Equal("test"),
+				"Message": Equal("abc"),
+			}))
+		})
+
+		It("should properly register the status if passed status is true", func() {
+			r.MarkDeploying(metav1.ConditionFalse, "CommitsUnsynced", "1 of 3 components deployed")
+			r.MarkDeployed("CommitsSynced", "3 of 3 components deployed")
+			statusCondition := meta.FindStatusCondition(r.Status.Conditions,
+				applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed)
+			Expect(*statusCondition).To(MatchFields(IgnoreExtras, Fields{
+				"Status":  Equal(metav1.ConditionTrue),
+				"Reason":  Equal("CommitsSynced"),
+				"Message": Equal("3 of 3 components deployed"),
+			}))
+		})
+	})
+
+	Context("When MarkDeploying method is called", func() {
+		It("should properly register the status to the release if passed status is false", func() {
+			r.MarkDeploying(metav1.ConditionFalse, "CommitsUnsynced", "1 of 3 components deployed")
+			statusCondition := meta.FindStatusCondition(r.Status.Conditions,
+				applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed)
+			Expect(*statusCondition).To(MatchFields(IgnoreExtras, Fields{
+				"Status":  Equal(metav1.ConditionFalse),
+				"Reason":  Equal("CommitsUnsynced"),
+				"Message": Equal("1 of 3 components deployed"),
+			}))
+		})
+
+		It("should properly register the status to the release if passed status is unknown", func() {
+			r.MarkDeploying(metav1.ConditionUnknown, "CommitsUnsynced", "0 of 3 components deployed")
+			statusCondition := meta.FindStatusCondition(r.Status.Conditions,
+				applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed)
+			Expect(*statusCondition).To(MatchFields(IgnoreExtras, Fields{
+				"Status":  Equal(metav1.ConditionUnknown),
+				"Reason":  Equal("CommitsUnsynced"),
+				"Message": Equal("0 of 3 components deployed"),
+			}))
+		})
+
+		It("should not change the release status if passed status that is true", func() {
+			r.MarkDeploying(metav1.ConditionTrue, "CommitsSynced", "3 of 3 components deployed")
+			statusCondition := meta.FindStatusCondition(r.Status.Conditions,
+				applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed)
+			Expect(statusCondition).To(BeNil())
+		})
+	})
+
+	Context("When MarkDeploymentSkipped method is called", func() {
+		It("should do nothing if the Release has not been released", func() {
+			r.MarkDeploymentSkipped("nothing to deploy")
+			Expect(meta.FindStatusCondition(r.Status.Conditions, DeployedConditionType)).To(BeNil())
+		})
+
+		It("should mark the Release as deployed and ready", func() {
+			r.Status.StartTime = nil
+			r.Status.CompletionTime = nil
+			r.MarkRunning()
+			r.MarkSucceeded()
+			r.MarkDeploymentSkipped("nothing to deploy")
+			Expect(*meta.FindStatusCondition(r.Status.Conditions, DeployedConditionType)).To(MatchFields(IgnoreExtras, Fields{
+				"Status":  Equal(metav1.ConditionTrue),
+				"Reason":  Equal(ReleaseReasonDeploymentSkipped.String()),
+				"Message": Equal("nothing to deploy"),
+			}))
+			Expect(meta.FindStatusCondition(r.Status.Conditions,
+				applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed)).To(BeNil())
+			Expect(r.IsDeployed()).To(BeTrue())
+			Expect(r.IsReady()).To(BeTrue())
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseSucceeded))
+		})
+	})
+
+	Context("When MarkFailed method is called", func() {
+		It("should do nothing if the Release is finished", func() {
+			args := conditionValues{
+				reason:  ReleaseReasonValidationError,
+				message: "cow say m00",
+			}
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:    releaseConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  ReleaseReasonTargetDisabledError.String(),
+				Message: "Testcase one message string",
+			}
+			r.MarkFailed(args.reason, args.message)
+			Expect(len(r.Status.Conditions)).To(Equal(1))
+			Expect(r.Status.Conditions[0]).To(MatchFields(IgnoreMissing|IgnoreExtras, Fields{
+				"Status":  Equal(metav1.ConditionTrue),
+				"Type":    Equal(releaseConditionType),
+				"Reason":  Equal(ReleaseReasonTargetDisabledError.String()),
+				"Message": Equal("Testcase one message string"),
+			}))
+		})
+
+		It("should register the Failed condition when the Release is not complete", func() {
+			args := conditionValues{
+				reason:  ReleaseReasonValidationError,
+				message: "what does the fox say",
+			}
+			r.Status.CompletionTime = nil
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:    releaseConditionType,
+				Status:  metav1.ConditionUnknown,
+				Reason:  ReleaseReasonTargetDisabledError.String(),
+				Message: "Testcase two message string",
+			}
+			r.MarkFailed(args.reason, args.message)
+			Expect(len(r.Status.Conditions)).To(Equal(3))
+			Expect(r.Status.Conditions[0]).To(MatchFields(IgnoreMissing|IgnoreExtras, Fields{
+				"Status":  Equal(metav1.ConditionFalse),
+				"Type":    Equal(releaseConditionType),
+				"Reason":  Equal(ReleaseReasonValidationError.String()),
+				"Message": Equal("what does the fox say"),
+			}))
+			Expect(*meta.FindStatusCondition(r.Status.Conditions, ReleasedConditionType)).To(MatchFields(IgnoreExtras, Fields{
+				"Status":  Equal(metav1.ConditionFalse),
+				"Reason":  Equal(ReleaseReasonValidationError.String()),
+				"Message": Equal("what does the fox say"),
+			}))
+			Expect(meta.IsStatusConditionFalse(r.Status.Conditions, ReadyConditionType)).To(BeTrue())
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseFailed))
+		})
+	})
+
+	Context("When MarkInvalid method is called", func() {
+		It("should register the Invalid status when the Release is not finished", func() {
+			args := conditionValues{
+				reason:  ReleaseReasonPipelineFailed,
+				message: "what does the fox say",
+			}
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:    "Fail",
+				Status:  metav1.ConditionUnknown,
+				Reason:  ReleaseReasonReleasePlanValidationError.String(),
+				Message: "message string",
+			}
+			r.MarkInvalid(args.reason, args.message)
+			Expect(len(r.Status.Conditions)).To(Equal(4))
+			for _, conditionType := range []string{ValidatedConditionType, ReadyConditionType, releaseConditionType} {
+				Expect(*meta.FindStatusCondition(r.Status.Conditions, conditionType)).To(MatchFields(IgnoreExtras, Fields{
+					"Status":  Equal(metav1.ConditionFalse),
+					"Reason":  Equal(ReleaseReasonPipelineFailed.String()),
+					"Message": Equal("what does the fox say"),
+				}))
+			}
+			Expect(r.Status.Conditions[0].Message).To(Equal("message string"))
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseFailed))
+			Expect(r.IsDone()).To(BeTrue())
+		})
+
+		It("should not register the Invalid status when the Release status is already finished", func() {
+			args := conditionValues{
+				reason:  ReleaseReasonTargetDisabledError,
+				message: "how now brown cow",
+			}
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:    releaseConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  ReleaseReasonValidationError.String(),
+				Message: "message string",
+			}
+			r.MarkInvalid(args.reason, args.message)
+			Expect(len(r.Status.Conditions)).To(Equal(1))
+			Expect(r.Status.Conditions[0]).To(MatchFields(IgnoreMissing|IgnoreExtras, Fields{
+				"Status":  Equal(metav1.ConditionTrue),
+				"Type":    Equal(releaseConditionType),
+				"Reason":  Equal(ReleaseReasonValidationError.String()),
+				"Message": Equal("message string"),
+			}))
+		})
+	})
+
+	Context("When MarkRunning method is called", func() {
+		It("should do nothing when the Release is already running", func() {
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:    "fake type",
+				Status:  metav1.ConditionFalse,
+				Reason:  ReleaseReasonPipelineFailed.String(),
+				Message: "fake message",
+			}
+			r.MarkRunning()
+			Expect(r.Status.StartTime).ToNot(Equal(time.Time{}))
+			Expect(r.Status.StartTime).ToNot(BeNil())
+			Expect(len(r.Status.Conditions)).To(Equal(1))
+			Expect(r.Status.Conditions[0]).To(MatchFields(IgnoreMissing|IgnoreExtras, Fields{
+				"Status":  Equal(metav1.ConditionFalse),
+				"Type":    Equal("fake type"),
+				"Reason":  Equal(ReleaseReasonPipelineFailed.String()),
+				"Message": Equal("fake message"),
+			}))
+		})
+
+		It("should register the Running status when the Release is not running", func() {
+			r.Status.StartTime = nil
+			r.Status.StartTime = &metav1.Time{
+				Time: time.Time{},
+			}
+			r.MarkRunning()
+			Expect(r.Status.StartTime.Time).ToNot(Equal(time.Time{}))
+			Expect(r.Status.StartTime).ToNot(BeNil())
+			Expect(len(r.Status.Conditions)).To(Equal(5))
+			Expect(meta.IsStatusConditionTrue(r.Status.Conditions, ValidatedConditionType)).To(BeTrue())
+			for _, conditionType := range []string{ReleasedConditionType, ReadyConditionType, releaseConditionType} {
+				Expect(*meta.FindStatusCondition(r.Status.Conditions, conditionType)).To(MatchFields(IgnoreExtras, Fields{
+					"Status":  Equal(metav1.ConditionUnknown),
+					"Reason":  Equal(ReleaseReasonRunning.String()),
+					"Message": Equal(""),
+				}))
+			}
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseReleasing))
+		})
+
+		It("should register the Running status when the Release is not running", func() {
+			r.Status.StartTime = nil
+			r.MarkRunning()
+			Expect(r.Status.StartTime.Time).ToNot(Equal(time.Time{}))
+			Expect(r.Status.StartTime).ToNot(BeNil())
+			Expect(len(r.Status.Conditions)).To(Equal(5))
+			Expect(meta.IsStatusConditionTrue(r.Status.Conditions, ValidatedConditionType)).To(BeTrue())
+			for _, conditionType := range []string{ReleasedConditionType, ReadyConditionType, releaseConditionType} {
+				Expect(*meta.FindStatusCondition(r.Status.Conditions, conditionType)).To(MatchFields(IgnoreExtras, Fields{
+					"Status":  Equal(metav1.ConditionUnknown),
+					"Reason":  Equal(ReleaseReasonRunning.String()),
+					"Message": Equal(""),
+				}))
+			}
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseReleasing))
+		})
+	})
+
+	Context("When MarkSucceeded method is called", func() {
+		It("should do nothing when when the Release is already successful", func() {
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:    releaseConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  ReleaseReasonTargetDisabledError.String(),
+				Message: "lucy in the sky with diamonds",
+			}
+			rr := r.DeepCopy()
+			r.MarkSucceeded()
+			Expect(r.Status.StartTime).ToNot(BeNil())
+			Expect(r.Status.CompletionTime).ToNot(BeNil())
+			Expect(r.Status.CompletionTime.Time).To(BeTemporally(">=", rr.Status.CompletionTime.Time))
+			Expect(r.Status.CompletionTime.Time).To(BeTemporally(">", r.Status.StartTime.Time))
+			Expect(len(r.Status.Conditions)).To(Equal(1))
+			Expect(r.Status.Conditions[0]).To(MatchFields(IgnoreMissing|IgnoreExtras, Fields{
+				"Status":  Equal(metav1.ConditionFalse),
+				"Type":    Equal(releaseConditionType),
+				"Reason":  Equal(ReleaseReasonTargetDisabledError.String()),
+				"Message": Equal("lucy in the sky with diamonds"),
+			}))
+			rr = nil
+		})
+
+		It("register the Succeeded status when the Release is completed", func() {
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:    "Fail",
+				Status:  metav1.ConditionUnknown,
+				Reason:  ReleaseReasonValidationError.String(),
+				Message: "all your base belong to us",
+			}
+			rr := r.DeepCopy()
+			r.MarkSucceeded()
+			Expect(r.Status.CompletionTime).ToNot(BeNil())
+			Expect(r.Status.CompletionTime.Time).To(BeTemporally(">=", rr.Status.CompletionTime.Time))
+			Expect(r.Status.CompletionTime.Time).To(BeTemporally(">", r.Status.StartTime.Time))
+			Expect(len(r.Status.Conditions)).To(Equal(4))
+			for _, conditionType := range []string{ReleasedConditionType, releaseConditionType} {
+				Expect(*meta.FindStatusCondition(r.Status.Conditions, conditionType)).To(MatchFields(IgnoreExtras, Fields{
+					"Status":  Equal(metav1.ConditionTrue),
+					"Reason":  Equal(ReleaseReasonSucceeded.String()),
+					"Message": Equal(""),
+				}))
+			}
+			Expect(*meta.FindStatusCondition(r.Status.Conditions, ReadyConditionType)).To(MatchFields(IgnoreExtras, Fields{
+				"Status": Equal(metav1.ConditionUnknown),
+				"Reason": Equal(ReleaseReasonDeploymentPending.String()),
+			}))
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseDeploying))
+			Expect(r.Status.Conditions[0].Message).To(Equal("all your base belong to us"))
+			rr = nil
+		})
+	})
+
+	Context("When the conditions are updated", func() {
+		It("should register the observed generation", func() {
+			r.Generation = 2
+			r.Status.StartTime = nil
+			r.MarkRunning()
+			Expect(r.Status.ObservedGeneration).To(Equal(int64(2)))
+			Expect(meta.FindStatusCondition(r.Status.Conditions, ReadyConditionType).ObservedGeneration).To(Equal(int64(2)))
+		})
+	})
+
+	Context("When setStatusCondition method is called", func() {
+		It("should update condition with provided arguments, and empty message", func() {
+			args := conditionValues{
+				status: metav1.ConditionStatus("fake"),
+				reason: ReleaseReasonPipelineFailed,
+			}
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:    releaseConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  ReleaseReasonTargetDisabledError.String(),
+				Message: "lucy in the sky with diamonds",
+			}
+			r.setStatusCondition(releaseConditionType, args.status, args.reason)
+			Expect(len(r.Status.Conditions)).To(Equal(1))
+			Expect(r.Status.Conditions[0]).To(MatchFields(IgnoreMissing|IgnoreExtras, Fields{
+				"Status":  Equal(args.status),
+				"Reason":  Equal(args.reason.String()),
+				"Message": Equal(""),
+			}))
+		})
+	})
+
+	Context("When setStatusConditionWithMessage method is called", func() {
+		It("should update condition with provided arguments", func() {
+			args := conditionValues{
+				status:  metav1.ConditionStatus("fake"),
+				reason:  ReleaseReasonValidationError,
+				message: "fake",
+			}
+			r.Status.Conditions[0] = metav1.Condition{
+				Type:    releaseConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  ReleaseReasonPipelineFailed.String(),
+				Message: "lucy in the sky with diamonds",
+			}
+			r.setStatusConditionWithMessage(releaseConditionType, args.status, args.reason, args.message)
+			Expect(len(r.Status.Conditions)).To(Equal(1))
+			Expect(r.Status.Conditions[0]).To(MatchFields(IgnoreMissing|IgnoreExtras, Fields{
+				"Status":  Equal(args.status),
+				"Reason":  Equal(args.reason.String()),
+				"Message": Equal(args.message),
+			}))
+		})
+	})
+
+	Context("When the Release status is updated", func() {
+		BeforeEach(func() {
+			r = &Release{}
+		})
+
+		It("should be validated and releasing after calling MarkRunning", func() {
+			r.MarkRunning()
+
+			Expect(meta.IsStatusConditionTrue(r.Status.Conditions, ValidatedConditionType)).To(BeTrue())
+			Expect(r.HasStarted()).To(BeTrue())
+			Expect(r.IsDone()).To(BeFalse())
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseReleasing))
+		})
+
+		It("should be done and not ready after calling MarkFailed", func() {
+			r.MarkRunning()
+			r.MarkFailed(ReleaseReasonPipelineFailed, "")
+
+			Expect(r.IsDone()).To(BeTrue())
+			Expect(r.HasSucceeded()).To(BeFalse())
+			Expect(r.IsReady()).To(BeFalse())
+			Expect(meta.FindStatusCondition(r.Status.Conditions, releaseConditionType)).NotTo(BeNil())
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseFailed))
+		})
+
+		It("should be ready after the deployment is skipped", func() {
+			r.MarkRunning()
+			r.MarkSucceeded()
+			r.MarkDeploymentSkipped("")
+
+			Expect(r.IsReady()).To(BeTrue())
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseSucceeded))
+		})
+
+		It("should be failed after calling MarkDeploymentFailed", func() {
+			r.MarkRunning()
+			r.MarkSucceeded()
+			r.MarkDeploying(metav1.ConditionFalse, "CommitsUnsynced", "")
+			Expect(r.HasDeploymentFailed()).To(BeFalse())
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseDeploying))
+
+			r.MarkDeploymentFailed(ReleaseReasonDeploymentFailed, "component foo failed to sync")
+			Expect(r.HasDeploymentFailed()).To(BeTrue())
+			Expect(r.IsDeployed()).To(BeFalse())
+			Expect(r.IsReady()).To(BeFalse())
+			Expect(r.Status.DeploymentCompletionTime).NotTo(BeNil())
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseFailed))
+
+			condition := meta.FindStatusCondition(r.Status.Conditions, ReadyConditionType)
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(ReleaseReasonDeploymentFailed.String()))
+			Expect(condition.Message).To(Equal("component foo failed to sync"))
+		})
+
+		It("should not change a failed deployment when calling MarkDeploying or MarkDeployed", func() {
+			r.MarkRunning()
+			r.MarkSucceeded()
+			r.MarkDeploying(metav1.ConditionFalse, "", "")
+			r.MarkDeploymentFailed(ReleaseReasonDeploymentTimedOut, "")
+
+			r.MarkDeploying(metav1.ConditionUnknown, "", "")
+			r.MarkDeployed("", "")
+			Expect(r.HasDeploymentFailed()).To(BeTrue())
+			Expect(meta.FindStatusCondition(r.Status.Conditions, DeployedConditionType).Reason).To(
+				Equal(ReleaseReasonDeploymentTimedOut.String()))
+		})
+
+		It("should not mark the deployment as failed if it hasn't started", func() {
+			r.MarkRunning()
+			r.MarkSucceeded()
+			r.MarkDeploymentFailed(ReleaseReasonDeploymentFailed, "")
+
+			Expect(r.HasDeploymentFailed()).To(BeFalse())
+		})
+
+		It("should track the deployment to each Environment", func() {
+			r.MarkRunning()
+			r.MarkSucceeded()
+			Expect(r.GetCurrentEnvironment()).To(BeNil())
+
+			stageBinding := NewObjectReference("managed", "stage-binding")
+			r.MarkEnvironmentDeploying("stage", stageBinding)
+			Expect(r.IsDeploying()).To(BeTrue())
+			Expect(r.Status.SnapshotEnvironmentBinding).To(Equal(stageBinding))
+			Expect(r.GetCurrentEnvironment().Name).To(Equal("stage"))
+			Expect(r.GetCurrentEnvironment().State).To(Equal(EnvironmentDeploymentStateDeploying))
+			Expect(r.GetCurrentEnvironment().DeploymentStartTime).To(Equal(r.Status.DeploymentStartTime))
+
+			r.MarkEnvironmentDeployed("CommitsSynced")
+			Expect(r.GetCurrentEnvironment().State).To(Equal(EnvironmentDeploymentStateDeployed))
+			Expect(r.GetCurrentEnvironment().DeploymentCompletionTime).NotTo(BeNil())
+			Expect(r.IsDeployed()).To(BeFalse())
+
+			r.MarkEnvironmentPending("prod")
+			Expect(r.GetCurrentEnvironment().State).To(Equal(EnvironmentDeploymentStatePending))
+
+			prodBinding := NewObjectReference("managed", "prod-binding")
+			r.MarkEnvironmentDeploying("prod", prodBinding)
+			Expect(r.Status.SnapshotEnvironmentBinding).To(Equal(prodBinding))
+			Expect(r.Status.DeploymentStartTime).To(Equal(r.Status.Environments[0].DeploymentStartTime))
+
+			r.MarkDeployed("CommitsSynced", "")
+			Expect(r.IsDeployed()).To(BeTrue())
+			Expect(r.Status.Environments).To(HaveLen(2))
+			Expect(r.GetCurrentEnvironment().State).To(Equal(EnvironmentDeploymentStateDeployed))
+		})
+
+		It("should mark the current Environment as failed when the deployment fails", func() {
+			r.MarkRunning()
+			r.MarkSucceeded()
+			r.MarkEnvironmentDeploying("stage", NewObjectReference("managed", "stage-binding"))
+			r.MarkDeploymentFailed(ReleaseReasonDeploymentFailed, "component foo failed to sync")
+
+			Expect(r.HasDeploymentFailed()).To(BeTrue())
+			Expect(r.GetCurrentEnvironment().State).To(Equal(EnvironmentDeploymentStateFailed))
+			Expect(r.GetCurrentEnvironment().Message).To(Equal("component foo failed to sync"))
+
+			r.MarkEnvironmentPending("prod")
+			Expect(r.Status.Environments).To(HaveLen(1))
+		})
+
+		It("should only be deployed once the verification succeeds", func() {
+			r.MarkRunning()
+			r.MarkSucceeded()
+			r.MarkEnvironmentDeploying("stage", NewObjectReference("managed", "stage-binding"))
+			r.MarkEnvironmentDeployed("CommitsSynced")
+
+			pipelineRun := NewObjectReference("managed", "verification-pipelinerun")
+			r.MarkVerifying(pipelineRun)
+			Expect(r.IsVerifying()).To(BeTrue())
+			Expect(r.IsDeployed()).To(BeFalse())
+			Expect(r.Status.Verification.PipelineRun).To(Equal(pipelineRun))
+			Expect(meta.FindStatusCondition(r.Status.Conditions, DeployedConditionType).Reason).To(
+				Equal(ReleaseReasonVerifying.String()))
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseDeploying))
+
+			r.MarkVerified()
+			Expect(r.IsVerifying()).To(BeFalse())
+			Expect(r.Status.Verification.CompletionTime).NotTo(BeNil())
+			Expect(r.IsDeployed()).To(BeTrue())
+			Expect(r.IsReady()).To(BeTrue())
+		})
+
+		It("should fail the deployment when the verification fails", func() {
+			r.MarkRunning()
+			r.MarkSucceeded()
+			r.MarkEnvironmentDeploying("stage", NewObjectReference("managed", "stage-binding"))
+			r.MarkEnvironmentDeployed("CommitsSynced")
+			r.MarkVerifying(NewObjectReference("managed", "verification-pipelinerun"))
+			r.MarkVerificationFailed("smoke tests failed")
+
+			Expect(r.HasDeploymentFailed()).To(BeTrue())
+			Expect(r.IsReady()).To(BeFalse())
+			condition := meta.FindStatusCondition(r.Status.Conditions, DeployedConditionType)
+			Expect(condition.Reason).To(Equal(ReleaseReasonVerificationFailed.String()))
+			Expect(condition.Message).To(Equal("smoke tests failed"))
+			Expect(r.GetCurrentEnvironment().State).To(Equal(EnvironmentDeploymentStateDeployed))
+		})
+
+		It("should be released right away after calling MarkReleasePipelineSkipped", func() {
+			r.MarkReleasePipelineSkipped()
+
+			Expect(r.HasStarted()).To(BeTrue())
+			Expect(r.HasSucceeded()).To(BeTrue())
+			Expect(r.IsReleasePipelineSkipped()).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(r.Status.Conditions, ValidatedConditionType)).To(BeTrue())
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseDeploying))
+		})
+
+		It("should only record the first previous deployment of an Environment", func() {
+			r.Spec.Snapshot = "new-snapshot"
+			r.MarkRunning()
+			r.MarkSucceeded()
+
+			r.RecordPreviousDeployment("stage", "old-snapshot", []applicationapiv1alpha1.BindingComponent{
+				{Name: "old-component"},
+			})
+			Expect(r.GetCurrentEnvironment().Name).To(Equal("stage"))
+			Expect(r.GetCurrentEnvironment().PreviousSnapshot).To(Equal("old-snapshot"))
+			Expect(r.GetCurrentEnvironment().PreviousComponents).To(Equal([]applicationapiv1alpha1.BindingComponent{
+				{Name: "old-component"},
+			}))
+
+			r.RecordPreviousDeployment("stage", "new-snapshot", []applicationapiv1alpha1.BindingComponent{
+				{Name: "new-component"},
+			})
+			Expect(r.GetCurrentEnvironment().PreviousSnapshot).To(Equal("old-snapshot"))
+			Expect(r.GetCurrentEnvironment().PreviousComponents).To(Equal([]applicationapiv1alpha1.BindingComponent{
+				{Name: "old-component"},
+			}))
+		})
+
+		It("should not record the Snapshot of the Release as the previous one", func() {
+			r.Spec.Snapshot = "new-snapshot"
+			r.MarkRunning()
+			r.MarkSucceeded()
+
+			r.RecordPreviousDeployment("stage", "new-snapshot", []applicationapiv1alpha1.BindingComponent{
+				{Name: "component"},
+			})
+			Expect(r.GetCurrentEnvironment().PreviousSnapshot).To(BeEmpty())
+			Expect(r.GetCurrentEnvironment().PreviousComponents).To(BeEmpty())
+		})
+
+		It("should only be rolled back once the deployment fails", func() {
+			r.MarkRunning()
+			r.MarkSucceeded()
+			r.MarkEnvironmentDeploying("stage", NewObjectReference("managed", "stage-binding"))
+
+			r.MarkRolledBack("")
+			Expect(r.IsRollbackDone()).To(BeFalse())
+
+			r.MarkDeploymentFailed(ReleaseReasonDeploymentTimedOut, "")
+			r.MarkRolledBack("the Environment stage was rolled back to the Snapshot old-snapshot")
+			Expect(r.IsRollbackDone()).To(BeTrue())
+			Expect(r.IsRolledBack()).To(BeTrue())
+			Expect(r.Status.Phase).To(Equal(ReleasePhaseFailed))
+
+			condition := meta.FindStatusCondition(r.Status.Conditions, RolledBackConditionType)
+			Expect(condition.Reason).To(Equal(ReleaseReasonRolledBack.String()))
+			Expect(condition.Message).To(Equal("the Environment stage was rolled back to the Snapshot old-snapshot"))
+		})
+
+		It("should not be rolled back after calling MarkRollbackSkipped", func() {
+			r.MarkRunning()
+			r.MarkSucceeded()
+			r.MarkEnvironmentDeploying("stage", NewObjectReference("managed", "stage-binding"))
+			r.MarkDeploymentFailed(ReleaseReasonDeploymentFailed, "")
+
+			r.MarkRollbackSkipped("")
+			r.MarkRolledBack("")
+			Expect(r.IsRollbackDone()).To(BeTrue())
+			Expect(r.IsRolledBack()).To(BeFalse())
+		})
+
+		It("should record the generation observed when the conditions were updated", func() {
+			r.Generation = 2
+			r.MarkRunning()
+
+			Expect(r.Status.ObservedGeneration).To(Equal(int64(2)))
+			Expect(r.Status.Conditions).To(ContainElement(HaveField("ObservedGeneration", int64(2))))
+		})
+	})
+
+	Context("When ValidateRollbackTarget method is called", func() {
+		var rollback, target *Release
+
+		BeforeEach(func() {
+			rollback = &Release{
+				ObjectMeta: metav1.ObjectMeta{Name: "rollback"},
+				Spec: ReleaseSpec{
+					ReleasePlan: "release-plan",
+					RollbackTo:  "target",
+				},
+			}
+			target = &Release{
+				ObjectMeta: metav1.ObjectMeta{Name: "target"},
+				Spec: ReleaseSpec{
+					Snapshot:    "snapshot",
+					ReleasePlan: "release-plan",
+				},
+			}
+			target.MarkRunning()
+			target.MarkSucceeded()
+			target.MarkEnvironmentDeploying("production", NewObjectReference("managed", "binding"))
+			target.MarkDeployed("CommitsSynced", "")
+		})
+
+		It("should accept a Release of the same ReleasePlan that was deployed", func() {
+			Expect(rollback.ValidateRollbackTarget(target)).To(Succeed())
+		})
+
+		It("should reject a Release using a different ReleasePlan", func() {
+			target.Spec.ReleasePlan = "other-release-plan"
+			Expect(rollback.ValidateRollbackTarget(target)).To(MatchError(
+				"the Release target to roll back to uses the ReleasePlan other-release-plan instead of release-plan"))
+		})
+
+		It("should reject a Release that was never deployed", func() {
+			target.Status = ReleaseStatus{}
+			target.MarkRunning()
+			target.MarkSucceeded()
+			target.MarkDeploymentSkipped("")
+			Expect(rollback.ValidateRollbackTarget(target)).To(MatchError(
+				"the Release target to roll back to was never successfully deployed"))
+		})
+
+		It("should reject a Release whose deployment failed", func() {
+			target.Status = ReleaseStatus{}
+			target.MarkRunning()
+			target.MarkSucceeded()
+			target.MarkEnvironmentDeploying("production", NewObjectReference("managed", "binding"))
+			target.MarkDeploymentFailed(ReleaseReasonDeploymentFailed, "")
+			Expect(rollback.ValidateRollbackTarget(target)).To(MatchError(
+				"the Release target to roll back to was never successfully deployed"))
+		})
+	})
+})
@@ -0,0 +1,206 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReleaseStrategySpec is the spec of the ReleaseStrategy a Release was processed with, once the settings inherited
+// from a ClusterReleaseStrategy are resolved. It mirrors the spec of the v1alpha1 ReleaseStrategy.
+type ReleaseStrategySpec struct {
+	// ClusterReleaseStrategy is the name of a ClusterReleaseStrategy to inherit from. The fields set in the
+	// ReleaseStrategy override the inherited ones, with params, workspaces and task overrides merged by name
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	ClusterReleaseStrategy string `json:"clusterReleaseStrategy,omitempty"`
+
+	// Release Tekton Pipeline to execute. Either Pipeline or PipelineRef has to be set unless inherited
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	Pipeline string `json:"pipeline,omitempty"`
+
+	// Bundle is a reference to the Tekton bundle where to find the pipeline. Deprecated: use PipelineRef with the
+	// bundles resolver instead
+	// +optional
+	Bundle string `json:"bundle,omitempty"`
+
+	// PipelineRef is a reference to the release Tekton Pipeline to be fetched by a Tekton remote resolver
+	// +optional
+	PipelineRef *PipelineRef `json:"pipelineRef,omitempty"`
+
+	// Params to pass to the pipeline
+	// +optional
+	Params []Params `json:"params,omitempty"`
+
+	// Policy to validate before releasing an artifact. It has to be set unless inherited
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	Policy string `json:"policy,omitempty"`
+
+	// PersistentVolumeClaim is the pvc to use in the Release pipeline namespace. It will be bound to a workspace
+	// named release-workspace. Deprecated: use Workspaces instead
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+
+	// Workspaces is the list of workspaces to bind to the release PipelineRun
+	// +optional
+	Workspaces []Workspace `json:"workspaces,omitempty"`
+
+	// ServiceAccount is the name of the service account to use in the
+	// release PipelineRun to gain elevated privileges
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// PodTemplate holds the scheduling and security settings for the pods of the release PipelineRun
+	// +optional
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+
+	// TaskRunSpecs holds the settings to override for specific tasks of the release Pipeline
+	// +optional
+	TaskRunSpecs []TaskRunSpec `json:"taskRunSpecs,omitempty"`
+}
+
+// PodTemplate holds the settings used to schedule and run the pods of the release PipelineRun
+type PodTemplate struct {
+	// NodeSelector is a selector which must be true for the pods to fit on a node
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are the pods' tolerations
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity holds the pods' scheduling constraints
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// SecurityContext holds the pod-level security attributes and common container settings
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass to assign to the pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// TaskRunSpec holds the settings to override for a specific task of the release Pipeline
+type TaskRunSpec struct {
+	// PipelineTaskName is the name of the task in the release Pipeline
+	// +required
+	PipelineTaskName string `json:"pipelineTaskName"`
+
+	// ServiceAccount is the name of the service account to use for the task instead of the PipelineRun one
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// ComputeResources are the compute resources to request for the task
+	// +optional
+	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
+}
+
+// PipelineRef holds the information needed by a Tekton remote resolver to fetch the release Pipeline
+type PipelineRef struct {
+	// Resolver is the name of the Tekton resolver to be used (e.g. bundles, git, cluster, hub)
+	// +kubebuilder:validation:Enum=bundles;git;cluster;hub
+	// +required
+	Resolver string `json:"resolver"`
+
+	// Params is the list of parameters passed to the resolver to locate the release Pipeline
+	// +optional
+	Params []ResolverParam `json:"params,omitempty"`
+}
+
+// ResolverParam holds the name and value of a parameter passed to a Tekton remote resolver
+type ResolverParam struct {
+	// Name is the name of the parameter
+	// +required
+	Name string `json:"name"`
+
+	// Value is the value of the parameter
+	// +required
+	Value string `json:"value"`
+}
+
+// Params holds the definition of a parameter that should be passed to the release Pipeline
+type Params struct {
+	// Name is the name of the parameter
+	Name string `json:"name"`
+
+	// Value is the string value of the parameter
+	Value string `json:"value,omitempty"`
+
+	// Values is a list of values for the parameter
+	Values []string `json:"values,omitempty"`
+
+	// ValueFrom is a reference to a source in the ReleaseStrategy namespace the value of the parameter
+	// should be taken from
+	// +optional
+	ValueFrom *ParamValueSource `json:"valueFrom,omitempty"`
+}
+
+// ParamValueSource represents a source for the value of a parameter. Only one of its fields may be set.
+type ParamValueSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap. The value of that key will be passed to the release Pipeline
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret. To avoid exposing its content in the PipelineRun, the value of the
+	// key is never passed to the release Pipeline. The name of the Secret is passed instead, so it can be mounted
+	// by the Pipeline tasks
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// Workspace defines a workspace to be bound to the release PipelineRun. Only one of its volume sources may be set.
+type Workspace struct {
+	// Name is the name of the workspace declared by the release Pipeline
+	// +required
+	Name string `json:"name"`
+
+	// SubPath is a directory within the volume to use as the root of the workspace
+	// +optional
+	SubPath string `json:"subPath,omitempty"`
+
+	// PersistentVolumeClaim is a reference to an existing PersistentVolumeClaim in the release Pipeline namespace
+	// +optional
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+
+	// VolumeClaimTemplate is a template for a PersistentVolumeClaim that will be created for each release PipelineRun
+	// and deleted along with it
+	// +optional
+	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
+
+	// EmptyDir is a temporary directory that shares the release PipelineRun lifetime
+	// +optional
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+
+	// ConfigMap is a ConfigMap in the release Pipeline namespace to be mounted as a read-only workspace
+	// +optional
+	ConfigMap *corev1.ConfigMapVolumeSource `json:"configMap,omitempty"`
+
+	// Secret is a Secret in the release Pipeline namespace to be mounted as a read-only workspace
+	// +optional
+	Secret *corev1.SecretVolumeSource `json:"secret,omitempty"`
+
+	// CSI is a volume provided by a CSI driver
+	// +optional
+	CSI *corev1.CSIVolumeSource `json:"csi,omitempty"`
+}
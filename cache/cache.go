@@ -34,16 +34,88 @@ func SetupComponentCache(mgr ctrl.Manager) error {
 		"spec.application", componentIndexFunc)
 }
 
-// SetupReleasePlanAdmissionCache adds a new index field to be able to search ReleasePlanAdmissions by origin.
+// SetupReleasePlanAdmissionCache adds a new index field to be able to search ReleasePlanAdmissions by origin. Each
+// ReleasePlanAdmission is indexed by all the origins it lists, and those selecting origins by label are indexed by
+// v1alpha1.OriginSelectorIndexValue.
 func SetupReleasePlanAdmissionCache(mgr ctrl.Manager) error {
 	releasePlanAdmissionIndexFunc := func(obj client.Object) []string {
-		return []string{obj.(*v1alpha1.ReleasePlanAdmission).Spec.Origin}
+		return obj.(*v1alpha1.ReleasePlanAdmission).GetOriginIndexValues()
 	}
 
 	return mgr.GetCache().IndexField(context.Background(), &v1alpha1.ReleasePlanAdmission{},
 		"spec.origin", releasePlanAdmissionIndexFunc)
 }
 
+// SetupClusterReleaseStrategyCache adds new index fields to be able to search ClusterReleaseStrategies by the
+// in-cluster Pipeline and the EnterpriseContractPolicy they reference.
+func SetupClusterReleaseStrategyCache(mgr ctrl.Manager) error {
+	pipelineIndexFunc := func(obj client.Object) []string {
+		return getPipelineIndexValues(&obj.(*v1alpha1.ClusterReleaseStrategy).Spec)
+	}
+
+	if err := mgr.GetCache().IndexField(context.Background(), &v1alpha1.ClusterReleaseStrategy{},
+		"spec.pipeline", pipelineIndexFunc); err != nil {
+		return err
+	}
+
+	policyIndexFunc := func(obj client.Object) []string {
+		return getPolicyIndexValues(&obj.(*v1alpha1.ClusterReleaseStrategy).Spec)
+	}
+
+	return mgr.GetCache().IndexField(context.Background(), &v1alpha1.ClusterReleaseStrategy{},
+		"spec.policy", policyIndexFunc)
+}
+
+// SetupReleaseStrategyCache adds new index fields to be able to search ReleaseStrategies by the ConfigMaps referenced
+// in their params, by the in-cluster Pipeline and the EnterpriseContractPolicy they reference and by the
+// ClusterReleaseStrategy they inherit from.
+func SetupReleaseStrategyCache(mgr ctrl.Manager) error {
+	releaseStrategyIndexFunc := func(obj client.Object) []string {
+		var configMaps []string
+		for _, param := range obj.(*v1alpha1.ReleaseStrategy).Spec.Params {
+			if param.ValueFrom != nil && param.ValueFrom.ConfigMapKeyRef != nil {
+				configMaps = append(configMaps, param.ValueFrom.ConfigMapKeyRef.Name)
+			}
+		}
+
+		return configMaps
+	}
+
+	if err := mgr.GetCache().IndexField(context.Background(), &v1alpha1.ReleaseStrategy{},
+		"spec.params.valueFrom.configMapKeyRef.name", releaseStrategyIndexFunc); err != nil {
+		return err
+	}
+
+	pipelineIndexFunc := func(obj client.Object) []string {
+		return getPipelineIndexValues(&obj.(*v1alpha1.ReleaseStrategy).Spec)
+	}
+
+	if err := mgr.GetCache().IndexField(context.Background(), &v1alpha1.ReleaseStrategy{},
+		"spec.pipeline", pipelineIndexFunc); err != nil {
+		return err
+	}
+
+	policyIndexFunc := func(obj client.Object) []string {
+		return getPolicyIndexValues(&obj.(*v1alpha1.ReleaseStrategy).Spec)
+	}
+
+	if err := mgr.GetCache().IndexField(context.Background(), &v1alpha1.ReleaseStrategy{},
+		"spec.policy", policyIndexFunc); err != nil {
+		return err
+	}
+
+	clusterReleaseStrategyIndexFunc := func(obj client.Object) []string {
+		if clusterReleaseStrategy := obj.(*v1alpha1.ReleaseStrategy).Spec.ClusterReleaseStrategy; clusterReleaseStrategy != "" {
+			return []string{clusterReleaseStrategy}
+		}
+
+		return nil
+	}
+
+	return mgr.GetCache().IndexField(context.Background(), &v1alpha1.ReleaseStrategy{},
+		"spec.clusterReleaseStrategy", clusterReleaseStrategyIndexFunc)
+}
+
 // SetupSnapshotEnvironmentBindingCache adds a new index field to be able to search SnapshotEnvironmentBindings by environment.
 func SetupSnapshotEnvironmentBindingCache(mgr ctrl.Manager) error {
 	snapshotEnvironmentBindingIndexFunc := func(obj client.Object) []string {
@@ -53,3 +125,22 @@ func SetupSnapshotEnvironmentBindingCache(mgr ctrl.Manager) error {
 	return mgr.GetCache().IndexField(context.Background(), &applicationapiv1alpha1.SnapshotEnvironmentBinding{},
 		"spec.environment", snapshotEnvironmentBindingIndexFunc)
 }
+
+// getPipelineIndexValues returns the name of the in-cluster Pipeline referenced by the given spec. Pipelines
+// referenced by bundle or through a resolver are not indexed, as they are resolved using ResolutionRequests.
+func getPipelineIndexValues(spec *v1alpha1.ReleaseStrategySpec) []string {
+	if spec.Pipeline == "" || spec.Bundle != "" || spec.PipelineRef != nil {
+		return nil
+	}
+
+	return []string{spec.Pipeline}
+}
+
+// getPolicyIndexValues returns the name of the EnterpriseContractPolicy referenced by the given spec.
+func getPolicyIndexValues(spec *v1alpha1.ReleaseStrategySpec) []string {
+	if spec.Policy == "" {
+		return nil
+	}
+
+	return []string{spec.Policy}
+}
@@ -18,11 +18,12 @@ package release
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
 	"github.com/redhat-appstudio/release-service/gitops"
 	"github.com/redhat-appstudio/release-service/loader"
 	"github.com/redhat-appstudio/release-service/syncer"
@@ -39,7 +40,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/pkg/apis"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -52,15 +52,21 @@ type Adapter struct {
 	ctx     context.Context
 	loader  loader.ObjectLoader
 	logger  logr.Logger
-	release *v1alpha1.Release
+	release *v1alpha2.Release
 	syncer  *syncer.Syncer
 }
 
-// finalizerName is the finalizer name to be added to the Releases
-const finalizerName string = "appstudio.redhat.com/release-finalizer"
+const (
+	// finalizerName is the finalizer name to be added to the Releases
+	finalizerName string = "appstudio.redhat.com/release-finalizer"
+
+	// deploymentStatusRefreshInterval is the time to wait before reconciling a Release again to refresh the status of
+	// its components while it's being deployed
+	deploymentStatusRefreshInterval = 30 * time.Second
+)
 
 // NewAdapter creates and returns an Adapter instance.
-func NewAdapter(ctx context.Context, client client.Client, release *v1alpha1.Release, loader loader.ObjectLoader, logger logr.Logger) *Adapter {
+func NewAdapter(ctx context.Context, client client.Client, release *v1alpha2.Release, loader loader.ObjectLoader, logger logr.Logger) *Adapter {
 	return &Adapter{
 		client:  client,
 		ctx:     ctx,
@@ -123,23 +129,68 @@ func (a *Adapter) EnsureFinalizerIsAdded() (reconciler.OperationResult, error) {
 // If it is not, no further operations will occur for this Release.
 func (a *Adapter) EnsureReleasePlanAdmissionEnabled() (reconciler.OperationResult, error) {
 	_, err := a.loader.GetActiveReleasePlanAdmissionFromRelease(a.ctx, a.client, a.release)
-
-	if err != nil && strings.Contains(err.Error(), "multiple ReleasePlanAdmissions found") {
+	if goerrors.Is(err, loader.ErrMultipleReleasePlanAdmissions) || goerrors.Is(err, loader.ErrReleasePlanAdmissionDisabled) {
 		patch := client.MergeFrom(a.release.DeepCopy())
-		a.release.MarkInvalid(v1alpha1.ReleaseReasonValidationError, err.Error())
+		a.release.MarkInvalid(loader.GetActiveReleasePlanAdmissionErrorReason(err), err.Error())
 		return reconciler.RequeueOnErrorOrStop(a.client.Status().Patch(a.ctx, a.release, patch))
 	}
-	if err != nil && strings.Contains(err.Error(), "auto-release label set to false") {
+
+	return reconciler.ContinueProcessing()
+}
+
+// EnsureRollbackTargetIsValid is an operation that will ensure that the Release being processed, if it's a rollback,
+// rolls back to a Release using the same ReleasePlan whose deployment succeeded. If it doesn't, the Release will be
+// marked as invalid and no further operations will occur. The Snapshot of the Release rolled back to is recorded as
+// the one to release. Rollbacks skipping the release Pipeline are marked as released right away, so the Snapshot is
+// deployed again without running a release PipelineRun.
+func (a *Adapter) EnsureRollbackTargetIsValid() (reconciler.OperationResult, error) {
+	if a.release.Spec.RollbackTo == "" || a.release.HasStarted() {
+		return reconciler.ContinueProcessing()
+	}
+
+	rollbackTarget, err := a.loader.GetRelease(a.ctx, a.client, a.release.Spec.RollbackTo, a.release.Namespace)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconciler.RequeueWithError(err)
+	}
+	if err == nil {
+		err = a.release.ValidateRollbackTarget(rollbackTarget)
+	}
+	if err != nil {
 		patch := client.MergeFrom(a.release.DeepCopy())
-		a.release.MarkInvalid(v1alpha1.ReleaseReasonTargetDisabledError, err.Error())
+		a.release.MarkInvalid(v1alpha2.ReleaseReasonRollbackValidationError, err.Error())
 		return reconciler.RequeueOnErrorOrStop(a.client.Status().Patch(a.ctx, a.release, patch))
 	}
-	return reconciler.ContinueProcessing()
+
+	patch := client.MergeFrom(a.release.DeepCopy())
+	a.release.Status.Snapshot = rollbackTarget.GetSnapshotName()
+
+	if a.release.Spec.SkipReleasePipeline {
+		releasePlanAdmission, err := a.loader.GetActiveReleasePlanAdmissionFromRelease(a.ctx, a.client, a.release)
+		if err != nil {
+			a.release.MarkInvalid(v1alpha2.ReleaseReasonReleasePlanValidationError, err.Error())
+			return reconciler.RequeueOnErrorOrStop(a.client.Status().Patch(a.ctx, a.release, patch))
+		}
+
+		a.release.Status.ReleasePlanAdmission = v1alpha2.NewObjectReference(releasePlanAdmission.Namespace,
+			releasePlanAdmission.Name)
+		a.release.Status.Target = releasePlanAdmission.Namespace
+		a.release.Status.Requester = a.release.GetRequester()
+		a.release.MarkReleasePipelineSkipped()
+
+		a.logger.Info("Skipping the release Pipeline of the rollback", "Release.RollbackTo", a.release.Spec.RollbackTo)
+	}
+
+	return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.release, patch))
 }
 
 // EnsureReleasePipelineRunExists is an operation that will ensure that a release PipelineRun associated to the Release
-// being processed exists. Otherwise, it will create a new release PipelineRun.
+// being processed exists. Otherwise, it will create a new release PipelineRun. Nothing is done for rollbacks skipping
+// the release Pipeline.
 func (a *Adapter) EnsureReleasePipelineRunExists() (reconciler.OperationResult, error) {
+	if a.release.IsReleasePipelineSkipped() {
+		return reconciler.ContinueProcessing()
+	}
+
 	pipelineRun, err := a.loader.GetReleasePipelineRun(a.ctx, a.client, a.release)
 	if err != nil && !errors.IsNotFound(err) {
 		return reconciler.RequeueWithError(err)
@@ -149,33 +200,51 @@ func (a *Adapter) EnsureReleasePipelineRunExists() (reconciler.OperationResult,
 		releasePlanAdmission, err := a.loader.GetActiveReleasePlanAdmissionFromRelease(a.ctx, a.client, a.release)
 		if err != nil {
 			patch := client.MergeFrom(a.release.DeepCopy())
-			a.release.MarkInvalid(v1alpha1.ReleaseReasonReleasePlanValidationError, err.Error())
+			a.release.MarkInvalid(v1alpha2.ReleaseReasonReleasePlanValidationError, err.Error())
 			return reconciler.RequeueOnErrorOrStop(a.client.Status().Patch(a.ctx, a.release, patch))
 		}
 
 		releaseStrategy, err := a.loader.GetReleaseStrategy(a.ctx, a.client, releasePlanAdmission)
 		if err != nil {
 			patch := client.MergeFrom(a.release.DeepCopy())
-			a.release.MarkInvalid(v1alpha1.ReleaseReasonValidationError, err.Error())
+			a.release.MarkInvalid(v1alpha2.ReleaseReasonValidationError, err.Error())
+			return reconciler.RequeueOnErrorOrStop(a.client.Status().Patch(a.ctx, a.release, patch))
+		}
+
+		// Resolve the params referencing ConfigMaps or Secrets, so only values get passed to the release PipelineRun.
+		// The effective strategy is kept unresolved, so the content of those references is not exposed in the Release.
+		effectiveReleaseStrategy := releaseStrategy
+		releaseStrategy = releaseStrategy.DeepCopy()
+		releaseStrategy.Spec.Params, err = a.loader.GetReleaseStrategyParams(a.ctx, a.client, releaseStrategy)
+		if err != nil {
+			patch := client.MergeFrom(a.release.DeepCopy())
+			a.release.MarkInvalid(v1alpha2.ReleaseReasonValidationError, err.Error())
+			return reconciler.RequeueOnErrorOrStop(a.client.Status().Patch(a.ctx, a.release, patch))
+		}
+
+		tenantParams, err := a.getTenantParams(releasePlanAdmission)
+		if err != nil {
+			patch := client.MergeFrom(a.release.DeepCopy())
+			a.release.MarkInvalid(v1alpha2.ReleaseReasonParamsValidationError, err.Error())
 			return reconciler.RequeueOnErrorOrStop(a.client.Status().Patch(a.ctx, a.release, patch))
 		}
 
 		enterpriseContractPolicy, err := a.loader.GetEnterpriseContractPolicy(a.ctx, a.client, releaseStrategy)
 		if err != nil {
 			patch := client.MergeFrom(a.release.DeepCopy())
-			a.release.MarkInvalid(v1alpha1.ReleaseReasonValidationError, err.Error())
+			a.release.MarkInvalid(v1alpha2.ReleaseReasonValidationError, err.Error())
 			return reconciler.RequeueOnErrorOrStop(a.client.Status().Patch(a.ctx, a.release, patch))
 		}
 
 		snapshot, err := a.loader.GetSnapshot(a.ctx, a.client, a.release)
 		if err != nil {
 			patch := client.MergeFrom(a.release.DeepCopy())
-			a.release.MarkInvalid(v1alpha1.ReleaseReasonValidationError, err.Error())
+			a.release.MarkInvalid(v1alpha2.ReleaseReasonValidationError, err.Error())
 			return reconciler.RequeueOnErrorOrStop(a.client.Status().Patch(a.ctx, a.release, patch))
 		}
 
 		if pipelineRun == nil {
-			pipelineRun, err = a.createReleasePipelineRun(releaseStrategy, enterpriseContractPolicy, snapshot)
+			pipelineRun, err = a.createReleasePipelineRun(releaseStrategy, tenantParams, enterpriseContractPolicy, snapshot)
 			if err != nil {
 				return reconciler.RequeueWithError(err)
 			}
@@ -184,7 +253,7 @@ func (a *Adapter) EnsureReleasePipelineRunExists() (reconciler.OperationResult,
 				"PipelineRun.Name", pipelineRun.Name, "PipelineRun.Namespace", pipelineRun.Namespace)
 		}
 
-		return reconciler.RequeueOnErrorOrContinue(a.registerReleaseStatusData(pipelineRun, releaseStrategy))
+		return reconciler.RequeueOnErrorOrContinue(a.registerReleaseStatusData(pipelineRun, releasePlanAdmission, effectiveReleaseStrategy))
 	}
 
 	return reconciler.ContinueProcessing()
@@ -208,10 +277,11 @@ func (a *Adapter) EnsureReleasePipelineStatusIsTracked() (reconciler.OperationRe
 	return reconciler.ContinueProcessing()
 }
 
-// EnsureSnapshotEnvironmentBindingExists is an operation that will ensure that a SnapshotEnvironmentBinding
-// associated to the Release being processed exists. Otherwise, it will create a new one.
+// EnsureSnapshotEnvironmentBindingExists is an operation that will ensure that a SnapshotEnvironmentBinding for the
+// Environment the Release being processed is currently deployed to exists. Otherwise, it will create a new one. The
+// first Environment listed in the ReleasePlanAdmission is deployed to when no deployment has started yet.
 func (a *Adapter) EnsureSnapshotEnvironmentBindingExists() (reconciler.OperationResult, error) {
-	if !a.release.HasSucceeded() || a.release.IsDeployed() {
+	if !a.release.HasSucceeded() || a.release.IsDeployed() || a.release.HasDeploymentFailed() {
 		return reconciler.ContinueProcessing()
 	}
 
@@ -221,8 +291,11 @@ func (a *Adapter) EnsureSnapshotEnvironmentBindingExists() (reconciler.Operation
 	}
 
 	// If no environment is set in the ReleasePlanAdmission, skip the Binding creation
-	if releasePlanAdmission.Spec.Environment == "" {
-		return reconciler.ContinueProcessing()
+	environments := releasePlanAdmission.GetEnvironments()
+	if len(environments) == 0 {
+		patch := client.MergeFrom(a.release.DeepCopy())
+		a.release.MarkDeploymentSkipped("no environment is set in the ReleasePlanAdmission")
+		return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.release, patch))
 	}
 
 	err = a.syncResources()
@@ -230,24 +303,67 @@ func (a *Adapter) EnsureSnapshotEnvironmentBindingExists() (reconciler.Operation
 		return reconciler.RequeueWithError(err)
 	}
 
-	binding, err := a.createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission)
+	environmentName := environments[0].Name
+	if environment := a.release.GetCurrentEnvironment(); environment != nil {
+		environmentName = environment.Name
+	}
+
+	resources, err := a.loader.GetSnapshotEnvironmentBindingResources(a.ctx, a.client, a.release, releasePlanAdmission,
+		environmentName)
 	if err != nil {
 		return reconciler.RequeueWithError(err)
 	}
 
-	a.logger.Info("Created/updated SnapshotEnvironmentBinding",
+	// The deployment can't succeed if the ReleasePlanAdmission or the Snapshot reference components the application
+	// doesn't have
+	err = gitops.ValidateComponentConfigurations(resources.ApplicationComponents, releasePlanAdmission)
+	if err == nil {
+		err = gitops.ValidateSnapshotComponents(resources.ApplicationComponents, resources.Snapshot)
+	}
+	if err != nil {
+		patch := client.MergeFrom(a.release.DeepCopy())
+		a.release.MarkEnvironmentDeploying(environmentName, nil)
+		a.release.MarkDeploymentFailed(v1alpha2.ReleaseReasonDeploymentFailed, err.Error())
+		return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.release, patch))
+	}
+
+	binding, err := a.createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission, resources)
+	if err != nil {
+		return reconciler.RequeueWithError(err)
+	}
+
+	a.logger.Info("Created/updated SnapshotEnvironmentBinding", "Environment.Name", environmentName,
 		"SnapshotEnvironmentBinding.Name", binding.Name, "SnapshotEnvironmentBinding.Namespace", binding.Namespace)
 
 	patch := client.MergeFrom(a.release.DeepCopy())
-	a.release.Status.SnapshotEnvironmentBinding = fmt.Sprintf("%s%c%s", binding.Namespace, types.Separator, binding.Name)
+	a.release.MarkEnvironmentDeploying(environmentName, v1alpha2.NewObjectReference(binding.Namespace, binding.Name))
+
+	if releasePlanAdmission.Spec.MissingComponentsPolicy == v1alpha1.MissingComponentsPolicyReport {
+		_, missingComponents := gitops.GetSnapshotComponents(resources.ApplicationComponents, resources.Snapshot)
+		if len(missingComponents) > 0 {
+			if a.release.Status.Deployment == nil {
+				a.release.Status.Deployment = &v1alpha2.DeploymentStatus{}
+			}
+			a.release.Status.Deployment.MissingComponents = missingComponents
+		}
+	}
 
 	return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.release, patch))
 }
 
 // EnsureSnapshotEnvironmentBindingIsTracked is an operation that will ensure that the SnapshotEnvironmentBinding
-// Deployment status is tracked in the Release being processed.
+// Deployment status is tracked in the Release being processed. If the deployment doesn't finish in the time set in
+// the ReleasePlanAdmission, the deployment will be marked as failed. Once the gate of the current Environment is
+// passed, the Release moves on to the next Environment, and it's marked as deployed when the last one is deployed.
 func (a *Adapter) EnsureSnapshotEnvironmentBindingIsTracked() (reconciler.OperationResult, error) {
-	if !a.release.HasSucceeded() || a.release.Status.SnapshotEnvironmentBinding == "" || a.release.IsDeployed() {
+	if !a.release.HasSucceeded() || a.release.Status.SnapshotEnvironmentBinding == nil || a.release.IsDeployed() ||
+		a.release.HasDeploymentFailed() {
+		return reconciler.ContinueProcessing()
+	}
+
+	// The binding of the next Environment has to be created before tracking the deployment again
+	if environment := a.release.GetCurrentEnvironment(); environment != nil &&
+		environment.State == v1alpha2.EnvironmentDeploymentStatePending {
 		return reconciler.ContinueProcessing()
 	}
 
@@ -263,44 +379,162 @@ func (a *Adapter) EnsureSnapshotEnvironmentBindingIsTracked() (reconciler.Operat
 		return reconciler.ContinueProcessing()
 	}
 
-	return reconciler.RequeueOnErrorOrContinue(a.registerGitOpsDeploymentStatus(binding))
+	err = a.registerGitOpsDeploymentStatus(binding)
+	if err != nil || a.release.IsDeployed() || a.release.HasDeploymentFailed() {
+		return reconciler.RequeueOnErrorOrContinue(err)
+	}
+
+	result, err := a.ensureDeploymentHasNotTimedOut()
+	if err != nil || !a.release.IsDeploying() || a.release.HasDeploymentFailed() {
+		return result, err
+	}
+
+	gateResult, err := a.ensureEnvironmentGateIsPassed(binding)
+	if err != nil || a.release.IsDeployed() || a.release.HasDeploymentFailed() {
+		return gateResult, err
+	}
+	if gateResult.RequeueRequest && (!result.RequeueRequest || gateResult.RequeueDelay < result.RequeueDelay) {
+		result = gateResult
+	}
+
+	if result.RequeueRequest && result.RequeueDelay < deploymentStatusRefreshInterval {
+		return result, nil
+	}
+
+	// GitOpsDeployments are not watched, so the Release is requeued to keep the status of its components updated
+	return reconciler.RequeueAfter(deploymentStatusRefreshInterval, nil)
+}
+
+// EnsureFailedDeploymentIsRolledBack is an operation that will ensure that, if the ReleasePlanAdmission enables
+// automatic rollbacks, the Environment whose deployment failed is restored to the Snapshot and the components it was
+// running before the Release being processed updated its SnapshotEnvironmentBinding.
+func (a *Adapter) EnsureFailedDeploymentIsRolledBack() (reconciler.OperationResult, error) {
+	environment := a.release.GetCurrentEnvironment()
+	if !a.release.HasDeploymentFailed() || a.release.IsRollbackDone() || a.release.Status.SnapshotEnvironmentBinding == nil ||
+		environment == nil || environment.PreviousSnapshot == "" {
+		return reconciler.ContinueProcessing()
+	}
+
+	releasePlanAdmission, err := a.loader.GetActiveReleasePlanAdmissionFromRelease(a.ctx, a.client, a.release)
+	if err != nil {
+		return reconciler.RequeueWithError(err)
+	}
+
+	if !releasePlanAdmission.Spec.AutoRollback {
+		return reconciler.ContinueProcessing()
+	}
+
+	binding, err := a.loader.GetSnapshotEnvironmentBindingFromReleaseStatus(a.ctx, a.client, a.release)
+	if err != nil {
+		return reconciler.RequeueWithError(err)
+	}
+
+	// The Environment can't be rolled back if another Release has deployed to it since
+	if binding.GetAnnotations()[libhandler.NamespacedNameAnnotation] != fmt.Sprintf("%s/%s", a.release.GetNamespace(), a.release.GetName()) ||
+		binding.Spec.Snapshot != a.release.GetSnapshotName() {
+		patch := client.MergeFrom(a.release.DeepCopy())
+		a.release.MarkRollbackSkipped(fmt.Sprintf("the SnapshotEnvironmentBinding %s was updated by another Release",
+			binding.Name))
+		return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.release, patch))
+	}
+
+	bindingPatch := client.MergeFrom(binding.DeepCopy())
+	binding.Spec.Snapshot = environment.PreviousSnapshot
+	// Releases that recorded the Snapshot before the components were recorded too keep the current components
+	if len(environment.PreviousComponents) > 0 {
+		binding.Spec.Components = make([]applicationapiv1alpha1.BindingComponent, len(environment.PreviousComponents))
+		for i := range environment.PreviousComponents {
+			environment.PreviousComponents[i].DeepCopyInto(&binding.Spec.Components[i])
+		}
+	}
+	err = a.client.Patch(a.ctx, binding, bindingPatch)
+	if err != nil {
+		return reconciler.RequeueWithError(err)
+	}
+
+	a.logger.Info("Rolled back SnapshotEnvironmentBinding", "Environment.Name", environment.Name,
+		"SnapshotEnvironmentBinding.Name", binding.Name, "Snapshot.Name", environment.PreviousSnapshot)
+
+	patch := client.MergeFrom(a.release.DeepCopy())
+	a.release.MarkRolledBack(fmt.Sprintf("the Environment %s was rolled back to the Snapshot %s", environment.Name,
+		environment.PreviousSnapshot))
+	return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.release, patch))
 }
 
 // createReleasePipelineRun creates and returns a new release PipelineRun. The new PipelineRun will include owner
 // annotations, so it triggers Release reconciles whenever it changes. The Pipeline information and the parameters to it
-// will be extracted from the given ReleaseStrategy. The Release's Snapshot will also be passed to the release
-// PipelineRun.
-func (a *Adapter) createReleasePipelineRun(releaseStrategy *v1alpha1.ReleaseStrategy,
+// will be extracted from the given ReleaseStrategy, with the given tenant params overriding them. The Release's
+// Snapshot will also be passed to the release PipelineRun.
+func (a *Adapter) createReleasePipelineRun(releaseStrategy *v1alpha1.ReleaseStrategy, tenantParams []v1alpha1.Params,
 	enterpriseContractPolicy *ecapiv1alpha1.EnterpriseContractPolicy,
 	snapshot *applicationapiv1alpha1.Snapshot) (*v1beta1.PipelineRun, error) {
 	pipelineRun := tekton.NewReleasePipelineRun("release-pipelinerun", releaseStrategy.Namespace).
 		WithOwner(a.release).
 		WithReleaseAndApplicationMetadata(a.release, snapshot.Spec.Application).
+		WithRequester(a.release).
 		WithReleaseStrategy(releaseStrategy).
+		WithParams(tenantParams).
 		WithEnterpriseContractPolicy(enterpriseContractPolicy).
 		WithSnapshot(snapshot).
 		AsPipelineRun()
 
-	err := a.client.Create(a.ctx, pipelineRun)
+	versionedPipelineRun, err := tekton.ToVersionedPipelineRun(pipelineRun)
 	if err != nil {
 		return nil, err
 	}
 
-	return pipelineRun, nil
+	err = a.client.Create(a.ctx, versionedPipelineRun)
+	if err != nil {
+		return nil, err
+	}
+
+	return tekton.FromVersionedPipelineRun(versionedPipelineRun)
 }
 
-// createSnapshotEnvironmentBinding creates or updates a SnapshotEnvironmentBinding for the Release being processed.
-func (a *Adapter) createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error) {
-	resources, err := a.loader.GetSnapshotEnvironmentBindingResources(a.ctx, a.client, a.release, releasePlanAdmission)
+// createVerificationPipelineRun creates and returns a new verification PipelineRun in the namespace of the given
+// ReleasePlanAdmission. Like release PipelineRuns, it will include owner annotations, so it triggers Release reconciles
+// whenever it changes. The name of the given Environment and the Release's Snapshot will be passed to it along with the
+// params set in the verification Pipeline.
+func (a *Adapter) createVerificationPipelineRun(releasePlanAdmission *v1alpha1.ReleasePlanAdmission, environmentName string,
+	snapshot *applicationapiv1alpha1.Snapshot) (*v1beta1.PipelineRun, error) {
+	pipelineRun := tekton.NewReleasePipelineRun("verification-pipelinerun", releasePlanAdmission.Namespace).
+		WithOwner(a.release).
+		WithReleaseAndApplicationMetadata(a.release, snapshot.Spec.Application).
+		WithPipelineType(tekton.PipelineTypeVerification).
+		WithRequester(a.release).
+		WithVerificationPipeline(releasePlanAdmission.Spec.VerificationPipeline).
+		WithEnvironment(environmentName).
+		WithSnapshot(snapshot).
+		AsPipelineRun()
+
+	versionedPipelineRun, err := tekton.ToVersionedPipelineRun(pipelineRun)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.client.Create(a.ctx, versionedPipelineRun)
 	if err != nil {
 		return nil, err
 	}
 
+	return tekton.FromVersionedPipelineRun(versionedPipelineRun)
+}
+
+// createSnapshotEnvironmentBinding creates or updates a SnapshotEnvironmentBinding for the Release being processed
+// using the given resources. Only the components contained in the Snapshot are bound, with their configuration
+// overridden by the ReleasePlanAdmission. Components missing from the Snapshot keep their previous configuration in
+// existing bindings unless the ReleasePlanAdmission asks to report them.
+func (a *Adapter) createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission *v1alpha1.ReleasePlanAdmission,
+	resources *loader.SnapshotEnvironmentBindingResources) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error) {
+	components, missingComponents := gitops.GetSnapshotComponents(resources.ApplicationComponents, resources.Snapshot)
+
 	// The binding information needs to be updated no matter if it already exists or not
-	binding := gitops.NewSnapshotEnvironmentBinding(resources.ApplicationComponents, resources.Snapshot, resources.Environment)
+	binding := gitops.NewSnapshotEnvironmentBinding(components, resources.Snapshot, resources.Environment,
+		releasePlanAdmission)
 
 	// Search for an existing binding
-	existingBinding, err := a.loader.GetSnapshotEnvironmentBinding(a.ctx, a.client, releasePlanAdmission)
+	existingBinding, err := a.loader.GetSnapshotEnvironmentBinding(a.ctx, a.client, resources.Environment,
+		resources.Application)
 	if err != nil && !errors.IsNotFound(err) {
 		return nil, err
 	}
@@ -321,9 +555,23 @@ func (a *Adapter) createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission
 
 		return binding, a.client.Create(a.ctx, binding)
 	} else {
+		// The Snapshot and the components the Environment is running have to be recorded before the binding is
+		// updated, as they are lost afterwards and they are needed to roll the Environment back if the deployment fails
+		releasePatch := client.MergeFrom(a.release.DeepCopy())
+		a.release.RecordPreviousDeployment(resources.Environment.Name, existingBinding.Spec.Snapshot,
+			existingBinding.Spec.Components)
+		err = a.client.Status().Patch(a.ctx, a.release, releasePatch)
+		if err != nil {
+			return nil, err
+		}
+
 		// We create the binding so if the owner reference is not already present, there must be a good reason for that
 		patch := client.MergeFrom(existingBinding.DeepCopy())
+		keptComponents := gitops.GetBoundComponents(existingBinding, missingComponents)
 		existingBinding.Spec = binding.Spec
+		if releasePlanAdmission.Spec.MissingComponentsPolicy != v1alpha1.MissingComponentsPolicyReport {
+			existingBinding.Spec.Components = append(existingBinding.Spec.Components, keptComponents...)
+		}
 
 		// Add owner annotations so the controller can watch for status updates to the binding and track them
 		// in the release
@@ -336,6 +584,26 @@ func (a *Adapter) createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission
 	}
 }
 
+// deletePipelineRun deletes the given PipelineRun using the Tekton API version in use. Nothing is done if the
+// PipelineRun is nil or it's already gone.
+func (a *Adapter) deletePipelineRun(pipelineRun *v1beta1.PipelineRun) error {
+	if pipelineRun == nil {
+		return nil
+	}
+
+	versionedPipelineRun, err := tekton.ToVersionedPipelineRun(pipelineRun)
+	if err != nil {
+		return err
+	}
+
+	err = a.client.Delete(a.ctx, versionedPipelineRun)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
 // finalizeRelease will finalize the Release being processed, removing the associated resources.
 func (a *Adapter) finalizeRelease() error {
 	pipelineRun, err := a.loader.GetReleasePipelineRun(a.ctx, a.client, a.release)
@@ -343,11 +611,19 @@ func (a *Adapter) finalizeRelease() error {
 		return err
 	}
 
-	if pipelineRun != nil {
-		err = a.client.Delete(a.ctx, pipelineRun)
-		if err != nil && !errors.IsNotFound(err) {
-			return err
-		}
+	err = a.deletePipelineRun(pipelineRun)
+	if err != nil {
+		return err
+	}
+
+	verificationPipelineRun, err := a.loader.GetVerificationPipelineRun(a.ctx, a.client, a.release)
+	if err != nil {
+		return err
+	}
+
+	err = a.deletePipelineRun(verificationPipelineRun)
+	if err != nil {
+		return err
 	}
 
 	a.logger.Info("Successfully finalized Release")
@@ -355,22 +631,218 @@ func (a *Adapter) finalizeRelease() error {
 	return nil
 }
 
+// getTenantParams returns the params set by the tenant in the ReleasePlan and in the Release being processed, with the
+// ones in the Release taking precedence. If any of the params is not allowed by the given ReleasePlanAdmission, an
+// error will be returned.
+func (a *Adapter) getTenantParams(releasePlanAdmission *v1alpha1.ReleasePlanAdmission) ([]v1alpha1.Params, error) {
+	releasePlan, err := a.loader.GetReleasePlan(a.ctx, a.client, a.release)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseParams := v1alpha1.ConvertParamsFromV1alpha2(a.release.Spec.Params)
+	params := append([]v1alpha1.Params{}, releaseParams...)
+	for _, releasePlanParam := range releasePlan.Spec.Params {
+		overridden := false
+		for _, param := range releaseParams {
+			if param.Name == releasePlanParam.Name {
+				overridden = true
+				break
+			}
+		}
+
+		if !overridden {
+			params = append(params, releasePlanParam)
+		}
+	}
+
+	return params, releasePlanAdmission.ValidateParams(params)
+}
+
+// ensureDeploymentHasNotTimedOut marks the deployment of the Release being processed as failed if the deployment to
+// the current Environment has taken longer than the deployment timeout set in the ReleasePlanAdmission. Otherwise, the
+// Release is requeued so it's reconciled again once the timeout is reached.
+func (a *Adapter) ensureDeploymentHasNotTimedOut() (reconciler.OperationResult, error) {
+	if !a.release.IsDeploying() {
+		return reconciler.ContinueProcessing()
+	}
+
+	startTime := a.release.Status.DeploymentStartTime
+	if environment := a.release.GetCurrentEnvironment(); environment != nil {
+		if environment.State != v1alpha2.EnvironmentDeploymentStateDeploying {
+			return reconciler.ContinueProcessing()
+		}
+		startTime = environment.DeploymentStartTime
+	}
+
+	releasePlanAdmission, err := a.loader.GetActiveReleasePlanAdmissionFromRelease(a.ctx, a.client, a.release)
+	if err != nil {
+		return reconciler.RequeueWithError(err)
+	}
+
+	timeout := releasePlanAdmission.Spec.DeploymentTimeout
+	if timeout == nil {
+		return reconciler.ContinueProcessing()
+	}
+
+	remaining := time.Until(startTime.Add(timeout.Duration))
+	if remaining > 0 {
+		return reconciler.RequeueAfter(remaining, nil)
+	}
+
+	patch := client.MergeFrom(a.release.DeepCopy())
+	a.release.MarkDeploymentFailed(v1alpha2.ReleaseReasonDeploymentTimedOut,
+		fmt.Sprintf("the deployment didn't finish in %s", timeout.Duration))
+
+	return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.release, patch))
+}
+
+// ensureDeploymentIsVerified ensures that a verification PipelineRun is created for the Release being processed once
+// it's deployed to the given Environment, which is the last one. The Release is marked as deployed once the
+// PipelineRun succeeds, while its deployment is marked as failed if the PipelineRun fails.
+func (a *Adapter) ensureDeploymentIsVerified(releasePlanAdmission *v1alpha1.ReleasePlanAdmission, environmentName string) (reconciler.OperationResult, error) {
+	pipelineRun, err := a.loader.GetVerificationPipelineRun(a.ctx, a.client, a.release)
+	if err != nil {
+		return reconciler.RequeueWithError(err)
+	}
+
+	if pipelineRun == nil {
+		snapshot, err := a.loader.GetSnapshot(a.ctx, a.client, a.release)
+		if err != nil {
+			return reconciler.RequeueWithError(err)
+		}
+
+		pipelineRun, err = a.createVerificationPipelineRun(releasePlanAdmission, environmentName, snapshot)
+		if err != nil {
+			return reconciler.RequeueWithError(err)
+		}
+
+		a.logger.Info("Created verification PipelineRun",
+			"PipelineRun.Name", pipelineRun.Name, "PipelineRun.Namespace", pipelineRun.Namespace)
+	}
+
+	patch := client.MergeFrom(a.release.DeepCopy())
+
+	a.release.MarkVerifying(v1alpha2.NewObjectReference(pipelineRun.Namespace, pipelineRun.Name))
+	if pipelineRun.IsDone() {
+		condition := pipelineRun.Status.GetCondition(apis.ConditionSucceeded)
+		if condition.IsTrue() {
+			a.release.MarkVerified()
+		} else {
+			a.release.MarkVerificationFailed(condition.Message)
+		}
+	}
+
+	return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.release, patch))
+}
+
+// ensureEnvironmentGateIsPassed moves the Release being processed on to the next Environment once the gate of the
+// current one, as set in the ReleasePlanAdmission, is passed. When the current Environment is the last one, the
+// Release is marked as deployed once all the components are deployed to it and, if the ReleasePlanAdmission sets a
+// verification Pipeline, the verification succeeds. While the gate has a soak duration, the
+// Release is requeued so it's reconciled again once it's over.
+func (a *Adapter) ensureEnvironmentGateIsPassed(binding *applicationapiv1alpha1.SnapshotEnvironmentBinding) (reconciler.OperationResult, error) {
+	environment := a.release.GetCurrentEnvironment()
+	if environment == nil {
+		return reconciler.ContinueProcessing()
+	}
+
+	releasePlanAdmission, err := a.loader.GetActiveReleasePlanAdmissionFromRelease(a.ctx, a.client, a.release)
+	if err != nil {
+		return reconciler.RequeueWithError(err)
+	}
+
+	deployed := environment.State == v1alpha2.EnvironmentDeploymentStateDeployed
+	nextEnvironment := releasePlanAdmission.GetNextEnvironment(environment.Name)
+	if nextEnvironment == nil {
+		condition := meta.FindStatusCondition(binding.Status.ComponentDeploymentConditions,
+			applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed)
+		if !deployed || condition == nil {
+			return reconciler.ContinueProcessing()
+		}
+
+		if releasePlanAdmission.Spec.VerificationPipeline != nil {
+			return a.ensureDeploymentIsVerified(releasePlanAdmission, environment.Name)
+		}
+
+		patch := client.MergeFrom(a.release.DeepCopy())
+		a.release.MarkDeployed(condition.Reason, condition.Message)
+		return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.release, patch))
+	}
+
+	if gate := releasePlanAdmission.GetEnvironmentGate(environment.Name); gate != nil {
+		if (gate.WaitForDeployment || gate.SoakDuration != nil) && !deployed {
+			return reconciler.ContinueProcessing()
+		}
+
+		if gate.SoakDuration != nil {
+			remaining := time.Until(environment.DeploymentCompletionTime.Add(gate.SoakDuration.Duration))
+			if remaining > 0 {
+				return reconciler.RequeueAfter(remaining, nil)
+			}
+		}
+
+		if gate.RequireApproval && binding.GetAnnotations()[v1alpha1.ApprovedReleaseAnnotation] !=
+			fmt.Sprintf("%s/%s", a.release.Namespace, a.release.Name) {
+			return reconciler.ContinueProcessing()
+		}
+	}
+
+	patch := client.MergeFrom(a.release.DeepCopy())
+	a.release.MarkEnvironmentPending(nextEnvironment.Name)
+	err = a.client.Status().Patch(a.ctx, a.release, patch)
+	if err != nil {
+		return reconciler.RequeueWithError(err)
+	}
+
+	a.logger.Info("Moving on to the next Environment", "Environment.Name", nextEnvironment.Name)
+
+	// Requeue so the SnapshotEnvironmentBinding of the next Environment gets created
+	return reconciler.Requeue()
+}
+
 // registerGitOpsDeploymentStatus updates the status of the Release being processed by monitoring the status of the
-// associated SnapshotEnvironmentBinding and setting the appropriate state in the Release.
+// associated SnapshotEnvironmentBinding and setting the appropriate state in the Release. The status of each of the
+// components is taken from the binding and its GitOpsDeployments. If the binding reports an error that won't be
+// recovered from, the deployment is marked as failed.
 func (a *Adapter) registerGitOpsDeploymentStatus(binding *applicationapiv1alpha1.SnapshotEnvironmentBinding) error {
 	if binding == nil {
 		return nil
 	}
 
+	snapshot, err := a.loader.GetSnapshot(a.ctx, a.client, a.release)
+	if err != nil {
+		return err
+	}
+
+	gitOpsDeployments, err := a.loader.GetGitOpsDeployments(a.ctx, a.client, binding)
+	if err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(a.release.DeepCopy())
+
+	if a.release.Status.Deployment == nil {
+		a.release.Status.Deployment = &v1alpha2.DeploymentStatus{}
+	}
+	a.release.Status.Deployment.Components = gitops.GetComponentDeploymentStatuses(binding, snapshot, gitOpsDeployments)
+
+	if message, failed := gitops.GetDeploymentFailure(binding); failed {
+		a.release.MarkDeploymentFailed(v1alpha2.ReleaseReasonDeploymentFailed, message)
+
+		return a.client.Status().Patch(a.ctx, a.release, patch)
+	}
+
 	condition := meta.FindStatusCondition(binding.Status.ComponentDeploymentConditions,
 		applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed)
 	if condition == nil {
-		return nil
+		return a.client.Status().Patch(a.ctx, a.release, patch)
 	}
 
-	patch := client.MergeFrom(a.release.DeepCopy())
-
-	if condition.Status == metav1.ConditionTrue {
+	// Releases tracking their Environments are marked as deployed once the last Environment is deployed
+	if condition.Status == metav1.ConditionTrue && a.release.GetCurrentEnvironment() != nil {
+		a.release.MarkEnvironmentDeployed(condition.Reason)
+	} else if condition.Status == metav1.ConditionTrue {
 		a.release.MarkDeployed(condition.Reason, condition.Message)
 	} else {
 		a.release.MarkDeploying(condition.Status, condition.Reason, condition.Message)
@@ -392,7 +864,7 @@ func (a *Adapter) registerReleasePipelineRunStatus(pipelineRun *v1beta1.Pipeline
 		if condition.IsTrue() {
 			a.release.MarkSucceeded()
 		} else {
-			a.release.MarkFailed(v1alpha1.ReleaseReasonPipelineFailed, condition.Message)
+			a.release.MarkFailed(v1alpha2.ReleaseReasonPipelineFailed, condition.Message)
 		}
 
 		return a.client.Status().Patch(a.ctx, a.release, patch)
@@ -401,19 +873,31 @@ func (a *Adapter) registerReleasePipelineRunStatus(pipelineRun *v1beta1.Pipeline
 	return nil
 }
 
-// registerReleaseStatusData adds all the Release information to its Status.
-func (a *Adapter) registerReleaseStatusData(releasePipelineRun *v1beta1.PipelineRun, releaseStrategy *v1alpha1.ReleaseStrategy) error {
-	if releasePipelineRun == nil || releaseStrategy == nil {
+// registerReleaseStatusData adds all the Release information to its Status, including references to the release
+// PipelineRun, the ReleasePlanAdmission and the strategy. ClusterReleaseStrategies are referenced without namespace.
+// The spec of the effective strategy is also registered, so it's possible to know how the Release was processed when
+// the strategy inherits from a ClusterReleaseStrategy, along with the identity of the user who created the Release.
+func (a *Adapter) registerReleaseStatusData(releasePipelineRun *v1beta1.PipelineRun,
+	releasePlanAdmission *v1alpha1.ReleasePlanAdmission, releaseStrategy *v1alpha1.ReleaseStrategy) error {
+	if releasePipelineRun == nil || releasePlanAdmission == nil || releaseStrategy == nil {
 		return nil
 	}
 
 	patch := client.MergeFrom(a.release.DeepCopy())
 
-	a.release.Status.ReleasePipelineRun = fmt.Sprintf("%s%c%s",
-		releasePipelineRun.Namespace, types.Separator, releasePipelineRun.Name)
-	a.release.Status.ReleaseStrategy = fmt.Sprintf("%s%c%s",
-		releaseStrategy.Namespace, types.Separator, releaseStrategy.Name)
+	a.release.Status.ReleasePipelineRun = v1alpha2.NewObjectReference(releasePipelineRun.Namespace,
+		releasePipelineRun.Name)
+	a.release.Status.ReleasePlanAdmission = v1alpha2.NewObjectReference(releasePlanAdmission.Namespace,
+		releasePlanAdmission.Name)
+	if releasePlanAdmission.Spec.ReleaseStrategyKind == v1alpha1.ClusterReleaseStrategyKind {
+		a.release.Status.ReleaseStrategy = v1alpha2.NewObjectReference("", releaseStrategy.Name)
+	} else {
+		a.release.Status.ReleaseStrategy = v1alpha2.NewObjectReference(releaseStrategy.Namespace,
+			releaseStrategy.Name)
+	}
+	a.release.Status.EffectiveReleaseStrategy = releaseStrategy.Spec.DeepCopy().ConvertToV1alpha2()
 	a.release.Status.Target = releasePipelineRun.Namespace
+	a.release.Status.Requester = a.release.GetRequester()
 
 	a.release.MarkRunning()
 
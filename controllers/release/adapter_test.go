@@ -21,11 +21,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+	"github.com/redhat-appstudio/release-service/gitops"
 	"github.com/redhat-appstudio/release-service/loader"
 	"github.com/redhat-appstudio/release-service/tekton"
 
@@ -35,9 +38,12 @@ import (
 	"github.com/operator-framework/operator-lib/handler"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Release Adapter", Ordered, func() {
@@ -183,28 +189,135 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
 				{
 					ContextKey: loader.ReleasePlanAdmissionContextKey,
-					Err:        fmt.Errorf("auto-release label set to false"),
+					Err:        fmt.Errorf("found ReleasePlanAdmission 'foo' with %w", loader.ErrReleasePlanAdmissionDisabled),
 				},
 			})
 			result, err := adapter.EnsureReleasePlanAdmissionEnabled()
 			Expect(!result.RequeueRequest && result.CancelRequest).To(BeTrue())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(adapter.release.Status.Conditions).To(HaveLen(1))
-			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha1.ReleaseReasonTargetDisabledError)))
+			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha2.ReleaseReasonTargetDisabledError)))
 		})
 
 		It("should stop reconcile if multiple ReleasePlanAdmissions exist", func() {
 			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
 				{
 					ContextKey: loader.ReleasePlanAdmissionContextKey,
-					Err:        fmt.Errorf("multiple ReleasePlanAdmissions found"),
+					Err:        loader.ErrMultipleReleasePlanAdmissions,
 				},
 			})
 			result, err := adapter.EnsureReleasePlanAdmissionEnabled()
 			Expect(!result.RequeueRequest && result.CancelRequest).To(BeTrue())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(adapter.release.Status.Conditions).To(HaveLen(1))
-			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha1.ReleaseReasonValidationError)))
+			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha2.ReleaseReasonValidationError)))
+		})
+	})
+
+	Context("When EnsureRollbackTargetIsValid is called", func() {
+		var adapter *Adapter
+		var rollbackTarget *v1alpha2.Release
+
+		AfterEach(func() {
+			_ = adapter.client.Delete(ctx, adapter.release)
+		})
+
+		BeforeEach(func() {
+			adapter = createReleaseAndAdapter()
+			adapter.release.Spec.RollbackTo = "previous-release"
+
+			rollbackTarget = &v1alpha2.Release{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "previous-release",
+					Namespace: "default",
+				},
+				Spec: adapter.release.Spec,
+			}
+			rollbackTarget.Spec.RollbackTo = ""
+			adapter.release.Spec.Snapshot = ""
+			rollbackTarget.MarkRunning()
+			rollbackTarget.MarkSucceeded()
+			rollbackTarget.MarkEnvironmentDeploying(environment.Name, nil)
+			rollbackTarget.MarkDeployed("CommitsSynced", "")
+
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleaseContextKey,
+					Resource:   rollbackTarget,
+				},
+			})
+		})
+
+		It("should continue if the Release is not a rollback", func() {
+			adapter.release.Spec.RollbackTo = ""
+
+			result, err := adapter.EnsureRollbackTargetIsValid()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.Status.Conditions).To(BeEmpty())
+		})
+
+		It("should continue without releasing the rollback if the release Pipeline is not skipped", func() {
+			result, err := adapter.EnsureRollbackTargetIsValid()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.HasStarted()).To(BeFalse())
+		})
+
+		It("should record the Snapshot of the Release to roll back to", func() {
+			result, err := adapter.EnsureRollbackTargetIsValid()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.Status.Snapshot).To(Equal(rollbackTarget.Spec.Snapshot))
+		})
+
+		It("should stop reconcile if the Release to roll back to doesn't exist", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleaseContextKey,
+					Err:        errors.NewNotFound(schema.GroupResource{Resource: "releases"}, rollbackTarget.Name),
+				},
+			})
+
+			result, err := adapter.EnsureRollbackTargetIsValid()
+			Expect(!result.RequeueRequest && result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.FindStatusCondition(adapter.release.Status.Conditions, v1alpha2.ValidatedConditionType).Reason).To(
+				Equal(v1alpha2.ReleaseReasonRollbackValidationError.String()))
+		})
+
+		It("should stop reconcile if the Release to roll back to was never deployed", func() {
+			rollbackTarget.Status = v1alpha2.ReleaseStatus{}
+
+			result, err := adapter.EnsureRollbackTargetIsValid()
+			Expect(!result.RequeueRequest && result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			condition := meta.FindStatusCondition(adapter.release.Status.Conditions, v1alpha2.ValidatedConditionType)
+			Expect(condition.Reason).To(Equal(v1alpha2.ReleaseReasonRollbackValidationError.String()))
+			Expect(condition.Message).To(ContainSubstring("was never successfully deployed"))
+		})
+
+		It("should mark the rollback as released if the release Pipeline is skipped", func() {
+			adapter.release.Spec.SkipReleasePipeline = true
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleaseContextKey,
+					Resource:   rollbackTarget,
+				},
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   releasePlanAdmission,
+				},
+			})
+
+			result, err := adapter.EnsureRollbackTargetIsValid()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.IsReleasePipelineSkipped()).To(BeTrue())
+			Expect(adapter.release.HasSucceeded()).To(BeTrue())
+			Expect(adapter.release.Status.Target).To(Equal(releasePlanAdmission.Namespace))
+			Expect(adapter.release.Status.ReleasePlanAdmission).To(Equal(
+				v1alpha2.NewObjectReference(releasePlanAdmission.Namespace, releasePlanAdmission.Name)))
 		})
 	})
 
@@ -219,6 +332,15 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			adapter = createReleaseAndAdapter()
 		})
 
+		It("should not create a release PipelineRun for rollbacks skipping the release Pipeline", func() {
+			adapter.release.MarkReleasePipelineSkipped()
+
+			result, err := adapter.EnsureReleasePipelineRunExists()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.Status.ReleasePipelineRun).To(BeNil())
+		})
+
 		It("should continue if the pipelineRun exists and the release has started", func() {
 			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
 				{
@@ -301,7 +423,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			Expect(!result.RequeueRequest && result.CancelRequest).To(BeTrue())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(adapter.release.Status.Conditions).To(HaveLen(1))
-			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha1.ReleaseReasonReleasePlanValidationError)))
+			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha2.ReleaseReasonReleasePlanValidationError)))
 		})
 
 		It("should fail if the ReleaseStrategy is not found", func() {
@@ -319,7 +441,51 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			Expect(!result.RequeueRequest && result.CancelRequest).To(BeTrue())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(adapter.release.Status.Conditions).To(HaveLen(1))
-			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha1.ReleaseReasonValidationError)))
+			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha2.ReleaseReasonValidationError)))
+		})
+
+		It("should fail if the ReleaseStrategy params can't be resolved", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+				},
+				{
+					ContextKey: loader.ReleaseStrategyContextKey,
+					Resource:   releaseStrategy,
+				},
+				{
+					ContextKey: loader.ReleaseStrategyParamsContextKey,
+					Err:        fmt.Errorf("unable to resolve param"),
+				},
+			})
+
+			result, err := adapter.EnsureReleasePipelineRunExists()
+			Expect(!result.RequeueRequest && result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.Status.Conditions).To(HaveLen(1))
+			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha2.ReleaseReasonValidationError)))
+			Expect(adapter.release.Status.Conditions[0].Message).To(ContainSubstring("unable to resolve param"))
+		})
+
+		It("should fail if the tenant params are not allowed by the ReleasePlanAdmission", func() {
+			adapter.release.Spec.Params = []v1alpha2.Params{{Name: "foo", Value: "bar"}}
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   releasePlanAdmission,
+				},
+				{
+					ContextKey: loader.ReleaseStrategyContextKey,
+					Resource:   releaseStrategy,
+				},
+			})
+
+			result, err := adapter.EnsureReleasePipelineRunExists()
+			Expect(!result.RequeueRequest && result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.Status.Conditions).To(HaveLen(1))
+			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha2.ReleaseReasonParamsValidationError)))
+			Expect(adapter.release.Status.Conditions[0].Message).To(ContainSubstring("param 'foo' is not allowed"))
 		})
 
 		It("should fail if the EnterpriseContractPolicy is not found", func() {
@@ -341,7 +507,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			Expect(!result.RequeueRequest && result.CancelRequest).To(BeTrue())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(adapter.release.Status.Conditions).To(HaveLen(1))
-			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha1.ReleaseReasonValidationError)))
+			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha2.ReleaseReasonValidationError)))
 		})
 
 		It("should fail if the Snapshot is not found", func() {
@@ -366,7 +532,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			Expect(!result.RequeueRequest && result.CancelRequest).To(BeTrue())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(adapter.release.Status.Conditions).To(HaveLen(1))
-			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha1.ReleaseReasonValidationError)))
+			Expect(adapter.release.Status.Conditions[0].Reason).To(Equal(string(v1alpha2.ReleaseReasonValidationError)))
 		})
 	})
 
@@ -472,7 +638,9 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			result, err := adapter.EnsureSnapshotEnvironmentBindingExists()
 			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
 			Expect(err).NotTo(HaveOccurred())
-			Expect(adapter.release.Status.SnapshotEnvironmentBinding).To(BeEmpty())
+			Expect(adapter.release.Status.SnapshotEnvironmentBinding).To(BeNil())
+			Expect(adapter.release.IsDeployed()).To(BeTrue())
+			Expect(adapter.release.IsReady()).To(BeTrue())
 		})
 
 		It("fails when the ReleasePlanAdmission is not present", func() {
@@ -492,6 +660,118 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			Expect(err.Error()).To(ContainSubstring("not found"))
 		})
 
+		It("fails when the resources required to create the binding are not present", func() {
+			adapter.release.MarkRunning()
+			adapter.release.MarkSucceeded()
+
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   releasePlanAdmission,
+				},
+				{
+					ContextKey: loader.SnapshotEnvironmentBindingResourcesContextKey,
+					Err:        fmt.Errorf("not found"),
+				},
+			})
+
+			result, err := adapter.EnsureSnapshotEnvironmentBindingExists()
+			Expect(result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not found"))
+		})
+
+		It("marks the deployment as failed if the ReleasePlanAdmission configures unknown components", func() {
+			adapter.release.MarkRunning()
+			adapter.release.MarkSucceeded()
+
+			newReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			newReleasePlanAdmission.Spec.ComponentConfigurations = []v1alpha1.ComponentConfiguration{
+				{Name: "unknown"},
+			}
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   newReleasePlanAdmission,
+				},
+				{
+					ContextKey: loader.SnapshotEnvironmentBindingResourcesContextKey,
+					Resource: &loader.SnapshotEnvironmentBindingResources{
+						Application:           application,
+						ApplicationComponents: []applicationapiv1alpha1.Component{*component},
+						Environment:           environment,
+						Snapshot:              snapshot,
+					},
+				},
+			})
+
+			result, err := adapter.EnsureSnapshotEnvironmentBindingExists()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.Status.SnapshotEnvironmentBinding).To(BeNil())
+			Expect(adapter.release.HasDeploymentFailed()).To(BeTrue())
+		})
+
+		It("reports the components missing from the Snapshot if the ReleasePlanAdmission asks for it", func() {
+			adapter.release.MarkRunning()
+			adapter.release.MarkSucceeded()
+
+			otherComponent := component.DeepCopy()
+			otherComponent.Name = "other-component"
+			newReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			newReleasePlanAdmission.Spec.MissingComponentsPolicy = v1alpha1.MissingComponentsPolicyReport
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   newReleasePlanAdmission,
+				},
+				{
+					ContextKey: loader.SnapshotEnvironmentBindingContextKey,
+					Resource:   snapshotEnvironmentBinding,
+				},
+				{
+					ContextKey: loader.SnapshotEnvironmentBindingResourcesContextKey,
+					Resource: &loader.SnapshotEnvironmentBindingResources{
+						Application:           application,
+						ApplicationComponents: []applicationapiv1alpha1.Component{*component, *otherComponent},
+						Environment:           environment,
+						Snapshot:              snapshot,
+					},
+				},
+			})
+
+			result, err := adapter.EnsureSnapshotEnvironmentBindingExists()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.Status.Deployment).NotTo(BeNil())
+			Expect(adapter.release.Status.Deployment.MissingComponents).To(Equal([]string{otherComponent.Name}))
+		})
+
+		It("marks the deployment as failed if the Snapshot contains components not in the application", func() {
+			adapter.release.MarkRunning()
+			adapter.release.MarkSucceeded()
+
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   releasePlanAdmission,
+				},
+				{
+					ContextKey: loader.SnapshotEnvironmentBindingResourcesContextKey,
+					Resource: &loader.SnapshotEnvironmentBindingResources{
+						Application: application,
+						Environment: environment,
+						Snapshot:    snapshot,
+					},
+				},
+			})
+
+			result, err := adapter.EnsureSnapshotEnvironmentBindingExists()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.HasDeploymentFailed()).To(BeTrue())
+		})
+
 		It("updates the binding if one already exists", func() {
 			adapter.release.MarkRunning()
 			adapter.release.MarkSucceeded()
@@ -519,7 +799,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			result, err := adapter.EnsureSnapshotEnvironmentBindingExists()
 			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
 			Expect(err).NotTo(HaveOccurred())
-			Expect(adapter.release.Status.SnapshotEnvironmentBinding).NotTo(BeEmpty())
+			Expect(adapter.release.Status.SnapshotEnvironmentBinding).NotTo(BeNil())
 
 			binding, _ := adapter.loader.GetSnapshotEnvironmentBindingFromReleaseStatus(adapter.ctx, adapter.client, adapter.release)
 			Expect(binding).NotTo(BeNil())
@@ -556,7 +836,11 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			result, err := adapter.EnsureSnapshotEnvironmentBindingExists()
 			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
 			Expect(err).NotTo(HaveOccurred())
-			Expect(adapter.release.Status.SnapshotEnvironmentBinding).NotTo(BeEmpty())
+			Expect(adapter.release.Status.SnapshotEnvironmentBinding).NotTo(BeNil())
+			Expect(adapter.release.GetCurrentEnvironment()).NotTo(BeNil())
+			Expect(adapter.release.GetCurrentEnvironment().Name).To(Equal(environment.Name))
+			Expect(adapter.release.GetCurrentEnvironment().SnapshotEnvironmentBinding).To(
+				Equal(adapter.release.Status.SnapshotEnvironmentBinding))
 
 			// Restore the context to get the actual binding
 			adapter.ctx = context.TODO()
@@ -597,7 +881,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		It("skips the operation if the release has been deployed already", func() {
 			adapter.release.MarkRunning()
 			adapter.release.MarkSucceeded()
-			adapter.release.Status.SnapshotEnvironmentBinding = snapshotEnvironmentBinding.Namespace + "/" + snapshotEnvironmentBinding.Name
+			adapter.release.Status.SnapshotEnvironmentBinding = v1alpha2.NewObjectReference(snapshotEnvironmentBinding.Namespace, snapshotEnvironmentBinding.Name)
 			adapter.release.MarkDeploying(metav1.ConditionFalse, "", "")
 			adapter.release.MarkDeployed("", "")
 
@@ -606,10 +890,22 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("skips the operation if the deployment of the release has failed", func() {
+			adapter.release.MarkRunning()
+			adapter.release.MarkSucceeded()
+			adapter.release.Status.SnapshotEnvironmentBinding = v1alpha2.NewObjectReference("not", "found")
+			adapter.release.MarkDeploying(metav1.ConditionFalse, "", "")
+			adapter.release.MarkDeploymentFailed(v1alpha2.ReleaseReasonDeploymentFailed, "")
+
+			result, err := adapter.EnsureSnapshotEnvironmentBindingIsTracked()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("fails if the binding is not found", func() {
 			adapter.release.MarkRunning()
 			adapter.release.MarkSucceeded()
-			adapter.release.Status.SnapshotEnvironmentBinding = "not/found"
+			adapter.release.Status.SnapshotEnvironmentBinding = v1alpha2.NewObjectReference("not", "found")
 
 			result, err := adapter.EnsureSnapshotEnvironmentBindingIsTracked()
 			Expect(result.RequeueRequest && !result.CancelRequest).To(BeTrue())
@@ -632,7 +928,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 				handler.TypeAnnotation:           adapter.release.Kind,
 				handler.NamespacedNameAnnotation: "other-release",
 			}
-			adapter.release.Status.SnapshotEnvironmentBinding = newSnapshotEnvironmentBinding.Namespace + "/" + newSnapshotEnvironmentBinding.Name
+			adapter.release.Status.SnapshotEnvironmentBinding = v1alpha2.NewObjectReference(newSnapshotEnvironmentBinding.Namespace, newSnapshotEnvironmentBinding.Name)
 
 			result, err := adapter.EnsureSnapshotEnvironmentBindingIsTracked()
 			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
@@ -648,12 +944,87 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			})
 			adapter.release.MarkRunning()
 			adapter.release.MarkSucceeded()
-			adapter.release.Status.SnapshotEnvironmentBinding = snapshotEnvironmentBinding.Namespace + "/" + snapshotEnvironmentBinding.Name
+			adapter.release.Status.SnapshotEnvironmentBinding = v1alpha2.NewObjectReference(snapshotEnvironmentBinding.Namespace, snapshotEnvironmentBinding.Name)
 
 			result, err := adapter.EnsureSnapshotEnvironmentBindingIsTracked()
 			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("requeues the release to refresh the status of its components while it's being deployed", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   releasePlanAdmission,
+				},
+				{
+					ContextKey: loader.SnapshotEnvironmentBindingContextKey,
+					Resource:   snapshotEnvironmentBinding,
+				},
+			})
+			adapter.release.MarkRunning()
+			adapter.release.MarkSucceeded()
+			adapter.release.MarkDeploying(metav1.ConditionUnknown, "", "")
+			adapter.release.Status.SnapshotEnvironmentBinding = v1alpha2.NewObjectReference(snapshotEnvironmentBinding.Namespace, snapshotEnvironmentBinding.Name)
+
+			result, err := adapter.EnsureSnapshotEnvironmentBindingIsTracked()
+			Expect(result.RequeueRequest).To(BeTrue())
+			Expect(result.RequeueDelay).To(Equal(deploymentStatusRefreshInterval))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When getTenantParams is called", func() {
+		var adapter *Adapter
+
+		AfterEach(func() {
+			_ = adapter.client.Delete(ctx, adapter.release)
+		})
+
+		BeforeEach(func() {
+			adapter = createReleaseAndAdapter()
+		})
+
+		It("should merge the ReleasePlan and Release params, giving precedence to the Release ones", func() {
+			adapter.release.Spec.Params = []v1alpha2.Params{{Name: "foo", Value: "release"}}
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanContextKey,
+					Resource: &v1alpha1.ReleasePlan{
+						Spec: v1alpha1.ReleasePlanSpec{
+							Params: []v1alpha1.Params{
+								{Name: "foo", Value: "releaseplan"},
+								{Name: "bar", Value: "releaseplan"},
+							},
+						},
+					},
+				},
+			})
+
+			params, err := adapter.getTenantParams(&v1alpha1.ReleasePlanAdmission{
+				Spec: v1alpha1.ReleasePlanAdmissionSpec{
+					AllowedParams: []v1alpha1.AllowedParam{{Name: "foo"}, {Name: "bar"}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(Equal([]v1alpha1.Params{
+				{Name: "foo", Value: "release"},
+				{Name: "bar", Value: "releaseplan"},
+			}))
+		})
+
+		It("should fail if the ReleasePlan is not found", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanContextKey,
+					Err:        fmt.Errorf("not found"),
+				},
+			})
+
+			params, err := adapter.getTenantParams(releasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(params).To(BeNil())
+		})
 	})
 
 	Context("When createReleasePipelineRun is called", func() {
@@ -672,7 +1043,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			adapter = createReleaseAndAdapter()
 
 			var err error
-			pipelineRun, err = adapter.createReleasePipelineRun(releaseStrategy, enterpriseContractPolicy, snapshot)
+			pipelineRun, err = adapter.createReleasePipelineRun(releaseStrategy, nil, enterpriseContractPolicy, snapshot)
 			Expect(pipelineRun).NotTo(BeNil())
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -707,7 +1078,62 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		})
 	})
 
-	Context("When registerGitOpsDeploymentStatus is called", func() {
+	Context("When createVerificationPipelineRun is called", func() {
+		var (
+			adapter     *Adapter
+			pipelineRun *v1beta1.PipelineRun
+		)
+
+		AfterEach(func() {
+			_ = adapter.client.Delete(ctx, adapter.release)
+
+			Expect(k8sClient.Delete(ctx, pipelineRun)).To(Succeed())
+		})
+
+		BeforeEach(func() {
+			adapter = createReleaseAndAdapter()
+
+			verificationReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			verificationReleasePlanAdmission.Spec.VerificationPipeline = &v1alpha1.VerificationPipeline{
+				PipelineRef: v1alpha1.PipelineRef{
+					Resolver: "cluster",
+					Params: []v1alpha1.ResolverParam{
+						{Name: "name", Value: "smoke-tests"},
+					},
+				},
+				Params: []v1alpha1.Params{{Name: "url", Value: "https://example.com"}},
+			}
+
+			var err error
+			pipelineRun, err = adapter.createVerificationPipelineRun(verificationReleasePlanAdmission, environment.Name, snapshot)
+			Expect(pipelineRun).NotTo(BeNil())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("has owner annotations", func() {
+			Expect(pipelineRun.GetAnnotations()[handler.NamespacedNameAnnotation]).To(ContainSubstring(adapter.release.Name))
+			Expect(pipelineRun.GetAnnotations()[handler.TypeAnnotation]).To(ContainSubstring("Release"))
+		})
+
+		It("has verification labels", func() {
+			Expect(pipelineRun.GetLabels()[tekton.PipelinesTypeLabel]).To(Equal("verification"))
+			Expect(pipelineRun.GetLabels()[tekton.ReleaseNameLabel]).To(Equal(adapter.release.Name))
+			Expect(pipelineRun.GetLabels()[tekton.ReleaseNamespaceLabel]).To(Equal(testNamespace))
+		})
+
+		It("references the pipeline specified in the ReleasePlanAdmission", func() {
+			Expect(string(pipelineRun.Spec.PipelineRef.Resolver)).To(Equal("cluster"))
+		})
+
+		It("contains the params of the verification Pipeline, the Environment and the Snapshot", func() {
+			jsonSpec, _ := json.Marshal(snapshot.Spec)
+			Expect(pipelineRun.Spec.Params).Should(ContainElement(HaveField("Value.StringVal", Equal(string(jsonSpec)))))
+			Expect(pipelineRun.Spec.Params).Should(ContainElement(HaveField("Value.StringVal", Equal(environment.Name))))
+			Expect(pipelineRun.Spec.Params).Should(ContainElement(HaveField("Value.StringVal", Equal("https://example.com"))))
+		})
+	})
+
+	Context("When registerGitOpsDeploymentStatus is called", func() {
 		var adapter *Adapter
 
 		AfterEach(func() {
@@ -741,6 +1167,340 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			Expect(adapter.registerGitOpsDeploymentStatus(newSnapshotEnvironmentBinding)).To(Succeed())
 			Expect(adapter.release.IsDeployed()).To(BeTrue())
 		})
+
+		It("only marks the current Environment as deployed when the Release tracks its Environments", func() {
+			adapter.release.MarkRunning()
+			adapter.release.MarkSucceeded()
+			adapter.release.MarkEnvironmentDeploying(environment.Name,
+				v1alpha2.NewObjectReference(snapshotEnvironmentBinding.Namespace, snapshotEnvironmentBinding.Name))
+			newSnapshotEnvironmentBinding := snapshotEnvironmentBinding.DeepCopy()
+			newSnapshotEnvironmentBinding.Status.ComponentDeploymentConditions = []metav1.Condition{
+				{
+					Type:   applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed,
+					Status: metav1.ConditionTrue,
+					Reason: "Deployed",
+				},
+			}
+			Expect(adapter.registerGitOpsDeploymentStatus(newSnapshotEnvironmentBinding)).To(Succeed())
+			Expect(adapter.release.IsDeployed()).To(BeFalse())
+			Expect(adapter.release.GetCurrentEnvironment().State).To(Equal(v1alpha2.EnvironmentDeploymentStateDeployed))
+		})
+
+		It("registers the deployment status of each component", func() {
+			newSnapshotEnvironmentBinding := snapshotEnvironmentBinding.DeepCopy()
+			newSnapshotEnvironmentBinding.Spec.Components = []applicationapiv1alpha1.BindingComponent{{Name: "foo"}}
+			newSnapshotEnvironmentBinding.Status.GitOpsDeployments = []applicationapiv1alpha1.BindingStatusGitOpsDeployment{
+				{ComponentName: "foo", GitOpsDeployment: "foo-deployment"},
+			}
+			gitOpsDeployment := gitops.NewGitOpsDeployment()
+			gitOpsDeployment.SetName("foo-deployment")
+			Expect(unstructured.SetNestedField(gitOpsDeployment.Object, "Progressing", "status", "health", "status")).To(Succeed())
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.GitOpsDeploymentsContextKey,
+					Resource:   []unstructured.Unstructured{*gitOpsDeployment},
+				},
+				{
+					ContextKey: loader.SnapshotContextKey,
+					Resource: &applicationapiv1alpha1.Snapshot{
+						Spec: applicationapiv1alpha1.SnapshotSpec{
+							Components: []applicationapiv1alpha1.SnapshotComponent{
+								{Name: "foo", ContainerImage: "quay.io/foo:1"},
+							},
+						},
+					},
+				},
+			})
+
+			Expect(adapter.registerGitOpsDeploymentStatus(newSnapshotEnvironmentBinding)).To(Succeed())
+			Expect(adapter.release.Status.Deployment).NotTo(BeNil())
+			Expect(adapter.release.Status.Deployment.Components).To(Equal([]v1alpha2.ComponentDeploymentStatus{
+				{
+					Name:             "foo",
+					ContainerImage:   "quay.io/foo:1",
+					GitOpsDeployment: "foo-deployment",
+					HealthStatus:     "Progressing",
+				},
+			}))
+		})
+
+		It("marks the deployment as failed when the binding reports an error", func() {
+			adapter.release.MarkDeploying(metav1.ConditionFalse, "", "")
+			newSnapshotEnvironmentBinding := snapshotEnvironmentBinding.DeepCopy()
+			newSnapshotEnvironmentBinding.Status.ComponentDeploymentConditions = []metav1.Condition{
+				{
+					Type:    applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed,
+					Status:  metav1.ConditionFalse,
+					Reason:  applicationapiv1alpha1.ComponentDeploymentConditionErrorOccurred,
+					Message: "component foo failed to sync",
+				},
+			}
+			Expect(adapter.registerGitOpsDeploymentStatus(newSnapshotEnvironmentBinding)).To(Succeed())
+			Expect(adapter.release.HasDeploymentFailed()).To(BeTrue())
+
+			condition := meta.FindStatusCondition(adapter.release.Status.Conditions, v1alpha2.DeployedConditionType)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(v1alpha2.ReleaseReasonDeploymentFailed.String()))
+			Expect(condition.Message).To(Equal("component foo failed to sync"))
+		})
+	})
+
+	Context("When ensureDeploymentHasNotTimedOut is called", func() {
+		var adapter *Adapter
+
+		AfterEach(func() {
+			_ = adapter.client.Delete(ctx, adapter.release)
+		})
+
+		BeforeEach(func() {
+			adapter = createReleaseAndAdapter()
+			adapter.release.MarkRunning()
+			adapter.release.MarkSucceeded()
+			adapter.release.MarkDeploying(metav1.ConditionFalse, "", "")
+		})
+
+		It("does nothing if no deployment timeout is set", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   releasePlanAdmission,
+				},
+			})
+
+			result, err := adapter.ensureDeploymentHasNotTimedOut()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.HasDeploymentFailed()).To(BeFalse())
+		})
+
+		It("requeues the Release until the deployment timeout is reached", func() {
+			modifiedReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			modifiedReleasePlanAdmission.Spec.DeploymentTimeout = &metav1.Duration{Duration: time.Hour}
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   modifiedReleasePlanAdmission,
+				},
+			})
+
+			result, err := adapter.ensureDeploymentHasNotTimedOut()
+			Expect(result.RequeueRequest).To(BeTrue())
+			Expect(result.RequeueDelay).To(BeNumerically(">", 0))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.HasDeploymentFailed()).To(BeFalse())
+		})
+
+		It("marks the deployment as failed once the deployment timeout is reached", func() {
+			modifiedReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			modifiedReleasePlanAdmission.Spec.DeploymentTimeout = &metav1.Duration{Duration: time.Minute}
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   modifiedReleasePlanAdmission,
+				},
+			})
+			adapter.release.Status.DeploymentStartTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+
+			result, err := adapter.ensureDeploymentHasNotTimedOut()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.HasDeploymentFailed()).To(BeTrue())
+			Expect(meta.FindStatusCondition(adapter.release.Status.Conditions, v1alpha2.ReadyConditionType).Reason).To(
+				Equal(v1alpha2.ReleaseReasonDeploymentTimedOut.String()))
+		})
+
+		It("measures the timeout from the start of the deployment to the current Environment", func() {
+			modifiedReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			modifiedReleasePlanAdmission.Spec.DeploymentTimeout = &metav1.Duration{Duration: time.Minute}
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   modifiedReleasePlanAdmission,
+				},
+			})
+			adapter.release.Status.DeploymentStartTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+			adapter.release.MarkEnvironmentDeploying("prod", nil)
+
+			result, err := adapter.ensureDeploymentHasNotTimedOut()
+			Expect(result.RequeueRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.HasDeploymentFailed()).To(BeFalse())
+		})
+	})
+
+	Context("When ensureEnvironmentGateIsPassed is called", func() {
+		var adapter *Adapter
+		var binding *applicationapiv1alpha1.SnapshotEnvironmentBinding
+		var multiEnvironmentReleasePlanAdmission *v1alpha1.ReleasePlanAdmission
+
+		AfterEach(func() {
+			_ = adapter.client.Delete(ctx, adapter.release)
+		})
+
+		BeforeEach(func() {
+			adapter = createReleaseAndAdapter()
+			adapter.release.MarkRunning()
+			adapter.release.MarkSucceeded()
+
+			binding = snapshotEnvironmentBinding.DeepCopy()
+			binding.Status.ComponentDeploymentConditions = []metav1.Condition{
+				{
+					Type:   applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed,
+					Status: metav1.ConditionTrue,
+					Reason: "CommitsSynced",
+				},
+			}
+			adapter.release.MarkEnvironmentDeploying("stage",
+				v1alpha2.NewObjectReference(binding.Namespace, binding.Name))
+
+			multiEnvironmentReleasePlanAdmission = releasePlanAdmission.DeepCopy()
+			multiEnvironmentReleasePlanAdmission.Spec.Environment = ""
+			multiEnvironmentReleasePlanAdmission.Spec.Environments = []v1alpha1.EnvironmentStep{
+				{Name: "stage"},
+				{Name: "prod"},
+			}
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   multiEnvironmentReleasePlanAdmission,
+				},
+			})
+		})
+
+		It("moves on to the next Environment right away if the current one has no gate", func() {
+			result, err := adapter.ensureEnvironmentGateIsPassed(binding)
+			Expect(result.RequeueRequest && result.RequeueDelay == 0).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.GetCurrentEnvironment().Name).To(Equal("prod"))
+			Expect(adapter.release.GetCurrentEnvironment().State).To(Equal(v1alpha2.EnvironmentDeploymentStatePending))
+		})
+
+		It("waits for the deployment to finish if the gate requires it", func() {
+			multiEnvironmentReleasePlanAdmission.Spec.Environments[0].Gate = &v1alpha1.EnvironmentGate{
+				WaitForDeployment: true,
+			}
+
+			result, err := adapter.ensureEnvironmentGateIsPassed(binding)
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.GetCurrentEnvironment().Name).To(Equal("stage"))
+
+			adapter.release.MarkEnvironmentDeployed("CommitsSynced")
+			result, err = adapter.ensureEnvironmentGateIsPassed(binding)
+			Expect(result.RequeueRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.GetCurrentEnvironment().Name).To(Equal("prod"))
+		})
+
+		It("requeues the Release until the soak duration is over", func() {
+			multiEnvironmentReleasePlanAdmission.Spec.Environments[0].Gate = &v1alpha1.EnvironmentGate{
+				SoakDuration: &metav1.Duration{Duration: time.Hour},
+			}
+			adapter.release.MarkEnvironmentDeployed("CommitsSynced")
+
+			result, err := adapter.ensureEnvironmentGateIsPassed(binding)
+			Expect(result.RequeueRequest).To(BeTrue())
+			Expect(result.RequeueDelay).To(BeNumerically(">", 59*time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.GetCurrentEnvironment().Name).To(Equal("stage"))
+		})
+
+		It("waits for the approval if the gate requires it", func() {
+			multiEnvironmentReleasePlanAdmission.Spec.Environments[0].Gate = &v1alpha1.EnvironmentGate{
+				RequireApproval: true,
+			}
+
+			result, err := adapter.ensureEnvironmentGateIsPassed(binding)
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.GetCurrentEnvironment().Name).To(Equal("stage"))
+
+			binding.Annotations = map[string]string{
+				v1alpha1.ApprovedReleaseAnnotation: adapter.release.Namespace + "/" + adapter.release.Name,
+			}
+			result, err = adapter.ensureEnvironmentGateIsPassed(binding)
+			Expect(result.RequeueRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.GetCurrentEnvironment().Name).To(Equal("prod"))
+		})
+
+		It("marks the Release as deployed once the last Environment is deployed", func() {
+			adapter.release.MarkEnvironmentPending("prod")
+			adapter.release.MarkEnvironmentDeploying("prod",
+				v1alpha2.NewObjectReference(binding.Namespace, binding.Name))
+
+			result, err := adapter.ensureEnvironmentGateIsPassed(binding)
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.IsDeployed()).To(BeFalse())
+
+			adapter.release.MarkEnvironmentDeployed("CommitsSynced")
+			result, err = adapter.ensureEnvironmentGateIsPassed(binding)
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.IsDeployed()).To(BeTrue())
+			Expect(adapter.release.Status.Environments).To(HaveLen(2))
+		})
+
+		Context("When the ReleasePlanAdmission sets a verification Pipeline", func() {
+			var verificationPipelineRun *v1beta1.PipelineRun
+
+			BeforeEach(func() {
+				multiEnvironmentReleasePlanAdmission.Spec.Environments = []v1alpha1.EnvironmentStep{{Name: "stage"}}
+				multiEnvironmentReleasePlanAdmission.Spec.VerificationPipeline = &v1alpha1.VerificationPipeline{
+					PipelineRef: v1alpha1.PipelineRef{Resolver: "cluster"},
+				}
+				adapter.release.MarkEnvironmentDeployed("CommitsSynced")
+
+				verificationPipelineRun = &v1beta1.PipelineRun{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "verification-pipeline-run",
+						Namespace: "default",
+					},
+				}
+				adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+					{
+						ContextKey: loader.ReleasePlanAdmissionContextKey,
+						Resource:   multiEnvironmentReleasePlanAdmission,
+					},
+					{
+						ContextKey: loader.VerificationPipelineRunContextKey,
+						Resource:   verificationPipelineRun,
+					},
+				})
+			})
+
+			It("doesn't mark the Release as deployed while the verification is running", func() {
+				result, err := adapter.ensureEnvironmentGateIsPassed(binding)
+				Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(adapter.release.IsDeployed()).To(BeFalse())
+				Expect(adapter.release.IsVerifying()).To(BeTrue())
+				Expect(adapter.release.Status.Verification.PipelineRun).To(Equal(
+					v1alpha2.NewObjectReference(verificationPipelineRun.Namespace, verificationPipelineRun.Name)))
+			})
+
+			It("marks the Release as deployed once the verification succeeds", func() {
+				verificationPipelineRun.Status.MarkSucceeded("", "")
+
+				result, err := adapter.ensureEnvironmentGateIsPassed(binding)
+				Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(adapter.release.IsDeployed()).To(BeTrue())
+				Expect(adapter.release.Status.Verification.CompletionTime).NotTo(BeNil())
+			})
+
+			It("marks the deployment as failed if the verification fails", func() {
+				verificationPipelineRun.Status.MarkFailed("", "smoke tests failed")
+
+				result, err := adapter.ensureEnvironmentGateIsPassed(binding)
+				Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(adapter.release.HasDeploymentFailed()).To(BeTrue())
+				Expect(meta.FindStatusCondition(adapter.release.Status.Conditions, v1alpha2.DeployedConditionType).Reason).To(
+					Equal(v1alpha2.ReleaseReasonVerificationFailed.String()))
+			})
+		})
 	})
 
 	Context("When registerReleasePipelineRunStatus is called", func() {
@@ -801,8 +1561,8 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		})
 
 		It("does nothing if there is no PipelineRun", func() {
-			Expect(adapter.registerReleaseStatusData(nil, nil)).To(Succeed())
-			Expect(adapter.release.Status.ReleasePipelineRun).To(BeEmpty())
+			Expect(adapter.registerReleaseStatusData(nil, nil, nil)).To(Succeed())
+			Expect(adapter.release.Status.ReleasePipelineRun).To(BeNil())
 		})
 
 		It("does nothing if there is no ReleaseStrategy", func() {
@@ -812,8 +1572,8 @@ var _ = Describe("Release Adapter", Ordered, func() {
 					Namespace: "default",
 				},
 			}
-			Expect(adapter.registerReleaseStatusData(pipelineRun, nil)).To(Succeed())
-			Expect(adapter.release.Status.ReleasePipelineRun).To(BeEmpty())
+			Expect(adapter.registerReleaseStatusData(pipelineRun, releasePlanAdmission, nil)).To(Succeed())
+			Expect(adapter.release.Status.ReleasePipelineRun).To(BeNil())
 		})
 
 		It("registers the Release data", func() {
@@ -823,17 +1583,69 @@ var _ = Describe("Release Adapter", Ordered, func() {
 					Namespace: "default",
 				},
 			}
-			Expect(adapter.registerReleaseStatusData(pipelineRun, releaseStrategy)).To(Succeed())
-			Expect(adapter.release.Status.ReleasePipelineRun).To(Equal(fmt.Sprintf("%s%c%s",
-				pipelineRun.Namespace, types.Separator, pipelineRun.Name)))
-			Expect(adapter.release.Status.ReleaseStrategy).To(Equal(fmt.Sprintf("%s%c%s",
-				releaseStrategy.Namespace, types.Separator, releaseStrategy.Name)))
+			Expect(adapter.registerReleaseStatusData(pipelineRun, releasePlanAdmission, releaseStrategy)).To(Succeed())
+			Expect(adapter.release.Status.ReleasePipelineRun).To(Equal(
+				v1alpha2.NewObjectReference(pipelineRun.Namespace, pipelineRun.Name)))
+			Expect(adapter.release.Status.ReleasePlanAdmission).To(Equal(
+				v1alpha2.NewObjectReference(releasePlanAdmission.Namespace, releasePlanAdmission.Name)))
+			Expect(adapter.release.Status.ReleaseStrategy).To(Equal(
+				v1alpha2.NewObjectReference(releaseStrategy.Namespace, releaseStrategy.Name)))
+			Expect(adapter.release.Status.EffectiveReleaseStrategy).To(Equal(releaseStrategy.Spec.ConvertToV1alpha2()))
 			Expect(adapter.release.Status.Target).To(Equal(pipelineRun.Namespace))
 		})
+
+		It("registers the requester of the Release", func() {
+			pipelineRun := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipeline-run",
+					Namespace: "default",
+				},
+			}
+			adapter.release.Annotations = map[string]string{
+				v1alpha1.RequesterAnnotation:               "system:serviceaccount:default:release",
+				v1alpha1.RequesterGroupsAnnotation:         "system:serviceaccounts,system:authenticated",
+				v1alpha1.RequesterServiceAccountAnnotation: "true",
+			}
+			Expect(adapter.registerReleaseStatusData(pipelineRun, releasePlanAdmission, releaseStrategy)).To(Succeed())
+			Expect(adapter.release.Status.Requester).To(Equal(&v1alpha1.Requester{
+				Username:       "system:serviceaccount:default:release",
+				Groups:         []string{"system:serviceaccounts", "system:authenticated"},
+				ServiceAccount: true,
+			}))
+		})
+
+		It("registers only the name of a ClusterReleaseStrategy", func() {
+			pipelineRun := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipeline-run",
+					Namespace: "default",
+				},
+			}
+			clusterReleaseStrategy := &v1alpha1.ClusterReleaseStrategy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-release-strategy",
+				},
+				Spec: v1alpha1.ReleaseStrategySpec{
+					Pipeline: "release-pipeline",
+					Policy:   "policy",
+				},
+			}
+			modifiedReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			modifiedReleasePlanAdmission.Spec.ReleaseStrategy = clusterReleaseStrategy.Name
+			modifiedReleasePlanAdmission.Spec.ReleaseStrategyKind = v1alpha1.ClusterReleaseStrategyKind
+
+			effectiveReleaseStrategy := clusterReleaseStrategy.AsReleaseStrategy(modifiedReleasePlanAdmission.Namespace)
+			Expect(adapter.registerReleaseStatusData(pipelineRun, modifiedReleasePlanAdmission,
+				effectiveReleaseStrategy)).To(Succeed())
+			Expect(adapter.release.Status.ReleaseStrategy).To(Equal(v1alpha2.NewObjectReference("", clusterReleaseStrategy.Name)))
+			Expect(adapter.release.Status.EffectiveReleaseStrategy.ClusterReleaseStrategy).To(
+				Equal(clusterReleaseStrategy.Name))
+		})
 	})
 
 	Context("When createOrUpdateSnapshotEnvironmentBinding is called", func() {
 		var adapter *Adapter
+		var resources *loader.SnapshotEnvironmentBindingResources
 
 		AfterEach(func() {
 			_ = adapter.client.Delete(ctx, adapter.release)
@@ -841,69 +1653,141 @@ var _ = Describe("Release Adapter", Ordered, func() {
 
 		BeforeEach(func() {
 			adapter = createReleaseAndAdapter()
+			resources = &loader.SnapshotEnvironmentBindingResources{
+				Application:           application,
+				ApplicationComponents: []applicationapiv1alpha1.Component{*component},
+				Environment:           environment,
+				Snapshot:              snapshot,
+			}
 		})
 
-		It("fails when the required resources are not present", func() {
+		It("creates a new binding owned by the release if the required resources are present", func() {
 			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
 				{
-					ContextKey: loader.SnapshotEnvironmentBindingResourcesContextKey,
-					Err:        fmt.Errorf("not found"),
+					ContextKey: loader.SnapshotEnvironmentBindingContextKey,
 				},
 			})
 
-			_, err := adapter.createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission)
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("not found"))
+			binding, err := adapter.createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission, resources)
+			Expect(binding).NotTo(BeNil())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(binding.OwnerReferences).To(HaveLen(1))
+			Expect(binding.Annotations).To(HaveLen(2))
+			Expect(binding.Annotations[handler.NamespacedNameAnnotation]).To(
+				Equal(adapter.release.Namespace + "/" + adapter.release.Name),
+			)
+			Expect(binding.Annotations[handler.TypeAnnotation]).To(Equal(adapter.release.Kind))
+
+			Expect(k8sClient.Delete(ctx, binding)).Should(Succeed())
 		})
 
-		It("creates a new binding owned by the release if the required resources are present", func() {
+		It("overrides the configuration of the components with the one in the ReleasePlanAdmission", func() {
 			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
 				{
 					ContextKey: loader.SnapshotEnvironmentBindingContextKey,
 				},
+			})
+
+			newReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			newReleasePlanAdmission.Spec.ComponentConfigurations = []v1alpha1.ComponentConfiguration{
 				{
-					ContextKey: loader.SnapshotEnvironmentBindingResourcesContextKey,
-					Resource: &loader.SnapshotEnvironmentBindingResources{
-						Application:           application,
-						ApplicationComponents: []applicationapiv1alpha1.Component{*component},
-						Environment:           environment,
-						Snapshot:              snapshot,
-					},
+					Name:          component.Name,
+					Configuration: applicationapiv1alpha1.BindingComponentConfiguration{Replicas: 3},
 				},
-			})
+			}
 
-			binding, err := adapter.createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission)
+			binding, err := adapter.createOrUpdateSnapshotEnvironmentBinding(newReleasePlanAdmission, resources)
 			Expect(binding).NotTo(BeNil())
 			Expect(err).NotTo(HaveOccurred())
+			Expect(binding.Spec.Components).To(HaveLen(1))
+			Expect(binding.Spec.Components[0].Configuration.Replicas).To(Equal(3))
 
-			Expect(binding.OwnerReferences).To(HaveLen(1))
-			Expect(binding.Annotations).To(HaveLen(2))
-			Expect(binding.Annotations[handler.NamespacedNameAnnotation]).To(
-				Equal(adapter.release.Namespace + "/" + adapter.release.Name),
-			)
-			Expect(binding.Annotations[handler.TypeAnnotation]).To(Equal(adapter.release.Kind))
+			Expect(k8sClient.Delete(ctx, binding)).Should(Succeed())
+		})
+
+		It("only binds the components contained in the Snapshot", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.SnapshotEnvironmentBindingContextKey,
+				},
+			})
+
+			otherComponent := component.DeepCopy()
+			otherComponent.Name = "other-component"
+			resources.ApplicationComponents = append(resources.ApplicationComponents, *otherComponent)
+
+			binding, err := adapter.createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission, resources)
+			Expect(binding).NotTo(BeNil())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binding.Spec.Components).To(HaveLen(1))
+			Expect(binding.Spec.Components[0].Name).To(Equal(component.Name))
 
 			Expect(k8sClient.Delete(ctx, binding)).Should(Succeed())
 		})
 
-		It("updates a binding and marks it as owned by the release if a binding is already present", func() {
+		It("keeps the components missing from the Snapshot that were already bound", func() {
+			otherComponent := component.DeepCopy()
+			otherComponent.Name = "other-component"
+			resources.ApplicationComponents = append(resources.ApplicationComponents, *otherComponent)
+
+			existingBinding := snapshotEnvironmentBinding.DeepCopy()
+			existingBinding.Spec.Components = []applicationapiv1alpha1.BindingComponent{
+				{
+					Name:          otherComponent.Name,
+					Configuration: applicationapiv1alpha1.BindingComponentConfiguration{Replicas: 5},
+				},
+			}
 			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
 				{
 					ContextKey: loader.SnapshotEnvironmentBindingContextKey,
-					Resource:   snapshotEnvironmentBinding,
+					Resource:   existingBinding,
 				},
+			})
+
+			binding, err := adapter.createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission, resources)
+			Expect(binding).NotTo(BeNil())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binding.Spec.Components).To(HaveLen(2))
+			Expect(binding.Spec.Components[1].Name).To(Equal(otherComponent.Name))
+			Expect(binding.Spec.Components[1].Configuration.Replicas).To(Equal(5))
+		})
+
+		It("drops the components missing from the Snapshot when they have to be reported", func() {
+			otherComponent := component.DeepCopy()
+			otherComponent.Name = "other-component"
+			resources.ApplicationComponents = append(resources.ApplicationComponents, *otherComponent)
+
+			existingBinding := snapshotEnvironmentBinding.DeepCopy()
+			existingBinding.Spec.Components = []applicationapiv1alpha1.BindingComponent{
+				{Name: otherComponent.Name},
+			}
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
 				{
-					ContextKey: loader.SnapshotEnvironmentBindingResourcesContextKey,
-					Resource: &loader.SnapshotEnvironmentBindingResources{
-						Application:           application,
-						ApplicationComponents: []applicationapiv1alpha1.Component{*component},
-						Environment:           environment,
-						Snapshot:              snapshot,
-					},
+					ContextKey: loader.SnapshotEnvironmentBindingContextKey,
+					Resource:   existingBinding,
+				},
+			})
+
+			newReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			newReleasePlanAdmission.Spec.MissingComponentsPolicy = v1alpha1.MissingComponentsPolicyReport
+
+			binding, err := adapter.createOrUpdateSnapshotEnvironmentBinding(newReleasePlanAdmission, resources)
+			Expect(binding).NotTo(BeNil())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binding.Spec.Components).To(HaveLen(1))
+			Expect(binding.Spec.Components[0].Name).To(Equal(component.Name))
+		})
+
+		It("updates a binding and marks it as owned by the release if a binding is already present", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.SnapshotEnvironmentBindingContextKey,
+					Resource:   snapshotEnvironmentBinding,
 				},
 			})
 
-			binding, err := adapter.createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission)
+			binding, err := adapter.createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission, resources)
 			Expect(binding).NotTo(BeNil())
 			Expect(err).NotTo(HaveOccurred())
 
@@ -913,6 +1797,170 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			)
 			Expect(binding.Annotations[handler.TypeAnnotation]).To(Equal(adapter.release.Kind))
 		})
+
+		It("records the Snapshot and the components the Environment was running before updating the binding", func() {
+			existingBinding := snapshotEnvironmentBinding.DeepCopy()
+			existingBinding.Spec.Snapshot = "previous-snapshot"
+			existingBinding.Spec.Components = []applicationapiv1alpha1.BindingComponent{{Name: "previous-component"}}
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.SnapshotEnvironmentBindingContextKey,
+					Resource:   existingBinding,
+				},
+			})
+			adapter.release.MarkRunning()
+			adapter.release.MarkSucceeded()
+
+			binding, err := adapter.createOrUpdateSnapshotEnvironmentBinding(releasePlanAdmission, resources)
+			Expect(binding).NotTo(BeNil())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binding.Spec.Snapshot).To(Equal(snapshot.Name))
+			Expect(adapter.release.GetCurrentEnvironment().Name).To(Equal(environment.Name))
+			Expect(adapter.release.GetCurrentEnvironment().PreviousSnapshot).To(Equal("previous-snapshot"))
+			Expect(adapter.release.GetCurrentEnvironment().PreviousComponents).To(Equal(
+				[]applicationapiv1alpha1.BindingComponent{{Name: "previous-component"}}))
+		})
+	})
+
+	Context("When EnsureFailedDeploymentIsRolledBack is called", func() {
+		var adapter *Adapter
+
+		AfterEach(func() {
+			_ = adapter.client.Delete(ctx, adapter.release)
+		})
+
+		BeforeEach(func() {
+			adapter = createReleaseAndAdapter()
+			adapter.release.MarkRunning()
+			adapter.release.MarkSucceeded()
+			adapter.release.RecordPreviousDeployment(environment.Name, "previous-snapshot",
+				[]applicationapiv1alpha1.BindingComponent{{Name: component.Name}, {Name: "pruned-component"}})
+			adapter.release.MarkEnvironmentDeploying(environment.Name,
+				v1alpha2.NewObjectReference(snapshotEnvironmentBinding.Namespace, snapshotEnvironmentBinding.Name))
+		})
+
+		It("skips the operation if the deployment of the release hasn't failed", func() {
+			result, err := adapter.EnsureFailedDeploymentIsRolledBack()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.IsRollbackDone()).To(BeFalse())
+		})
+
+		It("skips the operation if the ReleasePlanAdmission doesn't enable automatic rollbacks", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   releasePlanAdmission,
+				},
+			})
+			adapter.release.MarkDeploymentFailed(v1alpha2.ReleaseReasonDeploymentTimedOut, "")
+
+			result, err := adapter.EnsureFailedDeploymentIsRolledBack()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.IsRollbackDone()).To(BeFalse())
+		})
+
+		It("skips the rollback if another release has updated the binding since", func() {
+			newReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			newReleasePlanAdmission.Spec.AutoRollback = true
+			newSnapshotEnvironmentBinding := snapshotEnvironmentBinding.DeepCopy()
+			newSnapshotEnvironmentBinding.Annotations = map[string]string{
+				handler.TypeAnnotation:           adapter.release.Kind,
+				handler.NamespacedNameAnnotation: "other-release",
+			}
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   newReleasePlanAdmission,
+				},
+				{
+					ContextKey: loader.SnapshotEnvironmentBindingContextKey,
+					Resource:   newSnapshotEnvironmentBinding,
+				},
+			})
+			adapter.release.MarkDeploymentFailed(v1alpha2.ReleaseReasonDeploymentFailed, "")
+
+			result, err := adapter.EnsureFailedDeploymentIsRolledBack()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.IsRollbackDone()).To(BeTrue())
+			Expect(adapter.release.IsRolledBack()).To(BeFalse())
+			Expect(newSnapshotEnvironmentBinding.Spec.Snapshot).To(Equal(snapshot.Name))
+		})
+
+		It("restores the Snapshot the Environment was running before the release", func() {
+			newReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			newReleasePlanAdmission.Spec.AutoRollback = true
+			newSnapshotEnvironmentBinding := snapshotEnvironmentBinding.DeepCopy()
+			newSnapshotEnvironmentBinding.Annotations = map[string]string{
+				handler.TypeAnnotation:           adapter.release.Kind,
+				handler.NamespacedNameAnnotation: adapter.release.Namespace + "/" + adapter.release.Name,
+			}
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   newReleasePlanAdmission,
+				},
+				{
+					ContextKey: loader.SnapshotEnvironmentBindingContextKey,
+					Resource:   newSnapshotEnvironmentBinding,
+				},
+			})
+			adapter.release.MarkDeploymentFailed(v1alpha2.ReleaseReasonDeploymentTimedOut, "")
+
+			result, err := adapter.EnsureFailedDeploymentIsRolledBack()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.IsRolledBack()).To(BeTrue())
+			Expect(newSnapshotEnvironmentBinding.Spec.Snapshot).To(Equal("previous-snapshot"))
+
+			// Restore the binding so the rest of the tests find it as it was
+			patch := client.MergeFrom(newSnapshotEnvironmentBinding.DeepCopy())
+			newSnapshotEnvironmentBinding.Spec.Snapshot = snapshot.Name
+			newSnapshotEnvironmentBinding.Spec.Components = snapshotEnvironmentBinding.Spec.Components
+			Expect(k8sClient.Patch(ctx, newSnapshotEnvironmentBinding, patch)).To(Succeed())
+		})
+
+		It("restores the components the release pruned from the Environment", func() {
+			newReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			newReleasePlanAdmission.Spec.AutoRollback = true
+			newReleasePlanAdmission.Spec.MissingComponentsPolicy = v1alpha1.MissingComponentsPolicyReport
+			newSnapshotEnvironmentBinding := snapshotEnvironmentBinding.DeepCopy()
+			newSnapshotEnvironmentBinding.Annotations = map[string]string{
+				handler.TypeAnnotation:           adapter.release.Kind,
+				handler.NamespacedNameAnnotation: adapter.release.Namespace + "/" + adapter.release.Name,
+			}
+			newSnapshotEnvironmentBinding.Spec.Components = []applicationapiv1alpha1.BindingComponent{
+				{Name: component.Name},
+			}
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Resource:   newReleasePlanAdmission,
+				},
+				{
+					ContextKey: loader.SnapshotEnvironmentBindingContextKey,
+					Resource:   newSnapshotEnvironmentBinding,
+				},
+			})
+			adapter.release.MarkDeploymentFailed(v1alpha2.ReleaseReasonDeploymentFailed, "")
+
+			result, err := adapter.EnsureFailedDeploymentIsRolledBack()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.release.IsRolledBack()).To(BeTrue())
+			Expect(newSnapshotEnvironmentBinding.Spec.Snapshot).To(Equal("previous-snapshot"))
+			Expect(newSnapshotEnvironmentBinding.Spec.Components).To(Equal([]applicationapiv1alpha1.BindingComponent{
+				{Name: component.Name}, {Name: "pruned-component"},
+			}))
+
+			// Restore the binding so the rest of the tests find it as it was
+			patch := client.MergeFrom(newSnapshotEnvironmentBinding.DeepCopy())
+			newSnapshotEnvironmentBinding.Spec.Snapshot = snapshot.Name
+			newSnapshotEnvironmentBinding.Spec.Components = snapshotEnvironmentBinding.Spec.Components
+			Expect(k8sClient.Patch(ctx, newSnapshotEnvironmentBinding, patch)).To(Succeed())
+		})
 	})
 
 	Context("When finalizeRelease is called", func() {
@@ -931,7 +1979,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		})
 
 		It("finalizes the Release and deletes the PipelineRun", func() {
-			pipelineRun, err := adapter.createReleasePipelineRun(releaseStrategy, enterpriseContractPolicy, snapshot)
+			pipelineRun, err := adapter.createReleasePipelineRun(releaseStrategy, nil, enterpriseContractPolicy, snapshot)
 			Expect(pipelineRun).NotTo(BeNil())
 			Expect(err).NotTo(HaveOccurred())
 
@@ -991,12 +2039,12 @@ var _ = Describe("Release Adapter", Ordered, func() {
 	})
 
 	createReleaseAndAdapter = func() *Adapter {
-		release := &v1alpha1.Release{
+		release := &v1alpha2.Release{
 			ObjectMeta: metav1.ObjectMeta{
 				GenerateName: "release-",
 				Namespace:    "default",
 			},
-			Spec: v1alpha1.ReleaseSpec{
+			Spec: v1alpha2.ReleaseSpec{
 				Snapshot:    snapshot.Name,
 				ReleasePlan: releasePlan.Name,
 			},
@@ -1111,6 +2159,12 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			},
 			Spec: applicationapiv1alpha1.SnapshotSpec{
 				Application: application.Name,
+				Components: []applicationapiv1alpha1.SnapshotComponent{
+					{
+						Name:           "component",
+						ContainerImage: "quay.io/redhat-appstudio/component:latest",
+					},
+				},
 			},
 		}
 		Expect(k8sClient.Create(ctx, snapshot)).To(Succeed())
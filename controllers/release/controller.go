@@ -23,12 +23,11 @@ import (
 	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
 	goodies "github.com/redhat-appstudio/operator-goodies/predicates"
 	"github.com/redhat-appstudio/operator-goodies/reconciler"
-	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
 	"github.com/redhat-appstudio/release-service/cache"
 	"github.com/redhat-appstudio/release-service/gitops"
 	"github.com/redhat-appstudio/release-service/loader"
 	"github.com/redhat-appstudio/release-service/tekton"
-	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -61,13 +60,15 @@ func NewReleaseReconciler(client client.Client, logger *logr.Logger, scheme *run
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applications/finalizers,verbs=update
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=enterprisecontractpolicies,verbs=get;list;watch
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=enterprisecontractpolicies/status,verbs=get
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=managed-gitops.redhat.com,resources=gitopsdeployments,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := r.Log.WithValues("Release", req.NamespacedName)
 
-	release := &v1alpha1.Release{}
+	release := &v1alpha2.Release{}
 	err := r.Get(ctx, req.NamespacedName, release)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -83,10 +84,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		adapter.EnsureReleasePlanAdmissionEnabled,
 		adapter.EnsureFinalizersAreCalled,
 		adapter.EnsureFinalizerIsAdded,
+		adapter.EnsureRollbackTargetIsValid,
 		adapter.EnsureReleasePipelineRunExists,
 		adapter.EnsureReleasePipelineStatusIsTracked,
 		adapter.EnsureSnapshotEnvironmentBindingExists,
 		adapter.EnsureSnapshotEnvironmentBindingIsTracked,
+		adapter.EnsureFailedDeploymentIsRolledBack,
 	})
 
 }
@@ -111,8 +114,8 @@ func setupCache(mgr ctrl.Manager) error {
 }
 
 // setupControllerWithManager sets up the controller with the Manager which monitors new Releases and filters out
-// status updates. This controller also watches for PipelineRuns and SnapshotEnvironmentBindings that are created
-// by this controller and owned by the Releases so the owner gets reconciled on changes.
+// status updates. This controller also watches for release and verification PipelineRuns and SnapshotEnvironmentBindings
+// that are created by this controller and owned by the Releases so the owner gets reconciled on changes.
 func setupControllerWithManager(manager ctrl.Manager, reconciler *Reconciler) error {
 	err := setupCache(manager)
 	if err != nil {
@@ -120,18 +123,19 @@ func setupControllerWithManager(manager ctrl.Manager, reconciler *Reconciler) er
 	}
 
 	return ctrl.NewControllerManagedBy(manager).
-		For(&v1alpha1.Release{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&v1alpha2.Release{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(&source.Kind{Type: &applicationapiv1alpha1.SnapshotEnvironmentBinding{}}, &libhandler.EnqueueRequestForAnnotation{
 			Type: schema.GroupKind{
 				Kind:  "Release",
 				Group: "appstudio.redhat.com",
 			},
 		}, builder.WithPredicates(goodies.GenerationUnchangedOnUpdatePredicate{}, gitops.DeploymentFinishedPredicate())).
-		Watches(&source.Kind{Type: &v1beta1.PipelineRun{}}, &libhandler.EnqueueRequestForAnnotation{
+		Watches(&source.Kind{Type: tekton.NewPipelineRunObject()}, &libhandler.EnqueueRequestForAnnotation{
 			Type: schema.GroupKind{
 				Kind:  "Release",
 				Group: "appstudio.redhat.com",
 			},
-		}, builder.WithPredicates(tekton.ReleasePipelineRunSucceededPredicate())).
+		}, builder.WithPredicates(predicate.Or(tekton.ReleasePipelineRunSucceededPredicate(),
+			tekton.VerificationPipelineRunSucceededPredicate()))).
 		Complete(reconciler)
 }
@@ -0,0 +1,80 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseplanadmission
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/redhat-appstudio/operator-goodies/reconciler"
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/loader"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Adapter holds the objects needed to reconcile a ReleasePlanAdmission.
+type Adapter struct {
+	client               client.Client
+	ctx                  context.Context
+	loader               loader.ObjectLoader
+	logger               logr.Logger
+	releasePlanAdmission *v1alpha1.ReleasePlanAdmission
+}
+
+// NewAdapter creates and returns an Adapter instance.
+func NewAdapter(ctx context.Context, client client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission, loader loader.ObjectLoader, logger logr.Logger) *Adapter {
+	return &Adapter{
+		client:               client,
+		ctx:                  ctx,
+		loader:               loader,
+		logger:               logger,
+		releasePlanAdmission: releasePlanAdmission,
+	}
+}
+
+// EnsureReleasePlanAdmissionIsValidated is an operation that will ensure that no other ReleasePlanAdmission in the
+// same namespace admits releases of any of the same applications from any of the same origins with the same priority, registering the result of the
+// validation in the ReleasePlanAdmission status. Conflicts are rejected by the webhook, but they can still exist if
+// they were created before the validation was in place or if the admissions were created concurrently.
+func (a *Adapter) EnsureReleasePlanAdmissionIsValidated() (reconciler.OperationResult, error) {
+	patch := client.MergeFrom(a.releasePlanAdmission.DeepCopy())
+
+	conflictingReleasePlanAdmissions, err := a.loader.GetConflictingReleasePlanAdmissions(a.ctx, a.client,
+		a.releasePlanAdmission)
+	if err != nil {
+		return reconciler.RequeueWithError(err)
+	}
+
+	if len(conflictingReleasePlanAdmissions) > 0 {
+		var names []string
+		for _, conflictingReleasePlanAdmission := range conflictingReleasePlanAdmissions {
+			names = append(names, conflictingReleasePlanAdmission.Name)
+		}
+
+		a.releasePlanAdmission.MarkInvalid(v1alpha1.ReleasePlanAdmissionReasonConflict,
+			fmt.Sprintf("the ReleasePlanAdmissions %s also admit releases of the applications '%s' from the "+
+				"origins '%s' with priority %d", strings.Join(names, ", "),
+				strings.Join(a.releasePlanAdmission.GetApplications(), ", "),
+				strings.Join(a.releasePlanAdmission.GetOrigins(), ", "), a.releasePlanAdmission.Spec.Priority))
+	} else {
+		a.releasePlanAdmission.MarkValid()
+	}
+
+	return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.releasePlanAdmission, patch))
+}
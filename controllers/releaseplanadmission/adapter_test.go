@@ -0,0 +1,125 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseplanadmission
+
+import (
+	"fmt"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/loader"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var _ = Describe("ReleasePlanAdmission Adapter", Ordered, func() {
+	var (
+		createReleasePlanAdmissionAndAdapter func() *Adapter
+	)
+
+	Context("When NewAdapter is called", func() {
+		It("creates and return a new adapter", func() {
+			Expect(reflect.TypeOf(NewAdapter(ctx, k8sClient, nil, loader.NewLoader(), ctrl.Log))).To(Equal(reflect.TypeOf(&Adapter{})))
+		})
+	})
+
+	Context("When EnsureReleasePlanAdmissionIsValidated is called", func() {
+		var adapter *Adapter
+
+		AfterEach(func() {
+			_ = adapter.client.Delete(ctx, adapter.releasePlanAdmission)
+		})
+
+		BeforeEach(func() {
+			adapter = createReleasePlanAdmissionAndAdapter()
+		})
+
+		It("marks the ReleasePlanAdmission as valid if there are no conflicts", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ConflictingReleasePlanAdmissionsContextKey,
+					Resource:   []v1alpha1.ReleasePlanAdmission{},
+				},
+			})
+
+			result, err := adapter.EnsureReleasePlanAdmissionIsValidated()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.releasePlanAdmission.IsValid()).To(BeTrue())
+		})
+
+		It("marks the ReleasePlanAdmission as invalid if other ReleasePlanAdmissions conflict with it", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ConflictingReleasePlanAdmissionsContextKey,
+					Resource: []v1alpha1.ReleasePlanAdmission{
+						{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "bar"}},
+					},
+				},
+			})
+
+			result, err := adapter.EnsureReleasePlanAdmissionIsValidated()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.releasePlanAdmission.IsValid()).To(BeFalse())
+
+			condition := meta.FindStatusCondition(adapter.releasePlanAdmission.Status.Conditions, "Valid")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(v1alpha1.ReleasePlanAdmissionReasonConflict.String()))
+			Expect(condition.Message).To(ContainSubstring("foo, bar"))
+		})
+
+		It("requeues with an error if the conflicting ReleasePlanAdmissions can't be listed", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ConflictingReleasePlanAdmissionsContextKey,
+					Err:        fmt.Errorf("list error"),
+				},
+			})
+
+			result, err := adapter.EnsureReleasePlanAdmissionIsValidated()
+			Expect(result.RequeueRequest).To(BeTrue())
+			Expect(err).To(HaveOccurred())
+			Expect(adapter.releasePlanAdmission.Status.Conditions).To(BeEmpty())
+		})
+	})
+
+	createReleasePlanAdmissionAndAdapter = func() *Adapter {
+		releasePlanAdmission := &v1alpha1.ReleasePlanAdmission{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "release-plan-admission-",
+				Namespace:    testNamespace,
+			},
+			Spec: v1alpha1.ReleasePlanAdmissionSpec{
+				Application:     "application",
+				Origin:          "default",
+				Environment:     "environment",
+				ReleaseStrategy: "release-strategy",
+			},
+		}
+		Expect(k8sClient.Create(ctx, releasePlanAdmission)).To(Succeed())
+		releasePlanAdmission.Kind = "ReleasePlanAdmission"
+
+		return NewAdapter(ctx, k8sClient, releasePlanAdmission, loader.NewMockLoader(), ctrl.Log)
+	}
+})
@@ -0,0 +1,133 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseplanadmission
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/redhat-appstudio/operator-goodies/reconciler"
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/loader"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Reconciler reconciles a ReleasePlanAdmission object
+type Reconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// NewReleasePlanAdmissionReconciler creates and returns a Reconciler.
+func NewReleasePlanAdmissionReconciler(client client.Client, logger *logr.Logger, scheme *runtime.Scheme) *Reconciler {
+	return &Reconciler{
+		Client: client,
+		Log:    logger.WithName("releasePlanAdmission"),
+		Scheme: scheme,
+	}
+}
+
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=releaseplanadmissions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=releaseplanadmissions/status,verbs=get;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("ReleasePlanAdmission", req.NamespacedName)
+
+	releasePlanAdmission := &v1alpha1.ReleasePlanAdmission{}
+	err := r.Get(ctx, req.NamespacedName, releasePlanAdmission)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	adapter := NewAdapter(ctx, r.Client, releasePlanAdmission, loader.NewLoader(), logger)
+
+	return reconciler.ReconcileHandler([]reconciler.ReconcileOperation{
+		adapter.EnsureReleasePlanAdmissionIsValidated,
+	})
+}
+
+// SetupController creates a new ReleasePlanAdmission reconciler and adds it to the Manager.
+func SetupController(manager ctrl.Manager, log *logr.Logger) error {
+	return setupControllerWithManager(manager, NewReleasePlanAdmissionReconciler(manager.GetClient(), log, manager.GetScheme()))
+}
+
+// setupControllerWithManager sets up the controller with the Manager which monitors ReleasePlanAdmissions and filters
+// out status updates. Changes in a ReleasePlanAdmission also trigger the reconciliation of the ReleasePlanAdmissions
+// it conflicts with before and after the change, so conflicts get reported and cleared on all of them. Conflicting
+// ReleasePlanAdmissions are looked up through the "spec.origin" cache index set up by the release controller.
+func setupControllerWithManager(manager ctrl.Manager, reconciler *Reconciler) error {
+	return ctrl.NewControllerManagedBy(manager).
+		For(&v1alpha1.ReleasePlanAdmission{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &v1alpha1.ReleasePlanAdmission{}}, handler.Funcs{
+			CreateFunc: func(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+				reconciler.enqueueConflictingReleasePlanAdmissions(e.Object, q)
+			},
+			UpdateFunc: func(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+				reconciler.enqueueConflictingReleasePlanAdmissions(e.ObjectOld, q)
+				reconciler.enqueueConflictingReleasePlanAdmissions(e.ObjectNew, q)
+			},
+			DeleteFunc: func(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+				reconciler.enqueueConflictingReleasePlanAdmissions(e.Object, q)
+			},
+		}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(reconciler)
+}
+
+// enqueueConflictingReleasePlanAdmissions adds a reconcile request for each of the ReleasePlanAdmissions conflicting
+// with the given object to the queue.
+func (r *Reconciler) enqueueConflictingReleasePlanAdmissions(object client.Object, queue workqueue.RateLimitingInterface) {
+	releasePlanAdmission, ok := object.(*v1alpha1.ReleasePlanAdmission)
+	if !ok {
+		return
+	}
+
+	conflictingReleasePlanAdmissions, err := loader.NewLoader().GetConflictingReleasePlanAdmissions(
+		context.Background(), r.Client, releasePlanAdmission)
+	if err != nil {
+		r.Log.Error(err, "Unable to list conflicting ReleasePlanAdmissions",
+			"ReleasePlanAdmission.Name", releasePlanAdmission.Name,
+			"ReleasePlanAdmission.Namespace", releasePlanAdmission.Namespace)
+		return
+	}
+
+	for _, conflictingReleasePlanAdmission := range conflictingReleasePlanAdmissions {
+		queue.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      conflictingReleasePlanAdmission.Name,
+				Namespace: conflictingReleasePlanAdmission.Namespace,
+			},
+		})
+	}
+}
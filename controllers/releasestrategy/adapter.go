@@ -0,0 +1,171 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasestrategy
+
+import (
+	"context"
+	goerrors "errors"
+
+	"github.com/go-logr/logr"
+	"github.com/redhat-appstudio/operator-goodies/reconciler"
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/loader"
+	"github.com/redhat-appstudio/release-service/tekton"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Adapter holds the objects needed to reconcile a ReleaseStrategy.
+type Adapter struct {
+	client          client.Client
+	ctx             context.Context
+	loader          loader.ObjectLoader
+	logger          logr.Logger
+	releaseStrategy *v1alpha1.ReleaseStrategy
+}
+
+// NewAdapter creates and returns an Adapter instance.
+func NewAdapter(ctx context.Context, client client.Client, releaseStrategy *v1alpha1.ReleaseStrategy, loader loader.ObjectLoader, logger logr.Logger) *Adapter {
+	return &Adapter{
+		client:          client,
+		ctx:             ctx,
+		loader:          loader,
+		logger:          logger,
+		releaseStrategy: releaseStrategy,
+	}
+}
+
+// EnsureReleaseStrategyIsValidated is an operation that will ensure that the workspaces declared by the
+// ReleaseStrategy being processed are well defined, that the resources it references exist and that its params match
+// the ones declared by the release Pipeline, registering the result of the validation in its status. If the
+// ReleaseStrategy inherits from a ClusterReleaseStrategy, the effective ReleaseStrategy resulting from merging both
+// will be validated. Pipelines referenced by bundle or through a resolver are resolved using a Tekton
+// ResolutionRequest, so the validation will finish once the request is resolved.
+func (a *Adapter) EnsureReleaseStrategyIsValidated() (reconciler.OperationResult, error) {
+	patch := client.MergeFrom(a.releaseStrategy.DeepCopy())
+
+	releaseStrategy := a.releaseStrategy
+	if a.releaseStrategy.Spec.ClusterReleaseStrategy != "" {
+		clusterReleaseStrategy, err := a.loader.GetClusterReleaseStrategy(a.ctx, a.client,
+			a.releaseStrategy.Spec.ClusterReleaseStrategy)
+		if err != nil {
+			a.releaseStrategy.MarkInvalid(v1alpha1.ReleaseStrategyReasonInheritanceError, err.Error())
+			return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.releaseStrategy, patch))
+		}
+		releaseStrategy = a.releaseStrategy.InheritFrom(clusterReleaseStrategy)
+	}
+
+	if err := releaseStrategy.ValidateWorkspaces(); err != nil {
+		a.releaseStrategy.MarkInvalid(v1alpha1.ReleaseStrategyReasonWorkspaceError, err.Error())
+		return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.releaseStrategy, patch))
+	}
+
+	params, err := a.loader.GetReleaseStrategyParams(a.ctx, a.client, releaseStrategy)
+	if err != nil {
+		a.releaseStrategy.MarkInvalid(v1alpha1.ReleaseStrategyReasonParamReferenceError, err.Error())
+		return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.releaseStrategy, patch))
+	}
+
+	_, err = a.loader.GetEnterpriseContractPolicy(a.ctx, a.client, releaseStrategy)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconciler.RequeueWithError(err)
+	} else if err != nil {
+		a.releaseStrategy.MarkInvalid(v1alpha1.ReleaseStrategyReasonPolicyError, err.Error())
+		return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.releaseStrategy, patch))
+	}
+
+	pipeline, err := a.loader.GetReleaseStrategyPipeline(a.ctx, a.client, releaseStrategy)
+	if err != nil {
+		resolutionRequest := tekton.NewPipelineResolutionRequest(releaseStrategy)
+		switch {
+		case resolutionRequest != nil && errors.IsNotFound(err):
+			if err := a.createResolutionRequest(resolutionRequest); err != nil {
+				return reconciler.RequeueWithError(err)
+			}
+			a.releaseStrategy.MarkValidating(tekton.ErrResolutionInProgress.Error())
+		case goerrors.Is(err, tekton.ErrResolutionInProgress):
+			a.releaseStrategy.MarkValidating(err.Error())
+		case errors.IsNotFound(err):
+			a.releaseStrategy.MarkInvalid(v1alpha1.ReleaseStrategyReasonPipelineError, err.Error())
+		case isAPIError(err):
+			return reconciler.RequeueWithError(err)
+		default:
+			// The resolution failed or the resolved resource is not a valid Pipeline
+			a.releaseStrategy.MarkInvalid(v1alpha1.ReleaseStrategyReasonPipelineError, err.Error())
+		}
+
+		return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.releaseStrategy, patch))
+	}
+
+	providedParams, err := a.getProvidedParams()
+	if err != nil {
+		return reconciler.RequeueWithError(err)
+	}
+
+	if err := tekton.ValidatePipelineParams(pipeline, params, providedParams); err != nil {
+		a.releaseStrategy.MarkInvalid(v1alpha1.ReleaseStrategyReasonParamMismatchError, err.Error())
+	} else {
+		a.releaseStrategy.MarkValid()
+	}
+
+	return reconciler.RequeueOnErrorOrContinue(a.client.Status().Patch(a.ctx, a.releaseStrategy, patch))
+}
+
+// createResolutionRequest creates the given ResolutionRequest setting the ReleaseStrategy being processed as its
+// owner, so the request gets deleted along with the ReleaseStrategy and its updates trigger new reconciliations.
+func (a *Adapter) createResolutionRequest(resolutionRequest client.Object) error {
+	err := controllerutil.SetControllerReference(a.releaseStrategy, resolutionRequest, a.client.Scheme())
+	if err != nil {
+		return err
+	}
+
+	err = a.client.Create(a.ctx, resolutionRequest)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	a.logger.Info("Created ResolutionRequest to resolve the release Pipeline",
+		"ResolutionRequest.Name", resolutionRequest.GetName(), "ResolutionRequest.Namespace", resolutionRequest.GetNamespace())
+
+	return nil
+}
+
+// getProvidedParams returns the names of the params that are set when creating the release PipelineRun even though
+// they are not declared by the ReleaseStrategy being processed. That includes the params added by the release-service
+// and the tenant params allowed by the ReleasePlanAdmissions referencing the ReleaseStrategy.
+func (a *Adapter) getProvidedParams() ([]string, error) {
+	releasePlanAdmissions, err := a.loader.GetReleaseStrategyReleasePlanAdmissions(a.ctx, a.client, a.releaseStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	providedParams := append([]string{}, tekton.ServiceProvidedParams...)
+	for _, releasePlanAdmission := range releasePlanAdmissions {
+		for _, allowedParam := range releasePlanAdmission.Spec.AllowedParams {
+			providedParams = append(providedParams, allowedParam.Name)
+		}
+	}
+
+	return providedParams, nil
+}
+
+// isAPIError returns a boolean indicating whether the given error was returned by the Kubernetes API.
+func isAPIError(err error) bool {
+	_, ok := err.(errors.APIStatus)
+	return ok
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasestrategy
+
+import (
+	"fmt"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/loader"
+	"github.com/redhat-appstudio/release-service/tekton"
+
+	ecapiv1alpha1 "github.com/hacbs-contract/enterprise-contract-controller/api/v1alpha1"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ReleaseStrategy Adapter", Ordered, func() {
+	var (
+		createReleaseStrategyAndAdapter func() *Adapter
+	)
+
+	Context("When NewAdapter is called", func() {
+		It("creates and return a new adapter", func() {
+			Expect(reflect.TypeOf(NewAdapter(ctx, k8sClient, nil, loader.NewLoader(), ctrl.Log))).To(Equal(reflect.TypeOf(&Adapter{})))
+		})
+	})
+
+	Context("When EnsureReleaseStrategyIsValidated is called", func() {
+		var adapter *Adapter
+
+		AfterEach(func() {
+			_ = adapter.client.Delete(ctx, adapter.releaseStrategy)
+		})
+
+		BeforeEach(func() {
+			adapter = createReleaseStrategyAndAdapter()
+		})
+
+		It("marks the ReleaseStrategy as valid if all its references can be resolved", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.EnterpriseContractPolicyContextKey,
+					Resource:   &ecapiv1alpha1.EnterpriseContractPolicy{},
+				},
+				{
+					ContextKey: loader.ReleaseStrategyParamsContextKey,
+					Resource:   []v1alpha1.Params{},
+				},
+				{
+					ContextKey: loader.ReleaseStrategyPipelineContextKey,
+					Resource:   &tektonv1beta1.Pipeline{},
+				},
+				{
+					ContextKey: loader.ReleaseStrategyReleasePlanAdmissionsContextKey,
+					Resource:   []v1alpha1.ReleasePlanAdmission{},
+				},
+			})
+
+			result, err := adapter.EnsureReleaseStrategyIsValidated()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.releaseStrategy.IsValid()).To(BeTrue())
+		})
+
+		It("marks the ReleaseStrategy as invalid if any of its param references can't be resolved", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleaseStrategyParamsContextKey,
+					Err:        fmt.Errorf("unable to resolve param 'foo'"),
+				},
+			})
+
+			result, err := adapter.EnsureReleaseStrategyIsValidated()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.releaseStrategy.IsValid()).To(BeFalse())
+
+			condition := meta.FindStatusCondition(adapter.releaseStrategy.Status.Conditions, "Valid")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(v1alpha1.ReleaseStrategyReasonParamReferenceError.String()))
+			Expect(condition.Message).To(ContainSubstring("unable to resolve param 'foo'"))
+		})
+
+		It("marks the ReleaseStrategy as invalid if its EnterpriseContractPolicy can't be found", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleaseStrategyParamsContextKey,
+					Resource:   []v1alpha1.Params{},
+				},
+			})
+
+			result, err := adapter.EnsureReleaseStrategyIsValidated()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.releaseStrategy.IsValid()).To(BeFalse())
+
+			condition := meta.FindStatusCondition(adapter.releaseStrategy.Status.Conditions, "Valid")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(v1alpha1.ReleaseStrategyReasonPolicyError.String()))
+		})
+
+		It("marks the ReleaseStrategy as invalid if its Pipeline can't be found", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.EnterpriseContractPolicyContextKey,
+					Resource:   &ecapiv1alpha1.EnterpriseContractPolicy{},
+				},
+				{
+					ContextKey: loader.ReleaseStrategyParamsContextKey,
+					Resource:   []v1alpha1.Params{},
+				},
+			})
+
+			result, err := adapter.EnsureReleaseStrategyIsValidated()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.releaseStrategy.IsValid()).To(BeFalse())
+
+			condition := meta.FindStatusCondition(adapter.releaseStrategy.Status.Conditions, "Valid")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(v1alpha1.ReleaseStrategyReasonPipelineError.String()))
+		})
+
+		It("creates a ResolutionRequest and waits for it if the Pipeline is referenced by bundle", func() {
+			adapter.releaseStrategy.Spec.Bundle = "quay.io/foo/bar:baz"
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.EnterpriseContractPolicyContextKey,
+					Resource:   &ecapiv1alpha1.EnterpriseContractPolicy{},
+				},
+				{
+					ContextKey: loader.ReleaseStrategyParamsContextKey,
+					Resource:   []v1alpha1.Params{},
+				},
+			})
+
+			result, err := adapter.EnsureReleaseStrategyIsValidated()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+
+			condition := meta.FindStatusCondition(adapter.releaseStrategy.Status.Conditions, "Valid")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+			Expect(condition.Reason).To(Equal(v1alpha1.ReleaseStrategyReasonValidating.String()))
+
+			resolutionRequest := tekton.NewPipelineResolutionRequest(adapter.releaseStrategy)
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(resolutionRequest), resolutionRequest)).To(Succeed())
+			Expect(metav1.IsControlledBy(resolutionRequest, adapter.releaseStrategy)).To(BeTrue())
+		})
+
+		It("marks the ReleaseStrategy as invalid if its params don't match the Pipeline ones", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.EnterpriseContractPolicyContextKey,
+					Resource:   &ecapiv1alpha1.EnterpriseContractPolicy{},
+				},
+				{
+					ContextKey: loader.ReleaseStrategyParamsContextKey,
+					Resource:   []v1alpha1.Params{{Name: "foo", Value: "bar"}},
+				},
+				{
+					ContextKey: loader.ReleaseStrategyPipelineContextKey,
+					Resource: &tektonv1beta1.Pipeline{
+						Spec: tektonv1beta1.PipelineSpec{
+							Params: []tektonv1beta1.ParamSpec{
+								{Name: "foo", Type: tektonv1beta1.ParamTypeArray},
+							},
+						},
+					},
+				},
+				{
+					ContextKey: loader.ReleaseStrategyReleasePlanAdmissionsContextKey,
+					Resource:   []v1alpha1.ReleasePlanAdmission{},
+				},
+			})
+
+			result, err := adapter.EnsureReleaseStrategyIsValidated()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.releaseStrategy.IsValid()).To(BeFalse())
+
+			condition := meta.FindStatusCondition(adapter.releaseStrategy.Status.Conditions, "Valid")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(v1alpha1.ReleaseStrategyReasonParamMismatchError.String()))
+			Expect(condition.Message).To(ContainSubstring("param 'foo' is of type 'array'"))
+		})
+
+		It("accepts required params allowed as tenant params by a ReleasePlanAdmission", func() {
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.EnterpriseContractPolicyContextKey,
+					Resource:   &ecapiv1alpha1.EnterpriseContractPolicy{},
+				},
+				{
+					ContextKey: loader.ReleaseStrategyParamsContextKey,
+					Resource:   []v1alpha1.Params{},
+				},
+				{
+					ContextKey: loader.ReleaseStrategyPipelineContextKey,
+					Resource: &tektonv1beta1.Pipeline{
+						Spec: tektonv1beta1.PipelineSpec{
+							Params: []tektonv1beta1.ParamSpec{{Name: "foo"}},
+						},
+					},
+				},
+				{
+					ContextKey: loader.ReleaseStrategyReleasePlanAdmissionsContextKey,
+					Resource: []v1alpha1.ReleasePlanAdmission{
+						{
+							Spec: v1alpha1.ReleasePlanAdmissionSpec{
+								AllowedParams: []v1alpha1.AllowedParam{{Name: "foo"}},
+							},
+						},
+					},
+				},
+			})
+
+			result, err := adapter.EnsureReleaseStrategyIsValidated()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.releaseStrategy.IsValid()).To(BeTrue())
+		})
+
+		It("marks the ReleaseStrategy as invalid if any of its workspaces is not well defined", func() {
+			adapter.releaseStrategy.Spec.Workspaces = []v1alpha1.Workspace{{Name: "foo"}}
+
+			result, err := adapter.EnsureReleaseStrategyIsValidated()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.releaseStrategy.IsValid()).To(BeFalse())
+
+			condition := meta.FindStatusCondition(adapter.releaseStrategy.Status.Conditions, "Valid")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(v1alpha1.ReleaseStrategyReasonWorkspaceError.String()))
+			Expect(condition.Message).To(ContainSubstring("workspace 'foo' must set exactly one volume source"))
+		})
+
+		It("marks the ReleaseStrategy as invalid if the ClusterReleaseStrategy it inherits from can't be found", func() {
+			adapter.releaseStrategy.Spec.ClusterReleaseStrategy = "cluster-release-strategy"
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ClusterReleaseStrategyContextKey,
+					Err:        fmt.Errorf("not found"),
+				},
+			})
+
+			result, err := adapter.EnsureReleaseStrategyIsValidated()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.releaseStrategy.IsValid()).To(BeFalse())
+
+			condition := meta.FindStatusCondition(adapter.releaseStrategy.Status.Conditions, "Valid")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(v1alpha1.ReleaseStrategyReasonInheritanceError.String()))
+		})
+
+		It("validates the ReleaseStrategy resulting from inheriting from a ClusterReleaseStrategy", func() {
+			adapter.releaseStrategy.Spec.ClusterReleaseStrategy = "cluster-release-strategy"
+			adapter.ctx = loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ClusterReleaseStrategyContextKey,
+					Resource: &v1alpha1.ClusterReleaseStrategy{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster-release-strategy",
+						},
+						Spec: v1alpha1.ReleaseStrategySpec{
+							Pipeline:   "release-pipeline",
+							Policy:     "policy",
+							Workspaces: []v1alpha1.Workspace{{Name: "bar"}},
+						},
+					},
+				},
+			})
+
+			result, err := adapter.EnsureReleaseStrategyIsValidated()
+			Expect(!result.RequeueRequest && !result.CancelRequest).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adapter.releaseStrategy.IsValid()).To(BeFalse())
+
+			condition := meta.FindStatusCondition(adapter.releaseStrategy.Status.Conditions, "Valid")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(v1alpha1.ReleaseStrategyReasonWorkspaceError.String()))
+			Expect(condition.Message).To(ContainSubstring("workspace 'bar'"))
+		})
+	})
+
+	createReleaseStrategyAndAdapter = func() *Adapter {
+		releaseStrategy := &v1alpha1.ReleaseStrategy{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "release-strategy-",
+				Namespace:    testNamespace,
+			},
+			Spec: v1alpha1.ReleaseStrategySpec{
+				Pipeline: "release-pipeline",
+				Policy:   "policy",
+			},
+		}
+		Expect(k8sClient.Create(ctx, releaseStrategy)).To(Succeed())
+		releaseStrategy.Kind = "ReleaseStrategy"
+
+		return NewAdapter(ctx, k8sClient, releaseStrategy, loader.NewMockLoader(), ctrl.Log)
+	}
+})
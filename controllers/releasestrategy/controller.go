@@ -0,0 +1,237 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasestrategy
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	ecapiv1alpha1 "github.com/hacbs-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/operator-goodies/reconciler"
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/cache"
+	"github.com/redhat-appstudio/release-service/loader"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Reconciler reconciles a ReleaseStrategy object
+type Reconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// NewReleaseStrategyReconciler creates and returns a Reconciler.
+func NewReleaseStrategyReconciler(client client.Client, logger *logr.Logger, scheme *runtime.Scheme) *Reconciler {
+	return &Reconciler{
+		Client: client,
+		Log:    logger.WithName("releaseStrategy"),
+		Scheme: scheme,
+	}
+}
+
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=releasestrategies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=releasestrategies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=clusterreleasestrategies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=enterprisecontractpolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+//+kubebuilder:rbac:groups=tekton.dev,resources=pipelines,verbs=get;list;watch
+//+kubebuilder:rbac:groups=resolution.tekton.dev,resources=resolutionrequests,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=releaseplanadmissions,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("ReleaseStrategy", req.NamespacedName)
+
+	releaseStrategy := &v1alpha1.ReleaseStrategy{}
+	err := r.Get(ctx, req.NamespacedName, releaseStrategy)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	adapter := NewAdapter(ctx, r.Client, releaseStrategy, loader.NewLoader(), logger)
+
+	return reconciler.ReconcileHandler([]reconciler.ReconcileOperation{
+		adapter.EnsureReleaseStrategyIsValidated,
+	})
+}
+
+// SetupController creates a new ReleaseStrategy reconciler and adds it to the Manager.
+func SetupController(manager ctrl.Manager, log *logr.Logger) error {
+	return setupControllerWithManager(manager, NewReleaseStrategyReconciler(manager.GetClient(), log, manager.GetScheme()))
+}
+
+// setupCache indexes fields for each of the resources used in the ReleaseStrategy adapter in those cases where
+// filtering by field is required.
+func setupCache(mgr ctrl.Manager) error {
+	if err := cache.SetupClusterReleaseStrategyCache(mgr); err != nil {
+		return err
+	}
+
+	return cache.SetupReleaseStrategyCache(mgr)
+}
+
+// setupControllerWithManager sets up the controller with the Manager which monitors ReleaseStrategies and filters out
+// status updates. This controller also watches for the ConfigMaps, Pipelines, EnterpriseContractPolicies and
+// ClusterReleaseStrategies referenced by the ReleaseStrategies, so they get validated again when those resources
+// change, and for the ResolutionRequests they own, so the validation finishes once the release Pipeline is resolved.
+func setupControllerWithManager(manager ctrl.Manager, reconciler *Reconciler) error {
+	err := setupCache(manager)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(manager).
+		For(&v1alpha1.ReleaseStrategy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}},
+			handler.EnqueueRequestsFromMapFunc(reconciler.getReleaseStrategiesReferencingConfigMap)).
+		Watches(&source.Kind{Type: &tektonv1beta1.Pipeline{}},
+			handler.EnqueueRequestsFromMapFunc(reconciler.getReleaseStrategiesReferencingPipeline)).
+		Watches(&source.Kind{Type: &ecapiv1alpha1.EnterpriseContractPolicy{}},
+			handler.EnqueueRequestsFromMapFunc(reconciler.getReleaseStrategiesReferencingPolicy)).
+		Watches(&source.Kind{Type: &v1alpha1.ClusterReleaseStrategy{}},
+			handler.EnqueueRequestsFromMapFunc(reconciler.getReleaseStrategiesInheritingFrom),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&resolutionv1beta1.ResolutionRequest{}).
+		Complete(reconciler)
+}
+
+// getReleaseStrategiesReferencingConfigMap returns a reconcile request for each of the ReleaseStrategies in the
+// namespace of the given ConfigMap referencing it in their params.
+func (r *Reconciler) getReleaseStrategiesReferencingConfigMap(object client.Object) []reconcile.Request {
+	releaseStrategies := &v1alpha1.ReleaseStrategyList{}
+	err := r.List(context.Background(), releaseStrategies,
+		client.InNamespace(object.GetNamespace()),
+		client.MatchingFields{"spec.params.valueFrom.configMapKeyRef.name": object.GetName()})
+	if err != nil {
+		r.Log.Error(err, "Unable to list ReleaseStrategies referencing ConfigMap",
+			"ConfigMap.Name", object.GetName(), "ConfigMap.Namespace", object.GetNamespace())
+		return nil
+	}
+
+	return getReconcileRequests(releaseStrategies.Items)
+}
+
+// getReleaseStrategiesReferencingPipeline returns a reconcile request for each of the ReleaseStrategies in the
+// namespace of the given Pipeline referencing it, either directly or through the ClusterReleaseStrategy they inherit
+// from.
+func (r *Reconciler) getReleaseStrategiesReferencingPipeline(object client.Object) []reconcile.Request {
+	releaseStrategies, err := r.listReleaseStrategiesReferencing(object.GetNamespace(), "spec.pipeline", object.GetName())
+	if err != nil {
+		r.Log.Error(err, "Unable to list ReleaseStrategies referencing Pipeline",
+			"Pipeline.Name", object.GetName(), "Pipeline.Namespace", object.GetNamespace())
+		return nil
+	}
+
+	return getReconcileRequests(releaseStrategies)
+}
+
+// getReleaseStrategiesReferencingPolicy returns a reconcile request for each of the ReleaseStrategies in the
+// namespace of the given EnterpriseContractPolicy referencing it, either directly or through the
+// ClusterReleaseStrategy they inherit from.
+func (r *Reconciler) getReleaseStrategiesReferencingPolicy(object client.Object) []reconcile.Request {
+	releaseStrategies, err := r.listReleaseStrategiesReferencing(object.GetNamespace(), "spec.policy", object.GetName())
+	if err != nil {
+		r.Log.Error(err, "Unable to list ReleaseStrategies referencing EnterpriseContractPolicy",
+			"EnterpriseContractPolicy.Name", object.GetName(), "EnterpriseContractPolicy.Namespace", object.GetNamespace())
+		return nil
+	}
+
+	return getReconcileRequests(releaseStrategies)
+}
+
+// getReleaseStrategiesInheritingFrom returns a reconcile request for each of the ReleaseStrategies inheriting from
+// the given ClusterReleaseStrategy.
+func (r *Reconciler) getReleaseStrategiesInheritingFrom(object client.Object) []reconcile.Request {
+	releaseStrategies := &v1alpha1.ReleaseStrategyList{}
+	err := r.List(context.Background(), releaseStrategies,
+		client.MatchingFields{"spec.clusterReleaseStrategy": object.GetName()})
+	if err != nil {
+		r.Log.Error(err, "Unable to list ReleaseStrategies inheriting from ClusterReleaseStrategy",
+			"ClusterReleaseStrategy.Name", object.GetName())
+		return nil
+	}
+
+	return getReconcileRequests(releaseStrategies.Items)
+}
+
+// listReleaseStrategiesReferencing returns the ReleaseStrategies in the given namespace whose indexed field matches
+// the given value, along with the ones in the namespace inheriting from a ClusterReleaseStrategy whose indexed field
+// matches it, as resources referenced by ClusterReleaseStrategies are looked up in the namespace of the
+// ReleaseStrategy.
+func (r *Reconciler) listReleaseStrategiesReferencing(namespace, field, value string) ([]v1alpha1.ReleaseStrategy, error) {
+	releaseStrategies := &v1alpha1.ReleaseStrategyList{}
+	err := r.List(context.Background(), releaseStrategies,
+		client.InNamespace(namespace),
+		client.MatchingFields{field: value})
+	if err != nil {
+		return nil, err
+	}
+
+	clusterReleaseStrategies := &v1alpha1.ClusterReleaseStrategyList{}
+	err = r.List(context.Background(), clusterReleaseStrategies, client.MatchingFields{field: value})
+	if err != nil {
+		return nil, err
+	}
+
+	items := releaseStrategies.Items
+	for _, clusterReleaseStrategy := range clusterReleaseStrategies.Items {
+		inheritingReleaseStrategies := &v1alpha1.ReleaseStrategyList{}
+		err = r.List(context.Background(), inheritingReleaseStrategies,
+			client.InNamespace(namespace),
+			client.MatchingFields{"spec.clusterReleaseStrategy": clusterReleaseStrategy.Name})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, inheritingReleaseStrategies.Items...)
+	}
+
+	return items, nil
+}
+
+// getReconcileRequests returns a reconcile request for each of the given ReleaseStrategies.
+func getReconcileRequests(releaseStrategies []v1alpha1.ReleaseStrategy) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, releaseStrategy := range releaseStrategies {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      releaseStrategy.Name,
+				Namespace: releaseStrategy.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasestrategy
+
+import (
+	"context"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	ecapiv1alpha1 "github.com/hacbs-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("ReleaseStrategy Controller", Ordered, func() {
+
+	Context("When NewReleaseStrategyReconciler is called", func() {
+		It("creates and return a new Reconciler", func() {
+			Expect(reflect.TypeOf(NewReleaseStrategyReconciler(k8sClient, &ctrl.Log, scheme.Scheme))).To(Equal(reflect.TypeOf(&Reconciler{})))
+		})
+	})
+
+	// For the Reconcile function test we don't want to make a successful call as it will call every single operation
+	// defined there. We don't have any control over the operations being executed, and we want to keep a clean env for
+	// the adapter tests.
+	Context("When Reconcile is called", func() {
+		It("should succeed even if the release strategy is not found", func() {
+			reconciler := NewReleaseStrategyReconciler(k8sClient, &ctrl.Log, scheme.Scheme)
+			req := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "non-existent",
+					Namespace: "default",
+				},
+			}
+			result, err := reconciler.Reconcile(ctx, req)
+			Expect(reflect.TypeOf(result)).To(Equal(reflect.TypeOf(reconcile.Result{})))
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Context("When SetupController is called", func() {
+		It("should setup the controller successfully", func() {
+			manager, _ := ctrl.NewManager(cfg, ctrl.Options{
+				Scheme:             scheme.Scheme,
+				MetricsBindAddress: "0", // disable metrics
+				LeaderElection:     false,
+			})
+			Expect(SetupController(manager, &ctrl.Log)).To(Succeed())
+		})
+	})
+
+	Context("When setupCache is called", func() {
+		It("should setup the cache successfully", func() {
+			manager, _ := ctrl.NewManager(cfg, ctrl.Options{
+				Scheme:             scheme.Scheme,
+				MetricsBindAddress: "0", // disable metrics
+				LeaderElection:     false,
+			})
+			Expect(setupCache(manager)).To(Succeed())
+		})
+	})
+
+	Context("When setupControllerWithManager is called", func() {
+		It("should setup the controller successfully", func() {
+			reconciler := NewReleaseStrategyReconciler(k8sClient, &ctrl.Log, scheme.Scheme)
+			manager, _ := ctrl.NewManager(cfg, ctrl.Options{
+				Scheme:             scheme.Scheme,
+				MetricsBindAddress: "0", // disable metrics
+				LeaderElection:     false,
+			})
+			Expect(setupControllerWithManager(manager, reconciler)).To(Succeed())
+		})
+	})
+
+	Context("When the resources referenced by a ReleaseStrategy change", func() {
+		var (
+			cancelManager          context.CancelFunc
+			clusterReleaseStrategy *v1alpha1.ClusterReleaseStrategy
+			directReleaseStrategy  *v1alpha1.ReleaseStrategy
+			inheritingStrategy     *v1alpha1.ReleaseStrategy
+			reconciler             *Reconciler
+		)
+
+		BeforeAll(func() {
+			manager, err := ctrl.NewManager(cfg, ctrl.Options{
+				Scheme:             scheme.Scheme,
+				MetricsBindAddress: "0", // disable metrics
+				LeaderElection:     false,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupCache(manager)).To(Succeed())
+
+			var managerCtx context.Context
+			managerCtx, cancelManager = context.WithCancel(ctx)
+			go func() {
+				defer GinkgoRecover()
+				Expect(manager.Start(managerCtx)).To(Succeed())
+			}()
+			Expect(manager.GetCache().WaitForCacheSync(managerCtx)).To(BeTrue())
+
+			reconciler = NewReleaseStrategyReconciler(manager.GetClient(), &ctrl.Log, scheme.Scheme)
+
+			clusterReleaseStrategy = &v1alpha1.ClusterReleaseStrategy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "watched-cluster-release-strategy",
+				},
+				Spec: v1alpha1.ReleaseStrategySpec{
+					Pipeline: "cluster-pipeline",
+					Policy:   "cluster-policy",
+				},
+			}
+			Expect(k8sClient.Create(ctx, clusterReleaseStrategy)).To(Succeed())
+
+			directReleaseStrategy = &v1alpha1.ReleaseStrategy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "direct-release-strategy",
+					Namespace: testNamespace,
+				},
+				Spec: v1alpha1.ReleaseStrategySpec{
+					Pipeline: "release-pipeline",
+					Policy:   "policy",
+				},
+			}
+			Expect(k8sClient.Create(ctx, directReleaseStrategy)).To(Succeed())
+
+			inheritingStrategy = &v1alpha1.ReleaseStrategy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "inheriting-release-strategy",
+					Namespace: testNamespace,
+				},
+				Spec: v1alpha1.ReleaseStrategySpec{
+					ClusterReleaseStrategy: clusterReleaseStrategy.Name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, inheritingStrategy)).To(Succeed())
+		})
+
+		AfterAll(func() {
+			_ = k8sClient.Delete(ctx, inheritingStrategy)
+			_ = k8sClient.Delete(ctx, directReleaseStrategy)
+			_ = k8sClient.Delete(ctx, clusterReleaseStrategy)
+			cancelManager()
+		})
+
+		requestFor := func(releaseStrategy *v1alpha1.ReleaseStrategy) reconcile.Request {
+			return reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      releaseStrategy.Name,
+					Namespace: releaseStrategy.Namespace,
+				},
+			}
+		}
+
+		It("should enqueue the ReleaseStrategies referencing a Pipeline", func() {
+			pipeline := &tektonv1beta1.Pipeline{
+				ObjectMeta: metav1.ObjectMeta{Name: "release-pipeline", Namespace: testNamespace},
+			}
+			Eventually(func() []reconcile.Request {
+				return reconciler.getReleaseStrategiesReferencingPipeline(pipeline)
+			}).Should(ConsistOf(requestFor(directReleaseStrategy)))
+		})
+
+		It("should enqueue the ReleaseStrategies inheriting a Pipeline from a ClusterReleaseStrategy", func() {
+			pipeline := &tektonv1beta1.Pipeline{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-pipeline", Namespace: testNamespace},
+			}
+			Eventually(func() []reconcile.Request {
+				return reconciler.getReleaseStrategiesReferencingPipeline(pipeline)
+			}).Should(ConsistOf(requestFor(inheritingStrategy)))
+		})
+
+		It("should enqueue the ReleaseStrategies referencing an EnterpriseContractPolicy", func() {
+			policy := &ecapiv1alpha1.EnterpriseContractPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: testNamespace},
+			}
+			Eventually(func() []reconcile.Request {
+				return reconciler.getReleaseStrategiesReferencingPolicy(policy)
+			}).Should(ConsistOf(requestFor(directReleaseStrategy)))
+
+			policy.Name = "cluster-policy"
+			Eventually(func() []reconcile.Request {
+				return reconciler.getReleaseStrategiesReferencingPolicy(policy)
+			}).Should(ConsistOf(requestFor(inheritingStrategy)))
+		})
+
+		It("should not enqueue anything for resources in other namespaces", func() {
+			pipeline := &tektonv1beta1.Pipeline{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-pipeline", Namespace: "other"},
+			}
+			Consistently(func() []reconcile.Request {
+				return reconciler.getReleaseStrategiesReferencingPipeline(pipeline)
+			}).Should(BeEmpty())
+		})
+
+		It("should enqueue the ReleaseStrategies inheriting from a ClusterReleaseStrategy", func() {
+			Eventually(func() []reconcile.Request {
+				return reconciler.getReleaseStrategiesInheritingFrom(clusterReleaseStrategy)
+			}).Should(ConsistOf(requestFor(inheritingStrategy)))
+		})
+	})
+
+})
@@ -17,13 +17,17 @@ limitations under the License.
 package gitops
 
 import (
+	"fmt"
+	"math"
+
 	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"math"
 )
 
-// NewSnapshotEnvironmentBinding creates a new SnapshotEnvironmentBinding.
-func NewSnapshotEnvironmentBinding(components []applicationapiv1alpha1.Component, snapshot *applicationapiv1alpha1.Snapshot, environment *applicationapiv1alpha1.Environment) *applicationapiv1alpha1.SnapshotEnvironmentBinding {
+// NewSnapshotEnvironmentBinding creates a new SnapshotEnvironmentBinding. The configuration of the components will be
+// overridden by the component configurations set in the given ReleasePlanAdmission.
+func NewSnapshotEnvironmentBinding(components []applicationapiv1alpha1.Component, snapshot *applicationapiv1alpha1.Snapshot, environment *applicationapiv1alpha1.Environment, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) *applicationapiv1alpha1.SnapshotEnvironmentBinding {
 	return &applicationapiv1alpha1.SnapshotEnvironmentBinding{
 		ObjectMeta: v1.ObjectMeta{
 			GenerateName: environment.Name + "-",
@@ -33,23 +37,138 @@ func NewSnapshotEnvironmentBinding(components []applicationapiv1alpha1.Component
 			Application: snapshot.Spec.Application,
 			Environment: environment.Name,
 			Snapshot:    snapshot.Name,
-			Components:  getComponentBindings(components),
+			Components:  getComponentBindings(components, releasePlanAdmission),
 		},
 	}
 }
 
+// ValidateComponentConfigurations checks that all the component configurations set in the given ReleasePlanAdmission
+// reference one of the given Components. An error will be returned otherwise.
+func ValidateComponentConfigurations(components []applicationapiv1alpha1.Component, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) error {
+	names := map[string]bool{}
+	for _, component := range components {
+		names[component.Name] = true
+	}
+
+	for _, componentConfiguration := range releasePlanAdmission.Spec.ComponentConfigurations {
+		if !names[componentConfiguration.Name] {
+			return fmt.Errorf("the ReleasePlanAdmission %s sets the configuration of the component '%s', which "+
+				"is not part of the application", releasePlanAdmission.Name, componentConfiguration.Name)
+		}
+	}
+
+	return nil
+}
+
+// ValidateSnapshotComponents checks that all the components contained in the given Snapshot are part of the given
+// Components of the application. An error will be returned otherwise.
+func ValidateSnapshotComponents(components []applicationapiv1alpha1.Component, snapshot *applicationapiv1alpha1.Snapshot) error {
+	names := map[string]bool{}
+	for _, component := range components {
+		names[component.Name] = true
+	}
+
+	for _, snapshotComponent := range snapshot.Spec.Components {
+		if !names[snapshotComponent.Name] {
+			return fmt.Errorf("the Snapshot %s contains the component '%s', which is not part of the application",
+				snapshot.Name, snapshotComponent.Name)
+		}
+	}
+
+	return nil
+}
+
+// GetSnapshotComponents splits the given Components depending on whether they are contained in the given Snapshot.
+// The Components contained in the Snapshot are returned along with the names of the ones missing from it.
+func GetSnapshotComponents(components []applicationapiv1alpha1.Component, snapshot *applicationapiv1alpha1.Snapshot) ([]applicationapiv1alpha1.Component, []string) {
+	names := map[string]bool{}
+	for _, snapshotComponent := range snapshot.Spec.Components {
+		names[snapshotComponent.Name] = true
+	}
+
+	var snapshotComponents []applicationapiv1alpha1.Component
+	var missingComponents []string
+	for _, component := range components {
+		if names[component.Name] {
+			snapshotComponents = append(snapshotComponents, component)
+		} else {
+			missingComponents = append(missingComponents, component.Name)
+		}
+	}
+
+	return snapshotComponents, missingComponents
+}
+
+// GetBoundComponents returns the BindingComponents of the given SnapshotEnvironmentBinding matching any of the given
+// component names.
+func GetBoundComponents(binding *applicationapiv1alpha1.SnapshotEnvironmentBinding, names []string) []applicationapiv1alpha1.BindingComponent {
+	var bindingComponents []applicationapiv1alpha1.BindingComponent
+
+	for _, bindingComponent := range binding.Spec.Components {
+		for _, name := range names {
+			if bindingComponent.Name == name {
+				bindingComponents = append(bindingComponents, bindingComponent)
+				break
+			}
+		}
+	}
+
+	return bindingComponents
+}
+
 // getComponentBindings returns a list of BindingComponents created by using the information of the given Components.
-func getComponentBindings(components []applicationapiv1alpha1.Component) []applicationapiv1alpha1.BindingComponent {
+// The default component configuration of the ReleasePlanAdmission is applied first and the configuration specific to
+// each component next.
+func getComponentBindings(components []applicationapiv1alpha1.Component, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) []applicationapiv1alpha1.BindingComponent {
 	var bindingComponents []applicationapiv1alpha1.BindingComponent
 
 	for _, component := range components {
+		configuration := applicationapiv1alpha1.BindingComponentConfiguration{
+			Replicas: int(math.Max(1, float64(component.Spec.Replicas))),
+		}
+
+		if releasePlanAdmission != nil {
+			mergeComponentConfiguration(&configuration, releasePlanAdmission.Spec.DefaultComponentConfiguration)
+			mergeComponentConfiguration(&configuration, releasePlanAdmission.GetComponentConfiguration(component.Name))
+		}
+
 		bindingComponents = append(bindingComponents, applicationapiv1alpha1.BindingComponent{
-			Name: component.Name,
-			Configuration: applicationapiv1alpha1.BindingComponentConfiguration{
-				Replicas: int(math.Max(1, float64(component.Spec.Replicas))),
-			},
+			Name:          component.Name,
+			Configuration: configuration,
 		})
 	}
 
 	return bindingComponents
 }
+
+// mergeComponentConfiguration merges the given override into the given configuration. Replicas are only overridden
+// when set to a value greater than zero, the resources are replaced as a whole and the environment variables are
+// merged by name.
+func mergeComponentConfiguration(configuration, override *applicationapiv1alpha1.BindingComponentConfiguration) {
+	if override == nil {
+		return
+	}
+
+	if override.Replicas > 0 {
+		configuration.Replicas = override.Replicas
+	}
+
+	if override.Resources != nil {
+		configuration.Resources = override.Resources.DeepCopy()
+	}
+
+	for _, envVar := range override.Env {
+		found := false
+		for i := range configuration.Env {
+			if configuration.Env[i].Name == envVar.Name {
+				configuration.Env[i].Value = envVar.Value
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			configuration.Env = append(configuration.Env, envVar)
+		}
+	}
+}
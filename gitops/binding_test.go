@@ -21,6 +21,9 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"math"
 	"reflect"
@@ -29,6 +32,9 @@ import (
 var _ = Describe("Binding", func() {
 	components := []applicationapiv1alpha1.Component{
 		{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "foo",
+			},
 			Spec: applicationapiv1alpha1.ComponentSpec{
 				Application:   "app",
 				ComponentName: "foo",
@@ -42,6 +48,9 @@ var _ = Describe("Binding", func() {
 			},
 		},
 		{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "bar",
+			},
 			Spec: applicationapiv1alpha1.ComponentSpec{
 				Application:   "app",
 				ComponentName: "bar",
@@ -69,6 +78,21 @@ var _ = Describe("Binding", func() {
 		},
 	}
 
+	releasePlanAdmission := &v1alpha1.ReleasePlanAdmission{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "rpa",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ReleasePlanAdmissionSpec{
+			ComponentConfigurations: []v1alpha1.ComponentConfiguration{
+				{
+					Name:          "foo",
+					Configuration: applicationapiv1alpha1.BindingComponentConfiguration{Replicas: 2},
+				},
+			},
+		},
+	}
+
 	snapshot := &applicationapiv1alpha1.Snapshot{
 		ObjectMeta: v1.ObjectMeta{
 			GenerateName: "snapshot-",
@@ -81,7 +105,7 @@ var _ = Describe("Binding", func() {
 	}
 
 	Context("When calling getComponentBindings with a list of Components", func() {
-		bindingComponents := getComponentBindings(components)
+		bindingComponents := getComponentBindings(components, nil)
 
 		It("can create and return a new BindingComponent slice", func() {
 			Expect(reflect.TypeOf(bindingComponents)).To(Equal(reflect.TypeOf([]applicationapiv1alpha1.BindingComponent{})))
@@ -97,9 +121,124 @@ var _ = Describe("Binding", func() {
 		})
 	})
 
+	Context("When calling getComponentBindings with component configurations", func() {
+		It("overrides the configuration of all the components with the default one", func() {
+			bindingComponents := getComponentBindings(components, &v1alpha1.ReleasePlanAdmission{
+				Spec: v1alpha1.ReleasePlanAdmissionSpec{
+					DefaultComponentConfiguration: &applicationapiv1alpha1.BindingComponentConfiguration{
+						Replicas: 3,
+						Env:      []applicationapiv1alpha1.EnvVarPair{{Name: "ENV", Value: "production"}},
+					},
+				},
+			})
+			for _, bindingComponent := range bindingComponents {
+				Expect(bindingComponent.Configuration.Replicas).To(Equal(3))
+				Expect(bindingComponent.Configuration.Env).To(Equal([]applicationapiv1alpha1.EnvVarPair{
+					{Name: "ENV", Value: "production"},
+				}))
+			}
+		})
+
+		It("gives precedence to the configuration of each component over the default one", func() {
+			resources := &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			}
+			bindingComponents := getComponentBindings(components, &v1alpha1.ReleasePlanAdmission{
+				Spec: v1alpha1.ReleasePlanAdmissionSpec{
+					DefaultComponentConfiguration: &applicationapiv1alpha1.BindingComponentConfiguration{
+						Replicas: 3,
+						Env: []applicationapiv1alpha1.EnvVarPair{
+							{Name: "ENV", Value: "production"},
+							{Name: "LOG_LEVEL", Value: "info"},
+						},
+					},
+					ComponentConfigurations: []v1alpha1.ComponentConfiguration{
+						{
+							Name: "bar",
+							Configuration: applicationapiv1alpha1.BindingComponentConfiguration{
+								Resources: resources,
+								Env:       []applicationapiv1alpha1.EnvVarPair{{Name: "LOG_LEVEL", Value: "debug"}},
+							},
+						},
+					},
+				},
+			})
+			Expect(bindingComponents[0].Configuration.Resources).To(BeNil())
+			Expect(bindingComponents[1].Configuration.Replicas).To(Equal(3))
+			Expect(bindingComponents[1].Configuration.Resources).To(Equal(resources))
+			Expect(bindingComponents[1].Configuration.Env).To(Equal([]applicationapiv1alpha1.EnvVarPair{
+				{Name: "ENV", Value: "production"},
+				{Name: "LOG_LEVEL", Value: "debug"},
+			}))
+		})
+	})
+
+	Context("When calling ValidateComponentConfigurations", func() {
+		It("succeeds when all the configured components are part of the application", func() {
+			Expect(ValidateComponentConfigurations(components, releasePlanAdmission)).To(Succeed())
+		})
+
+		It("fails when a configured component is not part of the application", func() {
+			err := ValidateComponentConfigurations(components, &v1alpha1.ReleasePlanAdmission{
+				ObjectMeta: v1.ObjectMeta{Name: "rpa"},
+				Spec: v1alpha1.ReleasePlanAdmissionSpec{
+					ComponentConfigurations: []v1alpha1.ComponentConfiguration{{Name: "baz"}},
+				},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'baz', which is not part of the application"))
+		})
+	})
+
+	Context("When calling ValidateSnapshotComponents", func() {
+		It("succeeds when all the components of the Snapshot are part of the application", func() {
+			Expect(ValidateSnapshotComponents(components, &applicationapiv1alpha1.Snapshot{
+				Spec: applicationapiv1alpha1.SnapshotSpec{
+					Components: []applicationapiv1alpha1.SnapshotComponent{{Name: "foo"}},
+				},
+			})).To(Succeed())
+		})
+
+		It("fails when a component of the Snapshot is not part of the application", func() {
+			err := ValidateSnapshotComponents(components, &applicationapiv1alpha1.Snapshot{
+				Spec: applicationapiv1alpha1.SnapshotSpec{
+					Components: []applicationapiv1alpha1.SnapshotComponent{{Name: "baz"}},
+				},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'baz', which is not part of the application"))
+		})
+	})
+
+	Context("When calling GetSnapshotComponents", func() {
+		It("returns the components contained in the Snapshot and the names of the missing ones", func() {
+			snapshotComponents, missingComponents := GetSnapshotComponents(components, &applicationapiv1alpha1.Snapshot{
+				Spec: applicationapiv1alpha1.SnapshotSpec{
+					Components: []applicationapiv1alpha1.SnapshotComponent{{Name: "bar"}},
+				},
+			})
+			Expect(snapshotComponents).To(HaveLen(1))
+			Expect(snapshotComponents[0].Name).To(Equal("bar"))
+			Expect(missingComponents).To(Equal([]string{"foo"}))
+		})
+	})
+
+	Context("When calling GetBoundComponents", func() {
+		It("returns the bound components matching the given names", func() {
+			binding := &applicationapiv1alpha1.SnapshotEnvironmentBinding{
+				Spec: applicationapiv1alpha1.SnapshotEnvironmentBindingSpec{
+					Components: []applicationapiv1alpha1.BindingComponent{{Name: "foo"}, {Name: "bar"}},
+				},
+			}
+			Expect(GetBoundComponents(binding, []string{"bar", "baz"})).To(Equal([]applicationapiv1alpha1.BindingComponent{
+				{Name: "bar"},
+			}))
+		})
+	})
+
 	Context("When calling NewSnapshotEnvironmentBinding", func() {
 		It("can create and return a new SnapshotEnvironmentBinding", func() {
-			binding := NewSnapshotEnvironmentBinding(components, snapshot, environment)
+			binding := NewSnapshotEnvironmentBinding(components, snapshot, environment, releasePlanAdmission)
 			Expect(reflect.TypeOf(binding)).To(Equal(reflect.TypeOf(&applicationapiv1alpha1.SnapshotEnvironmentBinding{})))
 			Expect(*binding).To(MatchFields(IgnoreExtras, Fields{
 				"ObjectMeta": MatchFields(IgnoreExtras, Fields{
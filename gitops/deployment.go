@@ -0,0 +1,86 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitops
+
+import (
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GitOpsDeploymentGroupVersionKind is the GroupVersionKind of the GitOpsDeployments created by the GitOps service to
+// deploy the components of a SnapshotEnvironmentBinding. They are handled as unstructured objects, so the GitOps
+// service API is not required to read their status.
+var GitOpsDeploymentGroupVersionKind = schema.GroupVersionKind{
+	Group:   "managed-gitops.redhat.com",
+	Version: "v1alpha1",
+	Kind:    "GitOpsDeployment",
+}
+
+// NewGitOpsDeployment creates an empty unstructured GitOpsDeployment.
+func NewGitOpsDeployment() *unstructured.Unstructured {
+	gitOpsDeployment := &unstructured.Unstructured{}
+	gitOpsDeployment.SetGroupVersionKind(GitOpsDeploymentGroupVersionKind)
+
+	return gitOpsDeployment
+}
+
+// GetComponentDeploymentStatuses returns the deployment status of each of the components of the given
+// SnapshotEnvironmentBinding. The container images are taken from the given Snapshot, the GitOps repository commits
+// from the binding status and the health and sync status from the given GitOpsDeployments.
+func GetComponentDeploymentStatuses(binding *applicationapiv1alpha1.SnapshotEnvironmentBinding,
+	snapshot *applicationapiv1alpha1.Snapshot, gitOpsDeployments []unstructured.Unstructured) []v1alpha2.ComponentDeploymentStatus {
+	var statuses []v1alpha2.ComponentDeploymentStatus
+
+	for _, component := range binding.Spec.Components {
+		status := v1alpha2.ComponentDeploymentStatus{
+			Name: component.Name,
+		}
+
+		if snapshot != nil {
+			for _, snapshotComponent := range snapshot.Spec.Components {
+				if snapshotComponent.Name == component.Name {
+					status.ContainerImage = snapshotComponent.ContainerImage
+				}
+			}
+		}
+
+		for _, componentStatus := range binding.Status.Components {
+			if componentStatus.Name == component.Name {
+				status.CommitID = componentStatus.GitOpsRepository.CommitID
+			}
+		}
+
+		for _, bindingGitOpsDeployment := range binding.Status.GitOpsDeployments {
+			if bindingGitOpsDeployment.ComponentName == component.Name {
+				status.GitOpsDeployment = bindingGitOpsDeployment.GitOpsDeployment
+			}
+		}
+
+		for i := range gitOpsDeployments {
+			if status.GitOpsDeployment != "" && gitOpsDeployments[i].GetName() == status.GitOpsDeployment {
+				status.HealthStatus, _, _ = unstructured.NestedString(gitOpsDeployments[i].Object, "status", "health", "status")
+				status.SyncStatus, _, _ = unstructured.NestedString(gitOpsDeployments[i].Object, "status", "sync", "status")
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
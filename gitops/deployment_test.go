@@ -0,0 +1,100 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitops
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Deployment", func() {
+
+	Context("When NewGitOpsDeployment is called", func() {
+		It("should return an unstructured object with the GitOpsDeployment GroupVersionKind", func() {
+			Expect(NewGitOpsDeployment().GroupVersionKind()).To(Equal(GitOpsDeploymentGroupVersionKind))
+		})
+	})
+
+	Context("When GetComponentDeploymentStatuses is called", func() {
+		var binding *applicationapiv1alpha1.SnapshotEnvironmentBinding
+		var snapshot *applicationapiv1alpha1.Snapshot
+
+		BeforeEach(func() {
+			binding = &applicationapiv1alpha1.SnapshotEnvironmentBinding{
+				Spec: applicationapiv1alpha1.SnapshotEnvironmentBindingSpec{
+					Components: []applicationapiv1alpha1.BindingComponent{{Name: "foo"}, {Name: "bar"}},
+				},
+				Status: applicationapiv1alpha1.SnapshotEnvironmentBindingStatus{
+					Components: []applicationapiv1alpha1.BindingComponentStatus{
+						{
+							Name: "foo",
+							GitOpsRepository: applicationapiv1alpha1.BindingComponentGitOpsRepository{
+								CommitID: "abc123",
+							},
+						},
+					},
+					GitOpsDeployments: []applicationapiv1alpha1.BindingStatusGitOpsDeployment{
+						{ComponentName: "foo", GitOpsDeployment: "foo-deployment"},
+					},
+				},
+			}
+			snapshot = &applicationapiv1alpha1.Snapshot{
+				Spec: applicationapiv1alpha1.SnapshotSpec{
+					Components: []applicationapiv1alpha1.SnapshotComponent{
+						{Name: "foo", ContainerImage: "quay.io/foo:1"},
+						{Name: "bar", ContainerImage: "quay.io/bar:1"},
+					},
+				},
+			}
+		})
+
+		It("should return the status of every component in the binding", func() {
+			gitOpsDeployment := NewGitOpsDeployment()
+			gitOpsDeployment.SetName("foo-deployment")
+			Expect(unstructured.SetNestedField(gitOpsDeployment.Object, "Degraded", "status", "health", "status")).To(Succeed())
+			Expect(unstructured.SetNestedField(gitOpsDeployment.Object, "Synced", "status", "sync", "status")).To(Succeed())
+
+			statuses := GetComponentDeploymentStatuses(binding, snapshot, []unstructured.Unstructured{*gitOpsDeployment})
+			Expect(statuses).To(Equal([]v1alpha2.ComponentDeploymentStatus{
+				{
+					Name:             "foo",
+					ContainerImage:   "quay.io/foo:1",
+					GitOpsDeployment: "foo-deployment",
+					CommitID:         "abc123",
+					HealthStatus:     "Degraded",
+					SyncStatus:       "Synced",
+				},
+				{
+					Name:           "bar",
+					ContainerImage: "quay.io/bar:1",
+				},
+			}))
+		})
+
+		It("should leave the health and sync status empty if the GitOpsDeployment is not found", func() {
+			statuses := GetComponentDeploymentStatuses(binding, nil, nil)
+			Expect(statuses).To(HaveLen(2))
+			Expect(statuses[0].GitOpsDeployment).To(Equal("foo-deployment"))
+			Expect(statuses[0].ContainerImage).To(BeEmpty())
+			Expect(statuses[0].HealthStatus).To(BeEmpty())
+		})
+	})
+})
@@ -22,7 +22,8 @@ import (
 )
 
 // DeploymentFinishedPredicate returns a predicate which filters out update events to a
-// SnapshotEnvironmentBinding where the component deployment status goes from unknown to true/false.
+// SnapshotEnvironmentBinding where the component deployment status goes from unknown to true/false or where
+// the binding starts reporting a deployment failure.
 func DeploymentFinishedPredicate() predicate.Predicate {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -17,6 +17,8 @@ limitations under the License.
 package gitops
 
 import (
+	"fmt"
+
 	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,6 +46,44 @@ func hasDeploymentFinished(objectOld, objectNew client.Object) bool {
 	newCondition = meta.FindStatusCondition(newBinding.Status.ComponentDeploymentConditions,
 		applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed)
 
-	return (oldCondition == nil || oldCondition.Status != metav1.ConditionTrue) &&
-		(newCondition != nil && newCondition.Status != metav1.ConditionUnknown)
+	if (oldCondition == nil || oldCondition.Status != metav1.ConditionTrue) &&
+		(newCondition != nil && newCondition.Status != metav1.ConditionUnknown) {
+		return true
+	}
+
+	_, oldFailed := GetDeploymentFailure(oldBinding)
+	_, newFailed := GetDeploymentFailure(newBinding)
+
+	return !oldFailed && newFailed
+}
+
+// GetDeploymentFailure returns a message describing the error reported by the given SnapshotEnvironmentBinding if
+// its deployment failed in a way that won't be recovered from without changes to the deployment. A binding fails
+// when the GitOps repository of any of its components can't be generated, when the binding itself reports an error
+// or when the components can't be deployed due to an error. The boolean returned is false if no failure is found.
+func GetDeploymentFailure(binding *applicationapiv1alpha1.SnapshotEnvironmentBinding) (string, bool) {
+	if binding == nil {
+		return "", false
+	}
+
+	for _, condition := range binding.Status.GitOpsRepoConditions {
+		if condition.Status == metav1.ConditionFalse {
+			return fmt.Sprintf("failed to generate the GitOps repository: %s", condition.Message), true
+		}
+	}
+
+	condition := meta.FindStatusCondition(binding.Status.BindingConditions,
+		applicationapiv1alpha1.ComponentDeploymentConditionErrorOccurred)
+	if condition != nil && condition.Status == metav1.ConditionTrue {
+		return condition.Message, true
+	}
+
+	condition = meta.FindStatusCondition(binding.Status.ComponentDeploymentConditions,
+		applicationapiv1alpha1.ComponentDeploymentConditionAllComponentsDeployed)
+	if condition != nil && condition.Status == metav1.ConditionFalse &&
+		condition.Reason == applicationapiv1alpha1.ComponentDeploymentConditionErrorOccurred {
+		return condition.Message, true
+	}
+
+	return "", false
 }
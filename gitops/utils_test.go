@@ -189,4 +189,66 @@ var _ = Describe("Utils", Ordered, func() {
 			Expect(hasDeploymentFinished(bindingMissingComponentStatus, bindingTrueStatus)).To(Equal(true))
 		})
 	})
+
+	Context("when checking whether a SnapshotEnvironmentBinding deployment failed", func() {
+		var binding *applicationapiv1alpha1.SnapshotEnvironmentBinding
+
+		BeforeEach(func() {
+			binding = bindingFalseStatus.DeepCopy()
+		})
+
+		It("returns false when the binding is nil", func() {
+			_, failed := GetDeploymentFailure(nil)
+			Expect(failed).To(BeFalse())
+		})
+
+		It("returns false when the components are not deployed yet", func() {
+			_, failed := GetDeploymentFailure(binding)
+			Expect(failed).To(BeFalse())
+		})
+
+		It("returns the error when the GitOps repository can't be generated", func() {
+			binding.Status.GitOpsRepoConditions = []metav1.Condition{
+				{
+					Type:    "GitOpsResourcesGenerated",
+					Status:  metav1.ConditionFalse,
+					Reason:  "GenerateError",
+					Message: "component foo has an invalid image",
+				},
+			}
+
+			message, failed := GetDeploymentFailure(binding)
+			Expect(failed).To(BeTrue())
+			Expect(message).To(Equal("failed to generate the GitOps repository: component foo has an invalid image"))
+		})
+
+		It("returns the error when the binding reports one", func() {
+			binding.Status.BindingConditions = []metav1.Condition{
+				{
+					Type:    applicationapiv1alpha1.ComponentDeploymentConditionErrorOccurred,
+					Status:  metav1.ConditionTrue,
+					Reason:  applicationapiv1alpha1.ComponentDeploymentConditionErrorOccurred,
+					Message: "environment not found",
+				},
+			}
+
+			message, failed := GetDeploymentFailure(binding)
+			Expect(failed).To(BeTrue())
+			Expect(message).To(Equal("environment not found"))
+		})
+
+		It("returns the error when the components can't be deployed", func() {
+			binding.Status.ComponentDeploymentConditions[0].Reason = applicationapiv1alpha1.ComponentDeploymentConditionErrorOccurred
+			binding.Status.ComponentDeploymentConditions[0].Message = "component foo failed to sync"
+
+			message, failed := GetDeploymentFailure(binding)
+			Expect(failed).To(BeTrue())
+			Expect(message).To(Equal("component foo failed to sync"))
+		})
+
+		It("considers the deployment finished when the binding starts reporting a failure", func() {
+			binding.Status.ComponentDeploymentConditions[0].Reason = applicationapiv1alpha1.ComponentDeploymentConditionErrorOccurred
+			Expect(hasDeploymentFinished(bindingFalseStatus, binding)).To(BeTrue())
+		})
+	})
 })
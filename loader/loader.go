@@ -2,32 +2,55 @@ package loader
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	ecapiv1alpha1 "github.com/hacbs-contract/enterprise-contract-controller/api/v1alpha1"
 	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
 	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+	"github.com/redhat-appstudio/release-service/gitops"
 	"github.com/redhat-appstudio/release-service/tekton"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"strings"
+)
+
+var (
+	// ErrMultipleReleasePlanAdmissions is returned when several enabled ReleasePlanAdmissions share the highest
+	// priority among the ones admitting releases of an application from an origin
+	ErrMultipleReleasePlanAdmissions = goerrors.New("multiple ReleasePlanAdmissions found")
+
+	// ErrReleasePlanAdmissionDisabled is returned when the only ReleasePlanAdmissions admitting releases of an
+	// application from an origin have the auto-release label set to false
+	ErrReleasePlanAdmissionDisabled = goerrors.New("auto-release label set to false")
 )
 
 type ObjectLoader interface {
 	GetActiveReleasePlanAdmission(ctx context.Context, cli client.Client, releasePlan *v1alpha1.ReleasePlan) (*v1alpha1.ReleasePlanAdmission, error)
-	GetActiveReleasePlanAdmissionFromRelease(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*v1alpha1.ReleasePlanAdmission, error)
-	GetApplication(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*applicationapiv1alpha1.Application, error)
+	GetActiveReleasePlanAdmissionFromRelease(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*v1alpha1.ReleasePlanAdmission, error)
+	GetApplication(ctx context.Context, cli client.Client, name, namespace string) (*applicationapiv1alpha1.Application, error)
 	GetApplicationComponents(ctx context.Context, cli client.Client, application *applicationapiv1alpha1.Application) ([]applicationapiv1alpha1.Component, error)
+	GetClusterReleaseStrategy(ctx context.Context, cli client.Client, name string) (*v1alpha1.ClusterReleaseStrategy, error)
+	GetConflictingReleasePlanAdmissions(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) ([]v1alpha1.ReleasePlanAdmission, error)
 	GetEnterpriseContractPolicy(ctx context.Context, cli client.Client, releaseStrategy *v1alpha1.ReleaseStrategy) (*ecapiv1alpha1.EnterpriseContractPolicy, error)
-	GetEnvironment(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*applicationapiv1alpha1.Environment, error)
-	GetRelease(ctx context.Context, cli client.Client, name, namespace string) (*v1alpha1.Release, error)
-	GetReleasePipelineRun(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*v1beta1.PipelineRun, error)
-	GetReleasePlan(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*v1alpha1.ReleasePlan, error)
+	GetEnvironment(ctx context.Context, cli client.Client, name, namespace string) (*applicationapiv1alpha1.Environment, error)
+	GetGitOpsDeployments(ctx context.Context, cli client.Client, binding *applicationapiv1alpha1.SnapshotEnvironmentBinding) ([]unstructured.Unstructured, error)
+	GetRelease(ctx context.Context, cli client.Client, name, namespace string) (*v1alpha2.Release, error)
+	GetReleasePipelineRun(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*v1beta1.PipelineRun, error)
+	GetReleasePlan(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*v1alpha1.ReleasePlan, error)
 	GetReleaseStrategy(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*v1alpha1.ReleaseStrategy, error)
-	GetSnapshot(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*applicationapiv1alpha1.Snapshot, error)
-	GetSnapshotEnvironmentBinding(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error)
-	GetSnapshotEnvironmentBindingFromReleaseStatus(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error)
-	GetSnapshotEnvironmentBindingResources(ctx context.Context, cli client.Client, release *v1alpha1.Release, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*SnapshotEnvironmentBindingResources, error)
+	GetReleaseStrategyParams(ctx context.Context, cli client.Client, releaseStrategy *v1alpha1.ReleaseStrategy) ([]v1alpha1.Params, error)
+	GetReleaseStrategyPipeline(ctx context.Context, cli client.Client, releaseStrategy *v1alpha1.ReleaseStrategy) (*v1beta1.Pipeline, error)
+	GetReleaseStrategyReleasePlanAdmissions(ctx context.Context, cli client.Client, releaseStrategy *v1alpha1.ReleaseStrategy) ([]v1alpha1.ReleasePlanAdmission, error)
+	GetSnapshot(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*applicationapiv1alpha1.Snapshot, error)
+	GetSnapshotEnvironmentBinding(ctx context.Context, cli client.Client, environment *applicationapiv1alpha1.Environment, application *applicationapiv1alpha1.Application) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error)
+	GetSnapshotEnvironmentBindingFromReleaseStatus(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error)
+	GetSnapshotEnvironmentBindingResources(ctx context.Context, cli client.Client, release *v1alpha2.Release, releasePlanAdmission *v1alpha1.ReleasePlanAdmission, environmentName string) (*SnapshotEnvironmentBindingResources, error)
+	GetVerificationPipelineRun(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*v1beta1.PipelineRun, error)
 }
 
 type loader struct{}
@@ -47,36 +70,48 @@ func getObject(name, namespace string, cli client.Client, ctx context.Context, o
 
 // GetActiveReleasePlanAdmission returns the ReleasePlanAdmission targeted by the given ReleasePlan.
 // Only ReleasePlanAdmissions with the 'auto-release' label set to true (or missing the label, which is
-// treated the same as having the label and it being set to true) will be searched for. If a matching
+// treated the same as having the label and it being set to true) will be searched for. If more than one
+// matching ReleasePlanAdmission is found, the one with the highest priority will be returned. If a matching
 // ReleasePlanAdmission is not found or the List operation fails, an error will be returned. If more than
-// one matching ReleasePlanAdmission objects is found, an error will be returned.
+// one matching ReleasePlanAdmission objects share the highest priority, an error will be returned.
 func (l *loader) GetActiveReleasePlanAdmission(ctx context.Context, cli client.Client, releasePlan *v1alpha1.ReleasePlan) (*v1alpha1.ReleasePlanAdmission, error) {
-	releasePlanAdmissions := &v1alpha1.ReleasePlanAdmissionList{}
-	err := cli.List(ctx, releasePlanAdmissions,
-		client.InNamespace(releasePlan.Spec.Target),
-		client.MatchingFields{"spec.origin": releasePlan.Namespace})
+	releasePlanAdmissions, err := getOriginReleasePlanAdmissions(ctx, cli, releasePlan.Namespace, releasePlan.Spec.Target)
 	if err != nil {
 		return nil, err
 	}
 
-	var activeReleasePlanAdmission *v1alpha1.ReleasePlanAdmission
+	var activeReleasePlanAdmission, disabledReleasePlanAdmission *v1alpha1.ReleasePlanAdmission
+	tied := false
 
-	for i, releasePlanAdmission := range releasePlanAdmissions.Items {
-		if releasePlanAdmission.Spec.Application != releasePlan.Spec.Application {
+	for i, releasePlanAdmission := range releasePlanAdmissions {
+		if !releasePlanAdmission.MatchesApplication(releasePlan.Spec.Application) {
 			continue
 		}
 
-		if activeReleasePlanAdmission != nil {
-			return nil, fmt.Errorf("multiple ReleasePlanAdmissions found with the target (%+v) for application '%s'",
-				releasePlan.Spec.Target, releasePlan.Spec.Application)
+		if !releasePlanAdmission.IsEnabled() {
+			disabledReleasePlanAdmission = &releasePlanAdmissions[i]
+			continue
 		}
 
-		labelValue, found := releasePlanAdmission.GetLabels()[v1alpha1.AutoReleaseLabel]
-		if found && labelValue == "false" {
-			return nil, fmt.Errorf("found ReleasePlanAdmission '%s' with auto-release label set to false",
-				releasePlanAdmission.Name)
+		switch {
+		case activeReleasePlanAdmission == nil ||
+			releasePlanAdmission.Spec.Priority > activeReleasePlanAdmission.Spec.Priority:
+			activeReleasePlanAdmission = &releasePlanAdmissions[i]
+			tied = false
+		case releasePlanAdmission.Spec.Priority == activeReleasePlanAdmission.Spec.Priority:
+			tied = true
 		}
-		activeReleasePlanAdmission = &releasePlanAdmissions.Items[i]
+	}
+
+	if tied {
+		return nil, fmt.Errorf("%w with the target (%+v) for application '%s' and priority %d",
+			ErrMultipleReleasePlanAdmissions, releasePlan.Spec.Target, releasePlan.Spec.Application,
+			activeReleasePlanAdmission.Spec.Priority)
+	}
+
+	if activeReleasePlanAdmission == nil && disabledReleasePlanAdmission != nil {
+		return nil, fmt.Errorf("found ReleasePlanAdmission '%s' with %w", disabledReleasePlanAdmission.Name,
+			ErrReleasePlanAdmissionDisabled)
 	}
 
 	if activeReleasePlanAdmission == nil {
@@ -91,7 +126,7 @@ func (l *loader) GetActiveReleasePlanAdmission(ctx context.Context, cli client.C
 // the given Release. Only ReleasePlanAdmissions with the 'auto-release' label set to true (or missing the label, which
 // is treated the same as having the label and it being set to true) will be searched for. If a matching
 // ReleasePlanAdmission is not found or the List operation fails, an error will be returned.
-func (l *loader) GetActiveReleasePlanAdmissionFromRelease(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*v1alpha1.ReleasePlanAdmission, error) {
+func (l *loader) GetActiveReleasePlanAdmissionFromRelease(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*v1alpha1.ReleasePlanAdmission, error) {
 	releasePlan, err := l.GetReleasePlan(ctx, cli, release)
 	if err != nil {
 		return nil, err
@@ -100,11 +135,25 @@ func (l *loader) GetActiveReleasePlanAdmissionFromRelease(ctx context.Context, c
 	return l.GetActiveReleasePlanAdmission(ctx, cli, releasePlan)
 }
 
-// GetApplication returns the Application referenced by the ReleasePlanAdmission. If the Application is not found or
-// the Get operation fails, an error will be returned.
-func (l *loader) GetApplication(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*applicationapiv1alpha1.Application, error) {
+// GetActiveReleasePlanAdmissionErrorReason returns the reason to set in the status of a Release whose active
+// ReleasePlanAdmission can't be resolved due to the given error, so the release controller and the Release webhook
+// report the same reasons.
+func GetActiveReleasePlanAdmissionErrorReason(err error) v1alpha2.ReleaseReason {
+	switch {
+	case goerrors.Is(err, ErrMultipleReleasePlanAdmissions):
+		return v1alpha2.ReleaseReasonValidationError
+	case goerrors.Is(err, ErrReleasePlanAdmissionDisabled):
+		return v1alpha2.ReleaseReasonTargetDisabledError
+	default:
+		return v1alpha2.ReleaseReasonReleasePlanValidationError
+	}
+}
+
+// GetApplication returns the Application with the given name and namespace. If the Application is not found or the
+// Get operation fails, an error will be returned.
+func (l *loader) GetApplication(ctx context.Context, cli client.Client, name, namespace string) (*applicationapiv1alpha1.Application, error) {
 	application := &applicationapiv1alpha1.Application{}
-	return application, getObject(releasePlanAdmission.Spec.Application, releasePlanAdmission.Namespace, cli, ctx, application)
+	return application, getObject(name, namespace, cli, ctx, application)
 }
 
 // GetApplicationComponents returns a list of all the Components associated with the given Application.
@@ -120,6 +169,39 @@ func (l *loader) GetApplicationComponents(ctx context.Context, cli client.Client
 	return applicationComponents.Items, nil
 }
 
+// GetClusterReleaseStrategy returns the ClusterReleaseStrategy with the given name. If the ClusterReleaseStrategy is
+// not found or the Get operation fails, an error will be returned.
+func (l *loader) GetClusterReleaseStrategy(ctx context.Context, cli client.Client, name string) (*v1alpha1.ClusterReleaseStrategy, error) {
+	clusterReleaseStrategy := &v1alpha1.ClusterReleaseStrategy{}
+	return clusterReleaseStrategy, getObject(name, "", cli, ctx, clusterReleaseStrategy)
+}
+
+// GetConflictingReleasePlanAdmissions returns all the ReleasePlanAdmissions in the namespace of the given
+// ReleasePlanAdmission admitting releases of the same application from the same origin with the same priority. Only
+// the ReleasePlanAdmissions indexed by any of the origin index values of the given one are listed, so this operation
+// relies on the "spec.origin" cache index. If any of the List operations fails, an error will be returned.
+func (l *loader) GetConflictingReleasePlanAdmissions(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) ([]v1alpha1.ReleasePlanAdmission, error) {
+	var conflictingReleasePlanAdmissions []v1alpha1.ReleasePlanAdmission
+	for _, origin := range releasePlanAdmission.GetOriginIndexValues() {
+		releasePlanAdmissions := &v1alpha1.ReleasePlanAdmissionList{}
+		err := cli.List(ctx, releasePlanAdmissions,
+			client.InNamespace(releasePlanAdmission.Namespace),
+			client.MatchingFields{"spec.origin": origin})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range releasePlanAdmissions.Items {
+			if !containsReleasePlanAdmission(conflictingReleasePlanAdmissions, releasePlanAdmissions.Items[i].Name) &&
+				releasePlanAdmission.ConflictsWith(&releasePlanAdmissions.Items[i]) {
+				conflictingReleasePlanAdmissions = append(conflictingReleasePlanAdmissions, releasePlanAdmissions.Items[i])
+			}
+		}
+	}
+
+	return conflictingReleasePlanAdmissions, nil
+}
+
 // GetEnterpriseContractPolicy returns the EnterpriseContractPolicy referenced by the given ReleaseStrategy. If the
 // EnterpriseContractPolicy is not found or the Get operation fails, an error is returned.
 func (l *loader) GetEnterpriseContractPolicy(ctx context.Context, cli client.Client, releaseStrategy *v1alpha1.ReleaseStrategy) (*ecapiv1alpha1.EnterpriseContractPolicy, error) {
@@ -127,72 +209,178 @@ func (l *loader) GetEnterpriseContractPolicy(ctx context.Context, cli client.Cli
 	return enterpriseContractPolicy, getObject(releaseStrategy.Spec.Policy, releaseStrategy.Namespace, cli, ctx, enterpriseContractPolicy)
 }
 
-// GetEnvironment returns the Environment referenced by the given ReleasePlanAdmission. If the Environment is not found
-// or the Get operation fails, an error will be returned.
-func (l *loader) GetEnvironment(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*applicationapiv1alpha1.Environment, error) {
+// GetEnvironment returns the Environment with the given name and namespace. If the Environment is not found or the
+// Get operation fails, an error will be returned.
+func (l *loader) GetEnvironment(ctx context.Context, cli client.Client, name, namespace string) (*applicationapiv1alpha1.Environment, error) {
 	environment := &applicationapiv1alpha1.Environment{}
-	return environment, getObject(releasePlanAdmission.Spec.Environment, releasePlanAdmission.Namespace, cli, ctx, environment)
+	return environment, getObject(name, namespace, cli, ctx, environment)
+}
+
+// GetGitOpsDeployments returns the GitOpsDeployments listed in the status of the given SnapshotEnvironmentBinding.
+// GitOpsDeployments that are not found, either because they were not created yet or because the GitOps service is not
+// installed, are skipped. If any other Get operation fails, an error will be returned.
+func (l *loader) GetGitOpsDeployments(ctx context.Context, cli client.Client, binding *applicationapiv1alpha1.SnapshotEnvironmentBinding) ([]unstructured.Unstructured, error) {
+	var gitOpsDeployments []unstructured.Unstructured
+
+	for _, bindingGitOpsDeployment := range binding.Status.GitOpsDeployments {
+		if bindingGitOpsDeployment.GitOpsDeployment == "" {
+			continue
+		}
+
+		gitOpsDeployment := gitops.NewGitOpsDeployment()
+		err := getObject(bindingGitOpsDeployment.GitOpsDeployment, binding.Namespace, cli, ctx, gitOpsDeployment)
+		if err != nil {
+			if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		gitOpsDeployments = append(gitOpsDeployments, *gitOpsDeployment)
+	}
+
+	return gitOpsDeployments, nil
 }
 
 // GetRelease returns the Release with the given name and namespace. If the Release is not found or the Get operation
 // fails, an error will be returned.
-func (l *loader) GetRelease(ctx context.Context, cli client.Client, name, namespace string) (*v1alpha1.Release, error) {
-	release := &v1alpha1.Release{}
+func (l *loader) GetRelease(ctx context.Context, cli client.Client, name, namespace string) (*v1alpha2.Release, error) {
+	release := &v1alpha2.Release{}
 	return release, getObject(name, namespace, cli, ctx, release)
 }
 
-// GetReleasePipelineRun returns the PipelineRun referenced by the given Release or nil if it's not found. In the case
+// GetReleasePipelineRun returns the release PipelineRun referenced by the given Release or nil if it's not found. The
+// PipelineRun is listed using the Tekton API version in use and returned as a v1beta1 PipelineRun. In the case
 // the List operation fails, an error will be returned.
-func (l *loader) GetReleasePipelineRun(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*v1beta1.PipelineRun, error) {
-	pipelineRuns := &v1beta1.PipelineRunList{}
-	err := cli.List(ctx, pipelineRuns,
-		client.Limit(1),
-		client.MatchingLabels{
-			tekton.ReleaseNameLabel:      release.Name,
-			tekton.ReleaseNamespaceLabel: release.Namespace,
-		})
-	if err == nil && len(pipelineRuns.Items) > 0 {
-		return &pipelineRuns.Items[0], nil
-	}
-
-	return nil, err
+func (l *loader) GetReleasePipelineRun(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*v1beta1.PipelineRun, error) {
+	return getPipelineRun(ctx, cli, release, tekton.PipelineTypeRelease)
 }
 
 // GetReleasePlan returns the ReleasePlan referenced by the given Release. If the ReleasePlan is not found or
 // the Get operation fails, an error will be returned.
-func (l *loader) GetReleasePlan(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*v1alpha1.ReleasePlan, error) {
+func (l *loader) GetReleasePlan(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*v1alpha1.ReleasePlan, error) {
 	releasePlan := &v1alpha1.ReleasePlan{}
 	return releasePlan, getObject(release.Spec.ReleasePlan, release.Namespace, cli, ctx, releasePlan)
 }
 
-// GetReleaseStrategy returns the ReleaseStrategy referenced by the given ReleasePlanAdmission. If the ReleaseStrategy
-// is not found or the Get operation fails, an error will be returned.
+// GetReleaseStrategy returns the effective ReleaseStrategy referenced by the given ReleasePlanAdmission. If the
+// ReleasePlanAdmission references a ClusterReleaseStrategy, it will be returned as a ReleaseStrategy in the namespace
+// of the ReleasePlanAdmission. If the referenced ReleaseStrategy inherits from a ClusterReleaseStrategy, the result of
+// merging both will be returned. If any of the strategies is not found or the Get operation fails, an error will be
+// returned.
 func (l *loader) GetReleaseStrategy(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*v1alpha1.ReleaseStrategy, error) {
+	if releasePlanAdmission.Spec.ReleaseStrategyKind == v1alpha1.ClusterReleaseStrategyKind {
+		clusterReleaseStrategy, err := l.GetClusterReleaseStrategy(ctx, cli, releasePlanAdmission.Spec.ReleaseStrategy)
+		if err != nil {
+			return nil, err
+		}
+
+		return clusterReleaseStrategy.AsReleaseStrategy(releasePlanAdmission.Namespace), nil
+	}
+
 	releaseStrategy := &v1alpha1.ReleaseStrategy{}
-	return releaseStrategy, getObject(releasePlanAdmission.Spec.ReleaseStrategy, releasePlanAdmission.Namespace, cli, ctx, releaseStrategy)
+	err := getObject(releasePlanAdmission.Spec.ReleaseStrategy, releasePlanAdmission.Namespace, cli, ctx, releaseStrategy)
+	if err != nil || releaseStrategy.Spec.ClusterReleaseStrategy == "" {
+		return releaseStrategy, err
+	}
+
+	clusterReleaseStrategy, err := l.GetClusterReleaseStrategy(ctx, cli, releaseStrategy.Spec.ClusterReleaseStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	return releaseStrategy.InheritFrom(clusterReleaseStrategy), nil
+}
+
+// GetReleaseStrategyParams returns the params of the given ReleaseStrategy after resolving those referencing a
+// ConfigMap or a Secret. Params referencing a ConfigMap key will contain the value of that key, while params
+// referencing a Secret key will contain the name of the Secret, so its content is not exposed. If any of the referenced
+// resources or keys is not found and the reference is not optional, an error will be returned.
+func (l *loader) GetReleaseStrategyParams(ctx context.Context, cli client.Client, releaseStrategy *v1alpha1.ReleaseStrategy) ([]v1alpha1.Params, error) {
+	var params []v1alpha1.Params
+
+	for _, param := range releaseStrategy.Spec.Params {
+		if param.ValueFrom == nil {
+			params = append(params, param)
+			continue
+		}
+
+		value, err := getParamValueFromSource(ctx, cli, releaseStrategy.Namespace, param.Name, param.ValueFrom)
+		if err != nil {
+			return nil, err
+		}
+
+		params = append(params, v1alpha1.Params{
+			Name:  param.Name,
+			Value: value,
+		})
+	}
+
+	return params, nil
+}
+
+// GetReleaseStrategyPipeline returns the Pipeline referenced by the given ReleaseStrategy. Pipelines referenced by
+// name are loaded from the namespace of the ReleaseStrategy, while Pipelines referenced by bundle or through a
+// resolver are loaded from the ResolutionRequest returned by tekton.NewPipelineResolutionRequest, which is expected to
+// exist. If the resolution hasn't finished yet, tekton.ErrResolutionInProgress will be returned. If the Pipeline or
+// the ResolutionRequest are not found or the Get operation fails, an error will be returned.
+func (l *loader) GetReleaseStrategyPipeline(ctx context.Context, cli client.Client, releaseStrategy *v1alpha1.ReleaseStrategy) (*v1beta1.Pipeline, error) {
+	resolutionRequest := tekton.NewPipelineResolutionRequest(releaseStrategy)
+	if resolutionRequest == nil {
+		pipeline := &v1beta1.Pipeline{}
+		return pipeline, getObject(releaseStrategy.Spec.Pipeline, releaseStrategy.Namespace, cli, ctx, pipeline)
+	}
+
+	err := getObject(resolutionRequest.Name, resolutionRequest.Namespace, cli, ctx, resolutionRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return tekton.GetPipelineFromResolutionRequest(resolutionRequest)
+}
+
+// GetReleaseStrategyReleasePlanAdmissions returns all the ReleasePlanAdmissions referencing the given
+// ReleaseStrategy. If the List operation fails, an error will be returned.
+func (l *loader) GetReleaseStrategyReleasePlanAdmissions(ctx context.Context, cli client.Client, releaseStrategy *v1alpha1.ReleaseStrategy) ([]v1alpha1.ReleasePlanAdmission, error) {
+	releasePlanAdmissions := &v1alpha1.ReleasePlanAdmissionList{}
+	err := cli.List(ctx, releasePlanAdmissions, client.InNamespace(releaseStrategy.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	var referencingReleasePlanAdmissions []v1alpha1.ReleasePlanAdmission
+	for _, releasePlanAdmission := range releasePlanAdmissions.Items {
+		if releasePlanAdmission.Spec.ReleaseStrategy == releaseStrategy.Name &&
+			releasePlanAdmission.Spec.ReleaseStrategyKind != v1alpha1.ClusterReleaseStrategyKind {
+			referencingReleasePlanAdmissions = append(referencingReleasePlanAdmissions, releasePlanAdmission)
+		}
+	}
+
+	return referencingReleasePlanAdmissions, nil
 }
 
-// GetSnapshot returns the Snapshot referenced by the given Release. If the Snapshot is not found or the Get
+// GetSnapshot returns the Snapshot released by the given Release. If the Snapshot is not found or the Get
 // operation fails, an error is returned.
-func (l *loader) GetSnapshot(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*applicationapiv1alpha1.Snapshot, error) {
+func (l *loader) GetSnapshot(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*applicationapiv1alpha1.Snapshot, error) {
 	snapshot := &applicationapiv1alpha1.Snapshot{}
-	return snapshot, getObject(release.Spec.Snapshot, release.Namespace, cli, ctx, snapshot)
+	return snapshot, getObject(release.GetSnapshotName(), release.Namespace, cli, ctx, snapshot)
 }
 
-// GetSnapshotEnvironmentBinding returns the SnapshotEnvironmentBinding associated with the given ReleasePlanAdmission.
-// That association is defined by both the Environment and Application matching between the ReleasePlanAdmission and
-// the SnapshotEnvironmentBinding. If the Get operation fails, an error will be returned.
-func (l *loader) GetSnapshotEnvironmentBinding(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error) {
+// GetSnapshotEnvironmentBinding returns the SnapshotEnvironmentBinding associated with the given Environment and
+// Application. That association is defined by both the Environment and the Application matching the ones of the
+// SnapshotEnvironmentBinding. If the Get operation fails, an error will be returned.
+func (l *loader) GetSnapshotEnvironmentBinding(ctx context.Context, cli client.Client, environment *applicationapiv1alpha1.Environment, application *applicationapiv1alpha1.Application) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error) {
 	bindingList := &applicationapiv1alpha1.SnapshotEnvironmentBindingList{}
 	err := cli.List(ctx, bindingList,
-		client.InNamespace(releasePlanAdmission.Namespace),
-		client.MatchingFields{"spec.environment": releasePlanAdmission.Spec.Environment})
+		client.InNamespace(environment.Namespace),
+		client.MatchingFields{"spec.environment": environment.Name})
 	if err != nil {
 		return nil, err
 	}
 
 	for _, binding := range bindingList.Items {
-		if binding.Spec.Application == releasePlanAdmission.Spec.Application {
+		if binding.Spec.Application == application.Name {
 			return &binding, nil
 		}
 	}
@@ -201,25 +389,149 @@ func (l *loader) GetSnapshotEnvironmentBinding(ctx context.Context, cli client.C
 }
 
 // GetSnapshotEnvironmentBindingFromReleaseStatus returns the SnapshotEnvironmentBinding associated with the given Release.
-// That association is defined by namespaced name stored in the Release's status.
-func (l *loader) GetSnapshotEnvironmentBindingFromReleaseStatus(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error) {
+// That association is defined by the reference stored in the Release's status.
+func (l *loader) GetSnapshotEnvironmentBindingFromReleaseStatus(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error) {
+	if release.Status.SnapshotEnvironmentBinding == nil {
+		return nil, fmt.Errorf("release doesn't contain a valid reference to an SnapshotEnvironmentBinding")
+	}
+
 	binding := &applicationapiv1alpha1.SnapshotEnvironmentBinding{}
-	bindingNamespacedName := strings.Split(release.Status.SnapshotEnvironmentBinding, string(types.Separator))
-	if len(bindingNamespacedName) != 2 {
-		return nil, fmt.Errorf("release doesn't contain a valid reference to an SnapshotEnvironmentBinding ('%s')",
-			release.Status.SnapshotEnvironmentBinding)
+	err := cli.Get(ctx, release.Status.SnapshotEnvironmentBinding.NamespacedName(), binding)
+	if err != nil {
+		return nil, err
 	}
 
-	err := cli.Get(ctx, types.NamespacedName{
-		Namespace: bindingNamespacedName[0],
-		Name:      bindingNamespacedName[1],
-	}, binding)
+	return binding, nil
+}
 
+// GetVerificationPipelineRun returns the verification PipelineRun referenced by the given Release or nil if it's not
+// found. The PipelineRun is listed using the Tekton API version in use and returned as a v1beta1 PipelineRun. In the
+// case the List operation fails, an error will be returned.
+func (l *loader) GetVerificationPipelineRun(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*v1beta1.PipelineRun, error) {
+	return getPipelineRun(ctx, cli, release, tekton.PipelineTypeVerification)
+}
+
+// getOriginReleasePlanAdmissions returns the ReleasePlanAdmissions in the given target namespace admitting releases
+// coming from the given origin namespace, either because they list it or because they select it by label. If any of
+// the List operations fails or the origin namespace can't be retrieved, an error will be returned.
+func getOriginReleasePlanAdmissions(ctx context.Context, cli client.Client, origin, target string) ([]v1alpha1.ReleasePlanAdmission, error) {
+	releasePlanAdmissions := &v1alpha1.ReleasePlanAdmissionList{}
+	err := cli.List(ctx, releasePlanAdmissions,
+		client.InNamespace(target),
+		client.MatchingFields{"spec.origin": origin})
 	if err != nil {
 		return nil, err
 	}
 
-	return binding, nil
+	selectingReleasePlanAdmissions := &v1alpha1.ReleasePlanAdmissionList{}
+	err = cli.List(ctx, selectingReleasePlanAdmissions,
+		client.InNamespace(target),
+		client.MatchingFields{"spec.origin": v1alpha1.OriginSelectorIndexValue})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(selectingReleasePlanAdmissions.Items) == 0 {
+		return releasePlanAdmissions.Items, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	err = getObject(origin, "", cli, ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, releasePlanAdmission := range selectingReleasePlanAdmissions.Items {
+		if !containsReleasePlanAdmission(releasePlanAdmissions.Items, releasePlanAdmission.Name) &&
+			releasePlanAdmission.MatchesOrigin(namespace) {
+			releasePlanAdmissions.Items = append(releasePlanAdmissions.Items, releasePlanAdmission)
+		}
+	}
+
+	return releasePlanAdmissions.Items, nil
+}
+
+// containsReleasePlanAdmission checks whether a ReleasePlanAdmission with the given name is in the given list.
+func containsReleasePlanAdmission(releasePlanAdmissions []v1alpha1.ReleasePlanAdmission, name string) bool {
+	for _, releasePlanAdmission := range releasePlanAdmissions {
+		if releasePlanAdmission.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getParamValueFromSource returns the value of a param by looking into the given source in the given namespace.
+// If the source is a ConfigMap, the value of the referenced key will be returned. If the source is a Secret, the name of
+// the Secret will be returned once the existence of the referenced key is verified.
+func getParamValueFromSource(ctx context.Context, cli client.Client, namespace, paramName string, source *v1alpha1.ParamValueSource) (string, error) {
+	if source.ConfigMapKeyRef != nil {
+		reference := source.ConfigMapKeyRef
+		isOptional := reference.Optional != nil && *reference.Optional
+
+		configMap := &corev1.ConfigMap{}
+		err := getObject(reference.Name, namespace, cli, ctx, configMap)
+		if err != nil {
+			if errors.IsNotFound(err) && isOptional {
+				return "", nil
+			}
+			return "", fmt.Errorf("unable to resolve param '%s': %w", paramName, err)
+		}
+
+		value, found := configMap.Data[reference.Key]
+		if !found && !isOptional {
+			return "", fmt.Errorf("unable to resolve param '%s': key '%s' not found in ConfigMap '%s'",
+				paramName, reference.Key, reference.Name)
+		}
+
+		return value, nil
+	}
+
+	if source.SecretKeyRef != nil {
+		reference := source.SecretKeyRef
+		isOptional := reference.Optional != nil && *reference.Optional
+
+		secret := &corev1.Secret{}
+		err := getObject(reference.Name, namespace, cli, ctx, secret)
+		if err != nil {
+			if errors.IsNotFound(err) && isOptional {
+				return "", nil
+			}
+			return "", fmt.Errorf("unable to resolve param '%s': %w", paramName, err)
+		}
+
+		if _, found := secret.Data[reference.Key]; !found && !isOptional {
+			return "", fmt.Errorf("unable to resolve param '%s': key '%s' not found in Secret '%s'",
+				paramName, reference.Key, reference.Name)
+		}
+
+		return secret.Name, nil
+	}
+
+	return "", fmt.Errorf("unable to resolve param '%s': no source set in valueFrom", paramName)
+}
+
+// getPipelineRun returns the PipelineRun of the given type referenced by the given Release or nil if it's not found.
+func getPipelineRun(ctx context.Context, cli client.Client, release *v1alpha2.Release, pipelineType string) (*v1beta1.PipelineRun, error) {
+	pipelineRunList := tekton.NewPipelineRunList()
+	err := cli.List(ctx, pipelineRunList,
+		client.Limit(1),
+		client.MatchingLabels{
+			tekton.PipelinesTypeLabel:    pipelineType,
+			tekton.ReleaseNameLabel:      release.Name,
+			tekton.ReleaseNamespaceLabel: release.Namespace,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineRuns, err := tekton.GetPipelineRunsFromList(pipelineRunList)
+	if err == nil && len(pipelineRuns) > 0 {
+		return &pipelineRuns[0], nil
+	}
+
+	return nil, err
 }
 
 // Composite functions
@@ -232,34 +544,37 @@ type SnapshotEnvironmentBindingResources struct {
 	Snapshot              *applicationapiv1alpha1.Snapshot
 }
 
-// GetSnapshotEnvironmentBindingResources returns all the resources required to create a SnapshotEnvironmentBinding.
-// If any of those resources cannot be retrieved from the cluster, an error will be returned.
-func (l *loader) GetSnapshotEnvironmentBindingResources(ctx context.Context, cli client.Client, release *v1alpha1.Release, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*SnapshotEnvironmentBindingResources, error) {
+// GetSnapshotEnvironmentBindingResources returns all the resources required to create a SnapshotEnvironmentBinding
+// for the Environment with the given name. The Application and the Environment are looked up in the namespace of the
+// ReleasePlanAdmission, the former by the name of the Snapshot's application, as a ReleasePlanAdmission can admit
+// releases of multiple applications. If any of those resources cannot be retrieved from the cluster, an error will be
+// returned.
+func (l *loader) GetSnapshotEnvironmentBindingResources(ctx context.Context, cli client.Client, release *v1alpha2.Release, releasePlanAdmission *v1alpha1.ReleasePlanAdmission, environmentName string) (*SnapshotEnvironmentBindingResources, error) {
 	resources := &SnapshotEnvironmentBindingResources{}
 
-	application, err := l.GetApplication(ctx, cli, releasePlanAdmission)
+	snapshot, err := l.GetSnapshot(ctx, cli, release)
 	if err != nil {
 		return resources, err
 	}
-	resources.Application = application
+	resources.Snapshot = snapshot
 
-	applicationComponents, err := l.GetApplicationComponents(ctx, cli, application)
+	application, err := l.GetApplication(ctx, cli, snapshot.Spec.Application, releasePlanAdmission.Namespace)
 	if err != nil {
 		return resources, err
 	}
-	resources.ApplicationComponents = applicationComponents
+	resources.Application = application
 
-	environment, err := l.GetEnvironment(ctx, cli, releasePlanAdmission)
+	applicationComponents, err := l.GetApplicationComponents(ctx, cli, application)
 	if err != nil {
 		return resources, err
 	}
-	resources.Environment = environment
+	resources.ApplicationComponents = applicationComponents
 
-	snapshot, err := l.GetSnapshot(ctx, cli, release)
+	environment, err := l.GetEnvironment(ctx, cli, environmentName, releasePlanAdmission.Namespace)
 	if err != nil {
 		return resources, err
 	}
-	resources.Snapshot = snapshot
+	resources.Environment = environment
 
 	return resources, nil
 }
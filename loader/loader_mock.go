@@ -5,7 +5,9 @@ import (
 	ecapiv1alpha1 "github.com/hacbs-contract/enterprise-contract-controller/api/v1alpha1"
 	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
 	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -22,18 +24,25 @@ type (
 )
 
 const (
-	ApplicationContextKey                         contextKey = iota
-	ApplicationComponentsContextKey               contextKey = iota
-	EnterpriseContractPolicyContextKey            contextKey = iota
-	EnvironmentContextKey                         contextKey = iota
-	ReleaseContextKey                             contextKey = iota
-	ReleasePipelineRunContextKey                  contextKey = iota
-	ReleasePlanContextKey                         contextKey = iota
-	ReleasePlanAdmissionContextKey                contextKey = iota
-	ReleaseStrategyContextKey                     contextKey = iota
-	SnapshotContextKey                            contextKey = iota
-	SnapshotEnvironmentBindingContextKey          contextKey = iota
-	SnapshotEnvironmentBindingResourcesContextKey contextKey = iota
+	ApplicationContextKey                          contextKey = iota
+	ApplicationComponentsContextKey                contextKey = iota
+	ClusterReleaseStrategyContextKey               contextKey = iota
+	ConflictingReleasePlanAdmissionsContextKey     contextKey = iota
+	EnterpriseContractPolicyContextKey             contextKey = iota
+	EnvironmentContextKey                          contextKey = iota
+	GitOpsDeploymentsContextKey                    contextKey = iota
+	ReleaseContextKey                              contextKey = iota
+	ReleasePipelineRunContextKey                   contextKey = iota
+	ReleasePlanContextKey                          contextKey = iota
+	ReleasePlanAdmissionContextKey                 contextKey = iota
+	ReleaseStrategyContextKey                      contextKey = iota
+	ReleaseStrategyParamsContextKey                contextKey = iota
+	ReleaseStrategyPipelineContextKey              contextKey = iota
+	ReleaseStrategyReleasePlanAdmissionsContextKey contextKey = iota
+	SnapshotContextKey                             contextKey = iota
+	SnapshotEnvironmentBindingContextKey           contextKey = iota
+	SnapshotEnvironmentBindingResourcesContextKey  contextKey = iota
+	VerificationPipelineRunContextKey              contextKey = iota
 )
 
 func GetMockedContext(ctx context.Context, data []MockData) context.Context {
@@ -83,7 +92,7 @@ func (l *mockLoader) GetActiveReleasePlanAdmission(ctx context.Context, cli clie
 }
 
 // GetActiveReleasePlanAdmissionFromRelease returns the resource and error passed as values of the context.
-func (l *mockLoader) GetActiveReleasePlanAdmissionFromRelease(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*v1alpha1.ReleasePlanAdmission, error) {
+func (l *mockLoader) GetActiveReleasePlanAdmissionFromRelease(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*v1alpha1.ReleasePlanAdmission, error) {
 	if ctx.Value(ReleasePlanAdmissionContextKey) == nil {
 		return l.loader.GetActiveReleasePlanAdmissionFromRelease(ctx, cli, release)
 	}
@@ -91,9 +100,9 @@ func (l *mockLoader) GetActiveReleasePlanAdmissionFromRelease(ctx context.Contex
 }
 
 // GetApplication returns the resource and error passed as values of the context.
-func (l *mockLoader) GetApplication(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*applicationapiv1alpha1.Application, error) {
+func (l *mockLoader) GetApplication(ctx context.Context, cli client.Client, name, namespace string) (*applicationapiv1alpha1.Application, error) {
 	if ctx.Value(ApplicationContextKey) == nil {
-		return l.loader.GetApplication(ctx, cli, releasePlanAdmission)
+		return l.loader.GetApplication(ctx, cli, name, namespace)
 	}
 	return getMockedResourceAndErrorFromContext(ctx, ApplicationContextKey, &applicationapiv1alpha1.Application{})
 }
@@ -106,6 +115,22 @@ func (l *mockLoader) GetApplicationComponents(ctx context.Context, cli client.Cl
 	return getMockedResourceAndErrorFromContext(ctx, ApplicationComponentsContextKey, []applicationapiv1alpha1.Component{})
 }
 
+// GetClusterReleaseStrategy returns the resource and error passed as values of the context.
+func (l *mockLoader) GetClusterReleaseStrategy(ctx context.Context, cli client.Client, name string) (*v1alpha1.ClusterReleaseStrategy, error) {
+	if ctx.Value(ClusterReleaseStrategyContextKey) == nil {
+		return l.loader.GetClusterReleaseStrategy(ctx, cli, name)
+	}
+	return getMockedResourceAndErrorFromContext(ctx, ClusterReleaseStrategyContextKey, &v1alpha1.ClusterReleaseStrategy{})
+}
+
+// GetConflictingReleasePlanAdmissions returns the resource and error passed as values of the context.
+func (l *mockLoader) GetConflictingReleasePlanAdmissions(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) ([]v1alpha1.ReleasePlanAdmission, error) {
+	if ctx.Value(ConflictingReleasePlanAdmissionsContextKey) == nil {
+		return l.loader.GetConflictingReleasePlanAdmissions(ctx, cli, releasePlanAdmission)
+	}
+	return getMockedResourceAndErrorFromContext(ctx, ConflictingReleasePlanAdmissionsContextKey, []v1alpha1.ReleasePlanAdmission{})
+}
+
 // GetEnterpriseContractPolicy returns the resource and error passed as values of the context.
 func (l *mockLoader) GetEnterpriseContractPolicy(ctx context.Context, cli client.Client, releaseStrategy *v1alpha1.ReleaseStrategy) (*ecapiv1alpha1.EnterpriseContractPolicy, error) {
 	if ctx.Value(EnterpriseContractPolicyContextKey) == nil {
@@ -115,23 +140,31 @@ func (l *mockLoader) GetEnterpriseContractPolicy(ctx context.Context, cli client
 }
 
 // GetEnvironment returns the resource and error passed as values of the context.
-func (l *mockLoader) GetEnvironment(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*applicationapiv1alpha1.Environment, error) {
+func (l *mockLoader) GetEnvironment(ctx context.Context, cli client.Client, name, namespace string) (*applicationapiv1alpha1.Environment, error) {
 	if ctx.Value(EnvironmentContextKey) == nil {
-		return l.loader.GetEnvironment(ctx, cli, releasePlanAdmission)
+		return l.loader.GetEnvironment(ctx, cli, name, namespace)
 	}
 	return getMockedResourceAndErrorFromContext(ctx, EnvironmentContextKey, &applicationapiv1alpha1.Environment{})
 }
 
+// GetGitOpsDeployments returns the resource and error passed as values of the context.
+func (l *mockLoader) GetGitOpsDeployments(ctx context.Context, cli client.Client, binding *applicationapiv1alpha1.SnapshotEnvironmentBinding) ([]unstructured.Unstructured, error) {
+	if ctx.Value(GitOpsDeploymentsContextKey) == nil {
+		return l.loader.GetGitOpsDeployments(ctx, cli, binding)
+	}
+	return getMockedResourceAndErrorFromContext(ctx, GitOpsDeploymentsContextKey, []unstructured.Unstructured{})
+}
+
 // GetRelease returns the resource and error passed as values of the context.
-func (l *mockLoader) GetRelease(ctx context.Context, cli client.Client, name, namespace string) (*v1alpha1.Release, error) {
+func (l *mockLoader) GetRelease(ctx context.Context, cli client.Client, name, namespace string) (*v1alpha2.Release, error) {
 	if ctx.Value(ReleaseContextKey) == nil {
 		return l.loader.GetRelease(ctx, cli, name, namespace)
 	}
-	return getMockedResourceAndErrorFromContext(ctx, ReleaseContextKey, &v1alpha1.Release{})
+	return getMockedResourceAndErrorFromContext(ctx, ReleaseContextKey, &v1alpha2.Release{})
 }
 
 // GetReleasePipelineRun returns the resource and error passed as values of the context.
-func (l *mockLoader) GetReleasePipelineRun(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*v1beta1.PipelineRun, error) {
+func (l *mockLoader) GetReleasePipelineRun(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*v1beta1.PipelineRun, error) {
 	if ctx.Value(ReleasePipelineRunContextKey) == nil {
 		return l.loader.GetReleasePipelineRun(ctx, cli, release)
 	}
@@ -139,7 +172,7 @@ func (l *mockLoader) GetReleasePipelineRun(ctx context.Context, cli client.Clien
 }
 
 // GetReleasePlan returns the resource and error passed as values of the context.
-func (l *mockLoader) GetReleasePlan(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*v1alpha1.ReleasePlan, error) {
+func (l *mockLoader) GetReleasePlan(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*v1alpha1.ReleasePlan, error) {
 	if ctx.Value(ReleasePlanContextKey) == nil {
 		return l.loader.GetReleasePlan(ctx, cli, release)
 	}
@@ -154,8 +187,32 @@ func (l *mockLoader) GetReleaseStrategy(ctx context.Context, cli client.Client,
 	return getMockedResourceAndErrorFromContext(ctx, ReleaseStrategyContextKey, &v1alpha1.ReleaseStrategy{})
 }
 
+// GetReleaseStrategyParams returns the resource and error passed as values of the context.
+func (l *mockLoader) GetReleaseStrategyParams(ctx context.Context, cli client.Client, releaseStrategy *v1alpha1.ReleaseStrategy) ([]v1alpha1.Params, error) {
+	if ctx.Value(ReleaseStrategyParamsContextKey) == nil {
+		return l.loader.GetReleaseStrategyParams(ctx, cli, releaseStrategy)
+	}
+	return getMockedResourceAndErrorFromContext(ctx, ReleaseStrategyParamsContextKey, []v1alpha1.Params{})
+}
+
+// GetReleaseStrategyPipeline returns the resource and error passed as values of the context.
+func (l *mockLoader) GetReleaseStrategyPipeline(ctx context.Context, cli client.Client, releaseStrategy *v1alpha1.ReleaseStrategy) (*v1beta1.Pipeline, error) {
+	if ctx.Value(ReleaseStrategyPipelineContextKey) == nil {
+		return l.loader.GetReleaseStrategyPipeline(ctx, cli, releaseStrategy)
+	}
+	return getMockedResourceAndErrorFromContext(ctx, ReleaseStrategyPipelineContextKey, &v1beta1.Pipeline{})
+}
+
+// GetReleaseStrategyReleasePlanAdmissions returns the resource and error passed as values of the context.
+func (l *mockLoader) GetReleaseStrategyReleasePlanAdmissions(ctx context.Context, cli client.Client, releaseStrategy *v1alpha1.ReleaseStrategy) ([]v1alpha1.ReleasePlanAdmission, error) {
+	if ctx.Value(ReleaseStrategyReleasePlanAdmissionsContextKey) == nil {
+		return l.loader.GetReleaseStrategyReleasePlanAdmissions(ctx, cli, releaseStrategy)
+	}
+	return getMockedResourceAndErrorFromContext(ctx, ReleaseStrategyReleasePlanAdmissionsContextKey, []v1alpha1.ReleasePlanAdmission{})
+}
+
 // GetSnapshot returns the resource and error passed as values of the context.
-func (l *mockLoader) GetSnapshot(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*applicationapiv1alpha1.Snapshot, error) {
+func (l *mockLoader) GetSnapshot(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*applicationapiv1alpha1.Snapshot, error) {
 	if ctx.Value(SnapshotContextKey) == nil {
 		return l.loader.GetSnapshot(ctx, cli, release)
 	}
@@ -163,15 +220,15 @@ func (l *mockLoader) GetSnapshot(ctx context.Context, cli client.Client, release
 }
 
 // GetSnapshotEnvironmentBinding returns the resource and error passed as values of the context.
-func (l *mockLoader) GetSnapshotEnvironmentBinding(ctx context.Context, cli client.Client, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error) {
+func (l *mockLoader) GetSnapshotEnvironmentBinding(ctx context.Context, cli client.Client, environment *applicationapiv1alpha1.Environment, application *applicationapiv1alpha1.Application) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error) {
 	if ctx.Value(SnapshotEnvironmentBindingContextKey) == nil {
-		return l.loader.GetSnapshotEnvironmentBinding(ctx, cli, releasePlanAdmission)
+		return l.loader.GetSnapshotEnvironmentBinding(ctx, cli, environment, application)
 	}
 	return getMockedResourceAndErrorFromContext(ctx, SnapshotEnvironmentBindingContextKey, &applicationapiv1alpha1.SnapshotEnvironmentBinding{})
 }
 
 // GetSnapshotEnvironmentBindingFromReleaseStatus returns the resource and error passed as values of the context.
-func (l *mockLoader) GetSnapshotEnvironmentBindingFromReleaseStatus(ctx context.Context, cli client.Client, release *v1alpha1.Release) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error) {
+func (l *mockLoader) GetSnapshotEnvironmentBindingFromReleaseStatus(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*applicationapiv1alpha1.SnapshotEnvironmentBinding, error) {
 	if ctx.Value(SnapshotEnvironmentBindingContextKey) == nil {
 		return l.loader.GetSnapshotEnvironmentBindingFromReleaseStatus(ctx, cli, release)
 	}
@@ -179,9 +236,17 @@ func (l *mockLoader) GetSnapshotEnvironmentBindingFromReleaseStatus(ctx context.
 }
 
 // GetSnapshotEnvironmentBindingResources returns the resource and error passed as values of the context.
-func (l *mockLoader) GetSnapshotEnvironmentBindingResources(ctx context.Context, cli client.Client, release *v1alpha1.Release, releasePlanAdmission *v1alpha1.ReleasePlanAdmission) (*SnapshotEnvironmentBindingResources, error) {
+func (l *mockLoader) GetSnapshotEnvironmentBindingResources(ctx context.Context, cli client.Client, release *v1alpha2.Release, releasePlanAdmission *v1alpha1.ReleasePlanAdmission, environmentName string) (*SnapshotEnvironmentBindingResources, error) {
 	if ctx.Value(SnapshotEnvironmentBindingResourcesContextKey) == nil {
-		return l.loader.GetSnapshotEnvironmentBindingResources(ctx, cli, release, releasePlanAdmission)
+		return l.loader.GetSnapshotEnvironmentBindingResources(ctx, cli, release, releasePlanAdmission, environmentName)
 	}
 	return getMockedResourceAndErrorFromContext(ctx, SnapshotEnvironmentBindingResourcesContextKey, &SnapshotEnvironmentBindingResources{})
 }
+
+// GetVerificationPipelineRun returns the resource and error passed as values of the context.
+func (l *mockLoader) GetVerificationPipelineRun(ctx context.Context, cli client.Client, release *v1alpha2.Release) (*v1beta1.PipelineRun, error) {
+	if ctx.Value(VerificationPipelineRunContextKey) == nil {
+		return l.loader.GetVerificationPipelineRun(ctx, cli, release)
+	}
+	return getMockedResourceAndErrorFromContext(ctx, VerificationPipelineRunContextKey, &v1beta1.PipelineRun{})
+}
@@ -7,8 +7,10 @@ import (
 	. "github.com/onsi/gomega"
 	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
 	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 var _ = Describe("Release Adapter", Ordered, func() {
@@ -22,12 +24,12 @@ var _ = Describe("Release Adapter", Ordered, func() {
 
 	Context("When calling getMockedResourceAndErrorFromContext", func() {
 		contextErr := errors.New("error")
-		contextResource := &v1alpha1.Release{
+		contextResource := &v1alpha2.Release{
 			ObjectMeta: v12.ObjectMeta{
 				Name:      "pod",
 				Namespace: "default",
 			},
-			Spec: v1alpha1.ReleaseSpec{
+			Spec: v1alpha2.ReleaseSpec{
 				ReleasePlan: "releasePlan",
 				Snapshot:    "snapshot",
 			},
@@ -116,7 +118,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 					Resource:   application,
 				},
 			})
-			resource, err := loader.GetApplication(mockContext, nil, nil)
+			resource, err := loader.GetApplication(mockContext, nil, "", "")
 			Expect(resource).To(Equal(application))
 			Expect(err).To(BeNil())
 		})
@@ -137,6 +139,36 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		})
 	})
 
+	Context("When calling GetClusterReleaseStrategy", func() {
+		It("returns the resource and error from the context", func() {
+			clusterReleaseStrategy := &v1alpha1.ClusterReleaseStrategy{}
+			mockContext := GetMockedContext(ctx, []MockData{
+				{
+					ContextKey: ClusterReleaseStrategyContextKey,
+					Resource:   clusterReleaseStrategy,
+				},
+			})
+			resource, err := loader.GetClusterReleaseStrategy(mockContext, nil, "")
+			Expect(resource).To(Equal(clusterReleaseStrategy))
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Context("When calling GetConflictingReleasePlanAdmissions", func() {
+		It("returns the resource and error from the context", func() {
+			releasePlanAdmissions := []v1alpha1.ReleasePlanAdmission{{}}
+			mockContext := GetMockedContext(ctx, []MockData{
+				{
+					ContextKey: ConflictingReleasePlanAdmissionsContextKey,
+					Resource:   releasePlanAdmissions,
+				},
+			})
+			resource, err := loader.GetConflictingReleasePlanAdmissions(mockContext, nil, nil)
+			Expect(resource).To(Equal(releasePlanAdmissions))
+			Expect(err).To(BeNil())
+		})
+	})
+
 	Context("When calling GetEnterpriseContractPolicy", func() {
 		It("returns the resource and error from the context", func() {
 			enterpriseContractPolicy := &v1alpha12.EnterpriseContractPolicy{}
@@ -161,15 +193,30 @@ var _ = Describe("Release Adapter", Ordered, func() {
 					Resource:   environment,
 				},
 			})
-			resource, err := loader.GetEnvironment(mockContext, nil, nil)
+			resource, err := loader.GetEnvironment(mockContext, nil, "", "")
 			Expect(resource).To(Equal(environment))
 			Expect(err).To(BeNil())
 		})
 	})
 
+	Context("When calling GetGitOpsDeployments", func() {
+		It("returns the resource and error from the context", func() {
+			gitOpsDeployments := []unstructured.Unstructured{}
+			mockContext := GetMockedContext(ctx, []MockData{
+				{
+					ContextKey: GitOpsDeploymentsContextKey,
+					Resource:   gitOpsDeployments,
+				},
+			})
+			resource, err := loader.GetGitOpsDeployments(mockContext, nil, nil)
+			Expect(resource).To(Equal(gitOpsDeployments))
+			Expect(err).To(BeNil())
+		})
+	})
+
 	Context("When calling GetRelease", func() {
 		It("returns the resource and error from the context", func() {
-			release := &v1alpha1.Release{}
+			release := &v1alpha2.Release{}
 			mockContext := GetMockedContext(ctx, []MockData{
 				{
 					ContextKey: ReleaseContextKey,
@@ -227,6 +274,53 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		})
 	})
 
+	Context("When calling GetReleaseStrategyParams", func() {
+		It("returns the resource and error from the context", func() {
+			params := []v1alpha1.Params{
+				{Name: "foo", Value: "bar"},
+			}
+			mockContext := GetMockedContext(ctx, []MockData{
+				{
+					ContextKey: ReleaseStrategyParamsContextKey,
+					Resource:   params,
+				},
+			})
+			resource, err := loader.GetReleaseStrategyParams(mockContext, nil, nil)
+			Expect(resource).To(Equal(params))
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Context("When calling GetReleaseStrategyPipeline", func() {
+		It("returns the resource and error from the context", func() {
+			pipeline := &v1beta1.Pipeline{}
+			mockContext := GetMockedContext(ctx, []MockData{
+				{
+					ContextKey: ReleaseStrategyPipelineContextKey,
+					Resource:   pipeline,
+				},
+			})
+			resource, err := loader.GetReleaseStrategyPipeline(mockContext, nil, nil)
+			Expect(resource).To(Equal(pipeline))
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Context("When calling GetReleaseStrategyReleasePlanAdmissions", func() {
+		It("returns the resource and error from the context", func() {
+			releasePlanAdmissions := []v1alpha1.ReleasePlanAdmission{{}}
+			mockContext := GetMockedContext(ctx, []MockData{
+				{
+					ContextKey: ReleaseStrategyReleasePlanAdmissionsContextKey,
+					Resource:   releasePlanAdmissions,
+				},
+			})
+			resource, err := loader.GetReleaseStrategyReleasePlanAdmissions(mockContext, nil, nil)
+			Expect(resource).To(Equal(releasePlanAdmissions))
+			Expect(err).To(BeNil())
+		})
+	})
+
 	Context("When calling GetSnapshot", func() {
 		It("returns the resource and error from the context", func() {
 			snapshot := &applicationapiv1alpha1.Snapshot{}
@@ -251,7 +345,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 					Resource:   snapshotEnvironmentBinding,
 				},
 			})
-			resource, err := loader.GetSnapshotEnvironmentBinding(mockContext, nil, nil)
+			resource, err := loader.GetSnapshotEnvironmentBinding(mockContext, nil, nil, nil)
 			Expect(resource).To(Equal(snapshotEnvironmentBinding))
 			Expect(err).To(BeNil())
 		})
@@ -281,10 +375,25 @@ var _ = Describe("Release Adapter", Ordered, func() {
 					Resource:   snapshotEnvironmentBindingResources,
 				},
 			})
-			resource, err := loader.GetSnapshotEnvironmentBindingResources(mockContext, nil, nil, nil)
+			resource, err := loader.GetSnapshotEnvironmentBindingResources(mockContext, nil, nil, nil, "")
 			Expect(resource).To(Equal(snapshotEnvironmentBindingResources))
 			Expect(err).To(BeNil())
 		})
 	})
 
+	Context("When calling GetVerificationPipelineRun", func() {
+		It("returns the resource and error from the context", func() {
+			pipelineRun := &v1beta1.PipelineRun{}
+			mockContext := GetMockedContext(ctx, []MockData{
+				{
+					ContextKey: VerificationPipelineRunContextKey,
+					Resource:   pipelineRun,
+				},
+			})
+			resource, err := loader.GetVerificationPipelineRun(mockContext, nil, nil)
+			Expect(resource).To(Equal(pipelineRun))
+			Expect(err).To(BeNil())
+		})
+	})
+
 })
@@ -22,8 +22,10 @@ import (
 	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
 	"github.com/redhat-appstudio/operator-goodies/test"
 	appstudiov1alpha1 "github.com/redhat-appstudio/release-service/api/v1alpha1"
+	appstudiov1alpha2 "github.com/redhat-appstudio/release-service/api/v1alpha2"
 	"github.com/redhat-appstudio/release-service/cache"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	"go/build"
 	"path/filepath"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -87,9 +89,11 @@ var _ = BeforeSuite(func() {
 	Expect(cfg).NotTo(BeNil())
 
 	Expect(appstudiov1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+	Expect(appstudiov1alpha2.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(tektonv1beta1.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(ecapiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(applicationapiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+	Expect(resolutionv1beta1.AddToScheme(scheme.Scheme)).To(Succeed())
 
 	//+kubebuilder:scaffold:scheme
 
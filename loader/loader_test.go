@@ -1,19 +1,21 @@
 package loader
 
 import (
+	goerrors "errors"
 	"fmt"
+
 	ecapiv1alpha1 "github.com/hacbs-contract/enterprise-contract-controller/api/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
 	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
 	"github.com/redhat-appstudio/release-service/tekton"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
-	"strings"
 )
 
 var _ = Describe("Release Adapter", Ordered, func() {
@@ -23,14 +25,17 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		deleteResources func()
 
 		application                *applicationapiv1alpha1.Application
+		clusterReleaseStrategy     *v1alpha1.ClusterReleaseStrategy
 		component                  *applicationapiv1alpha1.Component
+		configMap                  *corev1.ConfigMap
 		enterpriseContractPolicy   *ecapiv1alpha1.EnterpriseContractPolicy
 		environment                *applicationapiv1alpha1.Environment
 		pipelineRun                *v1beta1.PipelineRun
-		release                    *v1alpha1.Release
+		release                    *v1alpha2.Release
 		releasePlan                *v1alpha1.ReleasePlan
 		releasePlanAdmission       *v1alpha1.ReleasePlanAdmission
 		releaseStrategy            *v1alpha1.ReleaseStrategy
+		secret                     *corev1.Secret
 		snapshot                   *applicationapiv1alpha1.Snapshot
 		snapshotEnvironmentBinding *applicationapiv1alpha1.SnapshotEnvironmentBinding
 	)
@@ -80,7 +85,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			Expect(returnedObject).To(BeNil())
 		})
 
-		It("fails to return an active release plan admission if multiple matches are found", func() {
+		It("fails to return an active release plan admission if multiple matches share the highest priority", func() {
 			newReleasePlanAdmission := releasePlanAdmission.DeepCopy()
 			newReleasePlanAdmission.Name = "new-release-plan-admission"
 			newReleasePlanAdmission.ResourceVersion = ""
@@ -88,23 +93,96 @@ var _ = Describe("Release Adapter", Ordered, func() {
 
 			Eventually(func() bool {
 				returnedObject, err := loader.GetActiveReleasePlanAdmission(ctx, k8sClient, releasePlan)
-				return returnedObject == nil && err != nil && strings.Contains(err.Error(), "multiple ReleasePlanAdmissions")
-			})
+				return returnedObject == nil && err != nil && goerrors.Is(err, ErrMultipleReleasePlanAdmissions)
+			}).Should(BeTrue())
 
 			Expect(k8sClient.Delete(ctx, newReleasePlanAdmission)).To(Succeed())
 		})
 
-		It("fails to return an active release plan admission if the auto release label is set to false", func() {
+		It("returns the release plan admission with the highest priority if multiple matches are found", func() {
+			prioritizedReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			prioritizedReleasePlanAdmission.Name = "prioritized-release-plan-admission"
+			prioritizedReleasePlanAdmission.Spec.Priority = releasePlanAdmission.Spec.Priority + 1
+			prioritizedReleasePlanAdmission.ResourceVersion = ""
+			Expect(k8sClient.Create(ctx, prioritizedReleasePlanAdmission)).To(Succeed())
+
+			Eventually(func() bool {
+				returnedObject, err := loader.GetActiveReleasePlanAdmission(ctx, k8sClient, releasePlan)
+				return err == nil && returnedObject.Name == prioritizedReleasePlanAdmission.Name
+			}).Should(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, prioritizedReleasePlanAdmission)).To(Succeed())
+		})
+
+		It("returns a release plan admission listing multiple applications and origins", func() {
+			multipleReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			multipleReleasePlanAdmission.Name = "multiple-release-plan-admission"
+			multipleReleasePlanAdmission.Spec.Application = ""
+			multipleReleasePlanAdmission.Spec.Applications = []string{"other-application", application.Name}
+			multipleReleasePlanAdmission.Spec.Origin = ""
+			multipleReleasePlanAdmission.Spec.Origins = []string{"other-origin", releasePlan.Namespace}
+			multipleReleasePlanAdmission.Spec.Priority = releasePlanAdmission.Spec.Priority + 1
+			multipleReleasePlanAdmission.ResourceVersion = ""
+			Expect(k8sClient.Create(ctx, multipleReleasePlanAdmission)).To(Succeed())
+
+			Eventually(func() bool {
+				returnedObject, err := loader.GetActiveReleasePlanAdmission(ctx, k8sClient, releasePlan)
+				return err == nil && returnedObject.Name == multipleReleasePlanAdmission.Name
+			}).Should(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, multipleReleasePlanAdmission)).To(Succeed())
+		})
+
+		It("returns a release plan admission selecting the origin by label", func() {
+			selectingReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			selectingReleasePlanAdmission.Name = "selecting-release-plan-admission"
+			selectingReleasePlanAdmission.Spec.Origin = ""
+			selectingReleasePlanAdmission.Spec.OriginSelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/metadata.name": releasePlan.Namespace},
+			}
+			selectingReleasePlanAdmission.Spec.Priority = releasePlanAdmission.Spec.Priority + 1
+			selectingReleasePlanAdmission.ResourceVersion = ""
+			Expect(k8sClient.Create(ctx, selectingReleasePlanAdmission)).To(Succeed())
+
+			Eventually(func() bool {
+				returnedObject, err := loader.GetActiveReleasePlanAdmission(ctx, k8sClient, releasePlan)
+				return err == nil && returnedObject.Name == selectingReleasePlanAdmission.Name
+			}).Should(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, selectingReleasePlanAdmission)).To(Succeed())
+		})
+
+		It("skips release plan admissions with the auto release label set to false", func() {
 			disabledReleasePlanAdmission := releasePlanAdmission.DeepCopy()
-			disabledReleasePlanAdmission.Labels[v1alpha1.AutoReleaseLabel] = "false"
+			disabledReleasePlanAdmission.Labels = map[string]string{v1alpha1.AutoReleaseLabel: "false"}
 			disabledReleasePlanAdmission.Name = "disabled-release-plan-admission"
+			disabledReleasePlanAdmission.Spec.Priority = releasePlanAdmission.Spec.Priority + 1
 			disabledReleasePlanAdmission.ResourceVersion = ""
 			Expect(k8sClient.Create(ctx, disabledReleasePlanAdmission)).To(Succeed())
 
 			Eventually(func() bool {
 				returnedObject, err := loader.GetActiveReleasePlanAdmission(ctx, k8sClient, releasePlan)
-				return returnedObject == nil && err != nil && strings.Contains(err.Error(), "with auto-release label set to false")
-			})
+				return err == nil && returnedObject.Name == releasePlanAdmission.Name
+			}).Should(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, disabledReleasePlanAdmission)).To(Succeed())
+		})
+
+		It("fails to return an active release plan admission if the auto release label is set to false", func() {
+			disabledReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			disabledReleasePlanAdmission.Labels = map[string]string{v1alpha1.AutoReleaseLabel: "false"}
+			disabledReleasePlanAdmission.Name = "disabled-release-plan-admission"
+			disabledReleasePlanAdmission.Spec.Application = "disabled-application"
+			disabledReleasePlanAdmission.ResourceVersion = ""
+			Expect(k8sClient.Create(ctx, disabledReleasePlanAdmission)).To(Succeed())
+
+			modifiedReleasePlan := releasePlan.DeepCopy()
+			modifiedReleasePlan.Spec.Application = disabledReleasePlanAdmission.Spec.Application
+
+			Eventually(func() bool {
+				returnedObject, err := loader.GetActiveReleasePlanAdmission(ctx, k8sClient, modifiedReleasePlan)
+				return returnedObject == nil && err != nil && goerrors.Is(err, ErrReleasePlanAdmissionDisabled)
+			}).Should(BeTrue())
 
 			Expect(k8sClient.Delete(ctx, disabledReleasePlanAdmission)).To(Succeed())
 		})
@@ -129,9 +207,20 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		})
 	})
 
+	Context("When calling GetActiveReleasePlanAdmissionErrorReason", func() {
+		It("returns the reasons matching the errors returned when resolving the active ReleasePlanAdmission", func() {
+			Expect(GetActiveReleasePlanAdmissionErrorReason(fmt.Errorf("%w with the target",
+				ErrMultipleReleasePlanAdmissions))).To(Equal(v1alpha2.ReleaseReasonValidationError))
+			Expect(GetActiveReleasePlanAdmissionErrorReason(fmt.Errorf("found ReleasePlanAdmission 'foo' with %w",
+				ErrReleasePlanAdmissionDisabled))).To(Equal(v1alpha2.ReleaseReasonTargetDisabledError))
+			Expect(GetActiveReleasePlanAdmissionErrorReason(fmt.Errorf("not found"))).To(
+				Equal(v1alpha2.ReleaseReasonReleasePlanValidationError))
+		})
+	})
+
 	Context("When calling GetApplication", func() {
 		It("returns the requested application", func() {
-			returnedObject, err := loader.GetApplication(ctx, k8sClient, releasePlanAdmission)
+			returnedObject, err := loader.GetApplication(ctx, k8sClient, application.Name, application.Namespace)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(returnedObject).NotTo(Equal(&applicationapiv1alpha1.Application{}))
 			Expect(returnedObject.Name).To(Equal(application.Name))
@@ -147,6 +236,35 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		})
 	})
 
+	Context("When calling GetClusterReleaseStrategy", func() {
+		It("returns the requested cluster release strategy", func() {
+			returnedObject, err := loader.GetClusterReleaseStrategy(ctx, k8sClient, clusterReleaseStrategy.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedObject).NotTo(Equal(&v1alpha1.ClusterReleaseStrategy{}))
+			Expect(returnedObject.Name).To(Equal(clusterReleaseStrategy.Name))
+		})
+	})
+
+	Context("When calling GetConflictingReleasePlanAdmissions", func() {
+		It("returns an empty list if no other ReleasePlanAdmission shares the origin and application", func() {
+			modifiedReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			modifiedReleasePlanAdmission.Spec.Application = "non-existent-application"
+
+			returnedObjects, err := loader.GetConflictingReleasePlanAdmissions(ctx, k8sClient, modifiedReleasePlanAdmission)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedObjects).To(BeEmpty())
+		})
+
+		It("returns the ReleasePlanAdmissions sharing the origin and application", func() {
+			modifiedReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			modifiedReleasePlanAdmission.Name = "conflicting-release-plan-admission"
+
+			returnedObjects, err := loader.GetConflictingReleasePlanAdmissions(ctx, k8sClient, modifiedReleasePlanAdmission)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedObjects).To(ContainElement(HaveField("Name", releasePlanAdmission.Name)))
+		})
+	})
+
 	Context("When calling GetEnterpriseContractPolicy", func() {
 		It("returns the requested enterprise contract policy", func() {
 			returnedObject, err := loader.GetEnterpriseContractPolicy(ctx, k8sClient, releaseStrategy)
@@ -158,18 +276,35 @@ var _ = Describe("Release Adapter", Ordered, func() {
 
 	Context("When calling GetEnvironment", func() {
 		It("returns the requested environment", func() {
-			returnedObject, err := loader.GetEnvironment(ctx, k8sClient, releasePlanAdmission)
+			returnedObject, err := loader.GetEnvironment(ctx, k8sClient, environment.Name, environment.Namespace)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(returnedObject).NotTo(Equal(&applicationapiv1alpha1.Environment{}))
 			Expect(returnedObject.Name).To(Equal(environment.Name))
 		})
 	})
 
+	Context("When calling GetGitOpsDeployments", func() {
+		It("skips the GitOpsDeployments that can't be found", func() {
+			binding := &applicationapiv1alpha1.SnapshotEnvironmentBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Status: applicationapiv1alpha1.SnapshotEnvironmentBindingStatus{
+					GitOpsDeployments: []applicationapiv1alpha1.BindingStatusGitOpsDeployment{
+						{ComponentName: "component", GitOpsDeployment: "missing"},
+					},
+				},
+			}
+
+			returnedObjects, err := loader.GetGitOpsDeployments(ctx, k8sClient, binding)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedObjects).To(BeEmpty())
+		})
+	})
+
 	Context("When calling GetRelease", func() {
 		It("returns the requested release", func() {
 			returnedObject, err := loader.GetRelease(ctx, k8sClient, release.Name, release.Namespace)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(returnedObject).NotTo(Equal(&v1alpha1.Release{}))
+			Expect(returnedObject).NotTo(Equal(&v1alpha2.Release{}))
 			Expect(returnedObject.Name).To(Equal(release.Name))
 		})
 	})
@@ -192,6 +327,34 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		})
 	})
 
+	Context("When calling GetVerificationPipelineRun", func() {
+		It("returns nil if the Release has no verification PipelineRun", func() {
+			returnedObject, err := loader.GetVerificationPipelineRun(ctx, k8sClient, release)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedObject).To(BeNil())
+		})
+
+		It("returns the verification PipelineRun if the labels match with the release data", func() {
+			verificationPipelineRun := pipelineRun.DeepCopy()
+			verificationPipelineRun.ObjectMeta = metav1.ObjectMeta{
+				Labels: map[string]string{
+					tekton.PipelinesTypeLabel:    tekton.PipelineTypeVerification,
+					tekton.ReleaseNameLabel:      release.Name,
+					tekton.ReleaseNamespaceLabel: release.Namespace,
+				},
+				Name:      "verification-pipeline-run",
+				Namespace: "default",
+			}
+			Expect(k8sClient.Create(ctx, verificationPipelineRun)).To(Succeed())
+			defer func() { Expect(k8sClient.Delete(ctx, verificationPipelineRun)).To(Succeed()) }()
+
+			Eventually(func() bool {
+				returnedObject, err := loader.GetVerificationPipelineRun(ctx, k8sClient, release)
+				return err == nil && returnedObject != nil && returnedObject.Name == verificationPipelineRun.Name
+			}).Should(BeTrue())
+		})
+	})
+
 	Context("When calling GetReleasePlan", func() {
 		It("returns the requested release plan", func() {
 			returnedObject, err := loader.GetReleasePlan(ctx, k8sClient, release)
@@ -208,6 +371,245 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			Expect(returnedObject).NotTo(Equal(&v1alpha1.ReleaseStrategy{}))
 			Expect(returnedObject.Name).To(Equal(releaseStrategy.Name))
 		})
+
+		It("returns the referenced cluster release strategy in the release plan admission namespace", func() {
+			modifiedReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			modifiedReleasePlanAdmission.Spec.ReleaseStrategy = clusterReleaseStrategy.Name
+			modifiedReleasePlanAdmission.Spec.ReleaseStrategyKind = v1alpha1.ClusterReleaseStrategyKind
+
+			returnedObject, err := loader.GetReleaseStrategy(ctx, k8sClient, modifiedReleasePlanAdmission)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedObject.Name).To(Equal(clusterReleaseStrategy.Name))
+			Expect(returnedObject.Namespace).To(Equal(releasePlanAdmission.Namespace))
+			Expect(returnedObject.Spec.Pipeline).To(Equal(clusterReleaseStrategy.Spec.Pipeline))
+			Expect(returnedObject.Spec.ClusterReleaseStrategy).To(Equal(clusterReleaseStrategy.Name))
+		})
+
+		It("returns the release strategy merged with the cluster release strategy it inherits from", func() {
+			inheritingReleaseStrategy := &v1alpha1.ReleaseStrategy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "inheriting-release-strategy",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.ReleaseStrategySpec{
+					ClusterReleaseStrategy: clusterReleaseStrategy.Name,
+					Params: []v1alpha1.Params{
+						{Name: "foo", Value: "baz"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, inheritingReleaseStrategy)).To(Succeed())
+			defer k8sClient.Delete(ctx, inheritingReleaseStrategy)
+
+			modifiedReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			modifiedReleasePlanAdmission.Spec.ReleaseStrategy = inheritingReleaseStrategy.Name
+
+			returnedObject, err := loader.GetReleaseStrategy(ctx, k8sClient, modifiedReleasePlanAdmission)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedObject.Name).To(Equal(inheritingReleaseStrategy.Name))
+			Expect(returnedObject.Spec.Pipeline).To(Equal(clusterReleaseStrategy.Spec.Pipeline))
+			Expect(returnedObject.Spec.Policy).To(Equal(clusterReleaseStrategy.Spec.Policy))
+			Expect(returnedObject.Spec.Params).To(Equal([]v1alpha1.Params{
+				{Name: "foo", Value: "baz"},
+				{Name: "bar", Value: "bar"},
+			}))
+		})
+
+		It("fails if the cluster release strategy to inherit from doesn't exist", func() {
+			inheritingReleaseStrategy := &v1alpha1.ReleaseStrategy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "orphan-release-strategy",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.ReleaseStrategySpec{
+					ClusterReleaseStrategy: "non-existent",
+				},
+			}
+			Expect(k8sClient.Create(ctx, inheritingReleaseStrategy)).To(Succeed())
+			defer k8sClient.Delete(ctx, inheritingReleaseStrategy)
+
+			modifiedReleasePlanAdmission := releasePlanAdmission.DeepCopy()
+			modifiedReleasePlanAdmission.Spec.ReleaseStrategy = inheritingReleaseStrategy.Name
+
+			_, err := loader.GetReleaseStrategy(ctx, k8sClient, modifiedReleasePlanAdmission)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When calling GetReleaseStrategyParams", func() {
+		It("returns the params untouched if they don't reference any source", func() {
+			modifiedReleaseStrategy := releaseStrategy.DeepCopy()
+			modifiedReleaseStrategy.Spec.Params = []v1alpha1.Params{
+				{Name: "foo", Value: "bar"},
+				{Name: "baz", Values: []string{"qux"}},
+			}
+
+			params, err := loader.GetReleaseStrategyParams(ctx, k8sClient, modifiedReleaseStrategy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(Equal(modifiedReleaseStrategy.Spec.Params))
+		})
+
+		It("resolves params referencing a ConfigMap key to the value of that key", func() {
+			modifiedReleaseStrategy := releaseStrategy.DeepCopy()
+			modifiedReleaseStrategy.Spec.Params = []v1alpha1.Params{
+				{
+					Name: "registry",
+					ValueFrom: &v1alpha1.ParamValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+							Key:                  "registry",
+						},
+					},
+				},
+			}
+
+			params, err := loader.GetReleaseStrategyParams(ctx, k8sClient, modifiedReleaseStrategy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(HaveLen(1))
+			Expect(params[0].Name).To(Equal("registry"))
+			Expect(params[0].Value).To(Equal(configMap.Data["registry"]))
+			Expect(params[0].ValueFrom).To(BeNil())
+		})
+
+		It("resolves params referencing a Secret key to the name of the Secret", func() {
+			modifiedReleaseStrategy := releaseStrategy.DeepCopy()
+			modifiedReleaseStrategy.Spec.Params = []v1alpha1.Params{
+				{
+					Name: "credentials",
+					ValueFrom: &v1alpha1.ParamValueSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+							Key:                  "token",
+						},
+					},
+				},
+			}
+
+			params, err := loader.GetReleaseStrategyParams(ctx, k8sClient, modifiedReleaseStrategy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(HaveLen(1))
+			Expect(params[0].Value).To(Equal(secret.Name))
+		})
+
+		It("fails if a referenced key doesn't exist", func() {
+			modifiedReleaseStrategy := releaseStrategy.DeepCopy()
+			modifiedReleaseStrategy.Spec.Params = []v1alpha1.Params{
+				{
+					Name: "registry",
+					ValueFrom: &v1alpha1.ParamValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+							Key:                  "non-existent-key",
+						},
+					},
+				},
+			}
+
+			params, err := loader.GetReleaseStrategyParams(ctx, k8sClient, modifiedReleaseStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("key 'non-existent-key' not found in ConfigMap"))
+			Expect(params).To(BeNil())
+		})
+
+		It("fails if a referenced resource doesn't exist", func() {
+			modifiedReleaseStrategy := releaseStrategy.DeepCopy()
+			modifiedReleaseStrategy.Spec.Params = []v1alpha1.Params{
+				{
+					Name: "credentials",
+					ValueFrom: &v1alpha1.ParamValueSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "non-existent-secret"},
+							Key:                  "token",
+						},
+					},
+				},
+			}
+
+			_, err := loader.GetReleaseStrategyParams(ctx, k8sClient, modifiedReleaseStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to resolve param 'credentials'"))
+		})
+
+		It("doesn't fail if an optional reference doesn't exist", func() {
+			optional := true
+			modifiedReleaseStrategy := releaseStrategy.DeepCopy()
+			modifiedReleaseStrategy.Spec.Params = []v1alpha1.Params{
+				{
+					Name: "registry",
+					ValueFrom: &v1alpha1.ParamValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "non-existent-config-map"},
+							Key:                  "registry",
+							Optional:             &optional,
+						},
+					},
+				},
+			}
+
+			params, err := loader.GetReleaseStrategyParams(ctx, k8sClient, modifiedReleaseStrategy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(HaveLen(1))
+			Expect(params[0].Value).To(BeEmpty())
+		})
+	})
+
+	Context("When calling GetReleaseStrategyPipeline", func() {
+		It("returns the Pipeline referenced by name", func() {
+			pipeline := &v1beta1.Pipeline{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      releaseStrategy.Spec.Pipeline,
+					Namespace: releaseStrategy.Namespace,
+				},
+			}
+			Expect(k8sClient.Create(ctx, pipeline)).To(Succeed())
+			defer k8sClient.Delete(ctx, pipeline)
+
+			returnedObject, err := loader.GetReleaseStrategyPipeline(ctx, k8sClient, releaseStrategy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedObject.Name).To(Equal(pipeline.Name))
+		})
+
+		It("fails if the ResolutionRequest for a Pipeline referenced by bundle doesn't exist", func() {
+			modifiedReleaseStrategy := releaseStrategy.DeepCopy()
+			modifiedReleaseStrategy.Spec.Bundle = "quay.io/foo/bar:baz"
+
+			_, err := loader.GetReleaseStrategyPipeline(ctx, k8sClient, modifiedReleaseStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("returns ErrResolutionInProgress if the ResolutionRequest hasn't been resolved yet", func() {
+			modifiedReleaseStrategy := releaseStrategy.DeepCopy()
+			modifiedReleaseStrategy.Spec.Bundle = "quay.io/foo/bar:baz"
+
+			resolutionRequest := tekton.NewPipelineResolutionRequest(modifiedReleaseStrategy)
+			Expect(k8sClient.Create(ctx, resolutionRequest)).To(Succeed())
+			defer k8sClient.Delete(ctx, resolutionRequest)
+
+			Eventually(func() error {
+				_, err := loader.GetReleaseStrategyPipeline(ctx, k8sClient, modifiedReleaseStrategy)
+				return err
+			}).Should(Equal(tekton.ErrResolutionInProgress))
+		})
+	})
+
+	Context("When calling GetReleaseStrategyReleasePlanAdmissions", func() {
+		It("returns the ReleasePlanAdmissions referencing the ReleaseStrategy", func() {
+			returnedObjects, err := loader.GetReleaseStrategyReleasePlanAdmissions(ctx, k8sClient, releaseStrategy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedObjects).To(HaveLen(1))
+			Expect(returnedObjects[0].Name).To(Equal(releasePlanAdmission.Name))
+		})
+
+		It("returns an empty list if no ReleasePlanAdmission references the ReleaseStrategy", func() {
+			modifiedReleaseStrategy := releaseStrategy.DeepCopy()
+			modifiedReleaseStrategy.Name = "non-existent"
+
+			returnedObjects, err := loader.GetReleaseStrategyReleasePlanAdmissions(ctx, k8sClient, modifiedReleaseStrategy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedObjects).To(BeEmpty())
+		})
 	})
 
 	Context("When calling GetSnapshot", func() {
@@ -220,18 +622,18 @@ var _ = Describe("Release Adapter", Ordered, func() {
 	})
 
 	Context("When calling GetSnapshotEnvironmentBinding", func() {
-		It("returns a snapshot environment binding if the environment field value matches the release plan admission one", func() {
-			returnedObject, err := loader.GetSnapshotEnvironmentBinding(ctx, k8sClient, releasePlanAdmission)
+		It("returns a snapshot environment binding if the environment field value matches the given environment", func() {
+			returnedObject, err := loader.GetSnapshotEnvironmentBinding(ctx, k8sClient, environment, application)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(returnedObject).NotTo(Equal(&applicationapiv1alpha1.SnapshotEnvironmentBinding{}))
 			Expect(returnedObject.Name).To(Equal(snapshotEnvironmentBinding.Name))
 		})
 
-		It("fails to return a snapshot environment binding if the environment field value doesn't match the release plan admission one", func() {
-			modifiedReleasePlanAdmission := releasePlanAdmission.DeepCopy()
-			modifiedReleasePlanAdmission.Spec.Environment = "non-existing-environment"
+		It("fails to return a snapshot environment binding if the environment field value doesn't match the given environment", func() {
+			modifiedEnvironment := environment.DeepCopy()
+			modifiedEnvironment.Name = "non-existing-environment"
 
-			returnedObject, err := loader.GetSnapshotEnvironmentBinding(ctx, k8sClient, modifiedReleasePlanAdmission)
+			returnedObject, err := loader.GetSnapshotEnvironmentBinding(ctx, k8sClient, modifiedEnvironment, application)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(returnedObject).To(BeNil())
 		})
@@ -246,8 +648,8 @@ var _ = Describe("Release Adapter", Ordered, func() {
 
 		It("fails to return a snapshot environment binding if the environment field value doesn't match the release plan admission one", func() {
 			modifiedRelease := release.DeepCopy()
-			modifiedRelease.Status.SnapshotEnvironmentBinding = fmt.Sprintf("%s%c%s", snapshotEnvironmentBinding.Namespace,
-				types.Separator, snapshotEnvironmentBinding.Name)
+			modifiedRelease.Status.SnapshotEnvironmentBinding = v1alpha2.NewObjectReference(
+				snapshotEnvironmentBinding.Namespace, snapshotEnvironmentBinding.Name)
 
 			returnedObject, err := loader.GetSnapshotEnvironmentBindingFromReleaseStatus(ctx, k8sClient, modifiedRelease)
 			Expect(err).NotTo(HaveOccurred())
@@ -258,20 +660,20 @@ var _ = Describe("Release Adapter", Ordered, func() {
 
 	Context("When calling GetSnapshotEnvironmentBindingResources", func() {
 		It("returns all the relevant resources", func() {
-			resources, err := loader.GetSnapshotEnvironmentBindingResources(ctx, k8sClient, release, releasePlanAdmission)
+			resources, err := loader.GetSnapshotEnvironmentBindingResources(ctx, k8sClient, release, releasePlanAdmission,
+				environment.Name)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(*resources).To(MatchFields(IgnoreExtras, Fields{
 				"Application":           Not(BeNil()),
 				"ApplicationComponents": Not(BeNil()),
+				"Environment":           Not(BeNil()),
 				"Snapshot":              Not(BeNil()),
 			}))
 		})
 
 		It("fails if any resource fails to be fetched", func() {
-			modifiedReleasePlanAdmission := releasePlanAdmission.DeepCopy()
-			modifiedReleasePlanAdmission.Spec.Application = "non-existent-application"
-
-			_, err := loader.GetSnapshotEnvironmentBindingResources(ctx, k8sClient, release, modifiedReleasePlanAdmission)
+			_, err := loader.GetSnapshotEnvironmentBindingResources(ctx, k8sClient, release, releasePlanAdmission,
+				"non-existing-environment")
 			Expect(err).To(HaveOccurred())
 		})
 	})
@@ -300,6 +702,17 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		}
 		Expect(k8sClient.Create(ctx, component)).Should(Succeed())
 
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "config-map",
+				Namespace: "default",
+			},
+			Data: map[string]string{
+				"registry": "quay.io/redhat",
+			},
+		}
+		Expect(k8sClient.Create(ctx, configMap)).Should(Succeed())
+
 		enterpriseContractPolicy = &ecapiv1alpha1.EnterpriseContractPolicy{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "enterprise-contract-policy",
@@ -341,6 +754,21 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		}
 		Expect(k8sClient.Create(ctx, releasePlan)).To(Succeed())
 
+		clusterReleaseStrategy = &v1alpha1.ClusterReleaseStrategy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-release-strategy",
+			},
+			Spec: v1alpha1.ReleaseStrategySpec{
+				Pipeline: "cluster-release-pipeline",
+				Policy:   enterpriseContractPolicy.Name,
+				Params: []v1alpha1.Params{
+					{Name: "foo", Value: "bar"},
+					{Name: "bar", Value: "bar"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, clusterReleaseStrategy)).Should(Succeed())
+
 		releaseStrategy = &v1alpha1.ReleaseStrategy{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "release-strategy",
@@ -370,6 +798,17 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		}
 		Expect(k8sClient.Create(ctx, releasePlanAdmission)).Should(Succeed())
 
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "secret",
+				Namespace: "default",
+			},
+			StringData: map[string]string{
+				"token": "secret-token",
+			},
+		}
+		Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+
 		snapshot = &applicationapiv1alpha1.Snapshot{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "snapshot",
@@ -395,12 +834,12 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		}
 		Expect(k8sClient.Create(ctx, snapshotEnvironmentBinding)).To(Succeed())
 
-		release = &v1alpha1.Release{
+		release = &v1alpha2.Release{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "release",
 				Namespace: "default",
 			},
-			Spec: v1alpha1.ReleaseSpec{
+			Spec: v1alpha2.ReleaseSpec{
 				Snapshot:    snapshot.Name,
 				ReleasePlan: releasePlan.Name,
 			},
@@ -410,6 +849,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		pipelineRun = &v1beta1.PipelineRun{
 			ObjectMeta: metav1.ObjectMeta{
 				Labels: map[string]string{
+					tekton.PipelinesTypeLabel:    tekton.PipelineTypeRelease,
 					tekton.ReleaseNameLabel:      release.Name,
 					tekton.ReleaseNamespaceLabel: release.Namespace,
 				},
@@ -422,7 +862,9 @@ var _ = Describe("Release Adapter", Ordered, func() {
 
 	deleteResources = func() {
 		Expect(k8sClient.Delete(ctx, application)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, clusterReleaseStrategy)).To(Succeed())
 		Expect(k8sClient.Delete(ctx, component)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, configMap)).To(Succeed())
 		Expect(k8sClient.Delete(ctx, enterpriseContractPolicy)).To(Succeed())
 		Expect(k8sClient.Delete(ctx, environment)).To(Succeed())
 		Expect(k8sClient.Delete(ctx, pipelineRun)).To(Succeed())
@@ -430,6 +872,7 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		Expect(k8sClient.Delete(ctx, releasePlan)).To(Succeed())
 		Expect(k8sClient.Delete(ctx, releasePlanAdmission)).To(Succeed())
 		Expect(k8sClient.Delete(ctx, releaseStrategy)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
 		Expect(k8sClient.Delete(ctx, snapshot)).To(Succeed())
 		Expect(k8sClient.Delete(ctx, snapshotEnvironmentBinding)).To(Succeed())
 	}
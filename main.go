@@ -27,17 +27,26 @@ import (
 	ecapiv1alpha1 "github.com/hacbs-contract/enterprise-contract-controller/api/v1alpha1"
 	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 
 	appstudiov1alpha1 "github.com/redhat-appstudio/release-service/api/v1alpha1"
+	appstudiov1alpha2 "github.com/redhat-appstudio/release-service/api/v1alpha2"
 	"github.com/redhat-appstudio/release-service/controllers"
+	"github.com/redhat-appstudio/release-service/loader"
+	"github.com/redhat-appstudio/release-service/tekton"
+	"github.com/redhat-appstudio/release-service/webhooks"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -49,9 +58,12 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(appstudiov1alpha1.AddToScheme(scheme))
+	utilruntime.Must(appstudiov1alpha2.AddToScheme(scheme))
 	utilruntime.Must(applicationapiv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(ecapiv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(tektonv1.AddToScheme(scheme))
 	utilruntime.Must(tektonv1beta1.AddToScheme(scheme))
+	utilruntime.Must(resolutionv1beta1.AddToScheme(scheme))
 
 	//+kubebuilder:scaffold:scheme
 }
@@ -60,11 +72,19 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var tektonAPIVersion string
+	var strictReleaseValidation bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&tektonAPIVersion, "tekton-api-version", "",
+		"The Tekton API version used for release PipelineRuns (v1 or v1beta1). "+
+			"If not set, the most recent version served by the cluster will be used.")
+	flag.BoolVar(&strictReleaseValidation, "strict-release-validation", false,
+		"Reject new Releases whose ReleasePlan, ReleasePlanAdmission, ReleaseStrategy or Snapshot can't be resolved "+
+			"or whose target is disabled, instead of marking them as invalid once they are processed.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -73,37 +93,38 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	config := ctrl.GetConfigOrDie()
+
+	if tektonAPIVersion == "" {
+		discoveredVersion, err := tekton.DiscoverAPIVersion(config)
+		if err != nil {
+			setupLog.Error(err, "unable to discover the Tekton API version")
+			os.Exit(1)
+		}
+		tektonAPIVersion = string(discoveredVersion)
+	}
+	if err := tekton.SetAPIVersion(tekton.APIVersion(tektonAPIVersion)); err != nil {
+		setupLog.Error(err, "unable to set the Tekton API version")
+		os.Exit(1)
+	}
+	setupLog.Info("using Tekton API version", "version", tektonAPIVersion)
+
+	mgr, err := ctrl.NewManager(config, ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
 		Port:                   9443,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "f3d4c01a.redhat.com",
+		// Secrets referenced by ReleaseStrategy params are read directly from the API server, so the operator doesn't
+		// need to list and watch every Secret in the cluster
+		ClientDisableCacheFor: []client.Object{&corev1.Secret{}},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	// Set a default value for the DEFAULT_RELEASE_PVC environment variable
-	if os.Getenv("DEFAULT_RELEASE_PVC") == "" {
-		err := os.Setenv("DEFAULT_RELEASE_PVC", "release-pvc")
-		if err != nil {
-			setupLog.Error(err, "unable to setup DEFAULT_RELEASE_PVC environment variable")
-			os.Exit(1)
-		}
-	}
-
-	// Set a default value for the DEFAULT_RELEASE_WORKSPACE_NAME environment variable
-	if os.Getenv("DEFAULT_RELEASE_WORKSPACE_NAME") == "" {
-		err := os.Setenv("DEFAULT_RELEASE_WORKSPACE_NAME", "release-workspace")
-		if err != nil {
-			setupLog.Error(err, "unable to setup DEFAULT_RELEASE_WORKSPACE_NAME environment variable")
-			os.Exit(1)
-		}
-	}
-
 	err = controllers.SetupControllers(mgr)
 	if err != nil {
 		setupLog.Error(err, "unable to setup controllers")
@@ -113,7 +134,13 @@ func main() {
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		setupLog.Info("setting up webhooks")
 
-		if err = (&appstudiov1alpha1.Release{}).SetupWebhookWithManager(mgr); err != nil {
+		var strictReleaseValidator admission.CustomValidator
+		if strictReleaseValidation {
+			setupLog.Info("enabling strict Release validation")
+			strictReleaseValidator = webhooks.NewReleaseWebhook(mgr.GetClient(), loader.NewLoader())
+		}
+
+		if err = (&appstudiov1alpha1.Release{}).SetupWebhookWithManager(mgr, strictReleaseValidator); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Release")
 			os.Exit(1)
 		}
@@ -127,6 +154,31 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "ReleasePlan")
 			os.Exit(1)
 		}
+
+		if err = (&appstudiov1alpha1.ReleaseStrategy{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ReleaseStrategy")
+			os.Exit(1)
+		}
+
+		if err = (&appstudiov1alpha1.ClusterReleaseStrategy{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterReleaseStrategy")
+			os.Exit(1)
+		}
+
+		if err = webhooks.SetupEnterpriseContractPolicyWebhook(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "EnterpriseContractPolicy")
+			os.Exit(1)
+		}
+
+		if err = webhooks.SetupEnvironmentWebhook(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Environment")
+			os.Exit(1)
+		}
+
+		if err = webhooks.SetupSnapshotWebhook(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Snapshot")
+			os.Exit(1)
+		}
 	}
 
 	//+kubebuilder:scaffold:builder
@@ -19,7 +19,7 @@ package metadata
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"testing"
@@ -167,7 +167,7 @@ var _ = Describe("Metadata", func() {
 
 	Context("GetAnnotationsWithPrefix function", func() {
 		When("calling filterByPrefix with GetAnnotations()", func() {
-			release := &v1alpha1.Release{
+			release := &v1alpha2.Release{
 				ObjectMeta: v1.ObjectMeta{
 					Annotations: map[string]string{
 						"pet/dog": "bark",
@@ -189,7 +189,7 @@ var _ = Describe("Metadata", func() {
 
 	Context("GetLabelsWithPrefix function", func() {
 		When("calling filterByPrefix with GetLabels()", func() {
-			release := &v1alpha1.Release{
+			release := &v1alpha2.Release{
 				ObjectMeta: v1.ObjectMeta{
 					Annotations: map[string]string{
 						"foo": "bar",
@@ -32,12 +32,13 @@ var (
 		},
 	)
 
-	ReleaseAttemptDeploymentSeconds = prometheus.NewHistogram(
+	ReleaseAttemptDeploymentSeconds = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "release_attempt_deployment_seconds",
 			Help:    "Release durations from the moment the SnapshotEnvironmentBinding was created til the release is marked as deployed",
 			Buckets: []float64{10, 20, 40, 60, 120, 240, 360, 480, 600},
 		},
+		[]string{"environment"},
 	)
 
 	ReleaseAttemptDeploymentTotal = prometheus.NewCounterVec(
@@ -45,7 +46,7 @@ var (
 			Name: "release_attempt_deployment_total",
 			Help: "Total number of deployments released to managed environments by the operator",
 		},
-		[]string{"reason", "succeeded", "target"},
+		[]string{"environment", "reason", "succeeded", "target"},
 	)
 
 	ReleaseAttemptDurationSeconds = prometheus.NewHistogramVec(
@@ -65,6 +66,14 @@ var (
 		[]string{"reason"},
 	)
 
+	ReleaseAttemptRollbackTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "release_attempt_rollback_total",
+			Help: "Total number of environments rolled back after a failed deployment",
+		},
+		[]string{"environment", "target"},
+	)
+
 	ReleaseAttemptRunningSeconds = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "release_attempt_running_seconds",
@@ -99,16 +108,24 @@ func RegisterCompletedRelease(reason, strategy, target string, startTime, comple
 }
 
 // RegisterDeployedRelease increments the 'release_attempt_deployment_total' and registers a new observation for
-// 'release_attempt_deployment_seconds' with the elapsed time from the moment the SnapshotEnvironmentBinding was
-// created to when it was marked as deployed.
-func RegisterDeployedRelease(reason, target, succeeded string, startTime, completionTime *metav1.Time) {
+// 'release_attempt_deployment_seconds' with the elapsed time from the moment the SnapshotEnvironmentBinding of the
+// given environment was created to when it was marked as deployed or its deployment failed. The 'succeeded' label
+// keeps the condition status values ("True" or "False") it has always had, so existing dashboards and alerts still work.
+func RegisterDeployedRelease(reason, target, environment string, startTime, completionTime *metav1.Time, succeeded bool) {
+	status := metav1.ConditionFalse
+	if succeeded {
+		status = metav1.ConditionTrue
+	}
+
 	labels := prometheus.Labels{
-		"reason":    reason,
-		"succeeded": succeeded,
-		"target":    target,
+		"environment": environment,
+		"reason":      reason,
+		"succeeded":   string(status),
+		"target":      target,
 	}
 
-	ReleaseAttemptDeploymentSeconds.Observe(completionTime.Sub(startTime.Time).Seconds())
+	ReleaseAttemptDeploymentSeconds.With(prometheus.Labels{"environment": environment}).
+		Observe(completionTime.Sub(startTime.Time).Seconds())
 	ReleaseAttemptDeploymentTotal.With(labels).Inc()
 }
 
@@ -123,6 +140,14 @@ func RegisterInvalidRelease(reason string) {
 	}).Inc()
 }
 
+// RegisterRolledBackRelease increments the 'release_attempt_rollback_total' metric.
+func RegisterRolledBackRelease(target, environment string) {
+	ReleaseAttemptRollbackTotal.With(prometheus.Labels{
+		"environment": environment,
+		"target":      target,
+	}).Inc()
+}
+
 // RegisterNewRelease increments the 'release_attempt_concurrent_total' and registers a new observation for
 // 'release_attempt_duration_seconds' with the elapsed time from the moment the Release was created to when
 // it started (Release marked as 'Running').
@@ -138,6 +163,7 @@ func init() {
 		ReleaseAttemptDeploymentTotal,
 		ReleaseAttemptDurationSeconds,
 		ReleaseAttemptInvalidTotal,
+		ReleaseAttemptRollbackTotal,
 		ReleaseAttemptRunningSeconds,
 		ReleaseAttemptTotal,
 	)
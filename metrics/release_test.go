@@ -57,6 +57,10 @@ var _ = Describe("Metrics Release", Ordered, func() {
 			Name: "release_attempt_duration_seconds",
 			Help: "Release durations from the moment the release PipelineRun was created til the release is marked as finished",
 		}
+		AttemptRollbackTotalHeader = inputHeader{
+			Name: "release_attempt_rollback_total",
+			Help: "Total number of environments rolled back after a failed deployment",
+		}
 		AttemptTotalHeader = inputHeader{
 			Name: "release_attempt_total",
 			Help: "Total number of releases processed by the operator",
@@ -67,8 +71,9 @@ var _ = Describe("Metrics Release", Ordered, func() {
 		validReleaseReason   = "valid_release_reason"
 		invalidReleaseReason = "invalid_release_reason"
 		strategy             = "nostrategy"
+		deployEnvironment    = "production"
 		deployReason         = "CommitsSynced"
-		deploySuccess        = "True"
+		deploySuccess        = true
 	)
 
 	var defaultNamespace = "default"
@@ -195,12 +200,13 @@ var _ = Describe("Metrics Release", Ordered, func() {
 
 	Context("When RegisterDeployedRelease is called", func() {
 		BeforeAll(func() {
-			ReleaseAttemptDeploymentSeconds = prometheus.NewHistogram(
+			ReleaseAttemptDeploymentSeconds = prometheus.NewHistogramVec(
 				prometheus.HistogramOpts{
 					Name:    "release_attempt_deployment_seconds",
 					Help:    "Release durations from the moment the SnapshotEnvironmentBinding was created til the release is marked as deployed",
 					Buckets: []float64{1, 5, 10, 30},
 				},
+				[]string{"environment"},
 			)
 			metrics.Registry.MustRegister(ReleaseAttemptDeploymentSeconds)
 		})
@@ -218,12 +224,13 @@ var _ = Describe("Metrics Release", Ordered, func() {
 			for _, seconds := range inputSeconds {
 				completionTime := metav1.NewTime(startTime.Add(time.Second * time.Duration(seconds)))
 				elapsedSeconds += seconds
-				RegisterDeployedRelease(deployReason, "", deploySuccess, &startTime, &completionTime)
+				RegisterDeployedRelease(deployReason, "", deployEnvironment, &startTime, &completionTime, deploySuccess)
 			}
 
-			labels := fmt.Sprintf(`reason="%s", succeeded="%s", target="",`, deployReason, deploySuccess)
+			labels := fmt.Sprintf(`environment="%s", reason="%s", succeeded="True", target="",`, deployEnvironment,
+				deployReason)
 			readerData := createCounterReader(AttemptDeploymentTotalHeader, labels, true, len(inputSeconds))
-			Expect(testutil.CollectAndCompare(ReleaseAttemptDeploymentTotal.WithLabelValues("CommitsSynced", "True", ""),
+			Expect(testutil.CollectAndCompare(ReleaseAttemptDeploymentTotal.WithLabelValues(deployEnvironment, "CommitsSynced", "True", ""),
 				strings.NewReader(readerData))).To(Succeed())
 		})
 
@@ -232,7 +239,8 @@ var _ = Describe("Metrics Release", Ordered, func() {
 			// Defined buckets for ReleaseAttemptDeploymentSeconds
 			timeBuckets := []string{"1", "5", "10", "30"}
 			data := []int{1, 2, 3, 4}
-			readerData := createHistogramReader(AttemptDeploymentSecondsHeader, timeBuckets, data, "", elapsedSeconds, len(inputSeconds))
+			labels := fmt.Sprintf(`environment="%s",`, deployEnvironment)
+			readerData := createHistogramReader(AttemptDeploymentSecondsHeader, timeBuckets, data, labels, elapsedSeconds, len(inputSeconds))
 			Expect(testutil.CollectAndCompare(ReleaseAttemptDeploymentSeconds, strings.NewReader(readerData))).To(Succeed())
 		})
 	})
@@ -252,4 +260,17 @@ var _ = Describe("Metrics Release", Ordered, func() {
 				strings.NewReader(readerData))).To(Succeed())
 		})
 	})
+
+	Context("When RegisterRolledBackRelease is called", func() {
+		It("increments the 'ReleaseAttemptRollbackTotal' metric.", func() {
+			for i := 0; i < 3; i++ {
+				RegisterRolledBackRelease("", deployEnvironment)
+			}
+
+			labels := fmt.Sprintf(`environment="%s", target="",`, deployEnvironment)
+			readerData := createCounterReader(AttemptRollbackTotalHeader, labels, true, 3.0)
+			Expect(testutil.CollectAndCompare(ReleaseAttemptRollbackTotal.WithLabelValues(deployEnvironment, ""),
+				strings.NewReader(readerData))).To(Succeed())
+		})
+	})
 })
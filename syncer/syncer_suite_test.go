@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	appstudiov1alpha1 "github.com/redhat-appstudio/release-service/api/v1alpha1"
+	appstudiov1alpha2 "github.com/redhat-appstudio/release-service/api/v1alpha2"
 	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -76,6 +77,9 @@ var _ = BeforeSuite(func() {
 	err = appstudiov1alpha1.AddToScheme(clientsetscheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = appstudiov1alpha2.AddToScheme(clientsetscheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = applicationapiv1alpha1.AddToScheme(clientsetscheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -93,7 +97,7 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = (&appstudiov1alpha1.Release{}).SetupWebhookWithManager(k8sManager)
+	err = (&appstudiov1alpha1.Release{}).SetupWebhookWithManager(k8sManager, nil)
 	Expect(err).NotTo(HaveOccurred())
 })
 
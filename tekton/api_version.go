@@ -0,0 +1,176 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton
+
+import (
+	"context"
+	"fmt"
+
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// APIVersion represents a version of the Tekton Pipelines API used to create and watch release PipelineRuns.
+type APIVersion string
+
+const (
+	// APIVersionV1 is the tekton.dev/v1 API version
+	APIVersionV1 APIVersion = "v1"
+
+	// APIVersionV1beta1 is the tekton.dev/v1beta1 API version
+	APIVersionV1beta1 APIVersion = "v1beta1"
+)
+
+// apiVersion is the Tekton API version in use. It should be set once at startup by calling SetAPIVersion.
+var apiVersion = APIVersionV1beta1
+
+// GetAPIVersion returns the Tekton API version in use.
+func GetAPIVersion() APIVersion {
+	return apiVersion
+}
+
+// SetAPIVersion sets the Tekton API version to use. An error will be returned if the version is not supported.
+func SetAPIVersion(version APIVersion) error {
+	if version != APIVersionV1 && version != APIVersionV1beta1 {
+		return fmt.Errorf("unsupported Tekton API version '%s'", version)
+	}
+
+	apiVersion = version
+
+	return nil
+}
+
+// DiscoverAPIVersion returns the most recent Tekton API version serving PipelineRuns in the cluster. As Tekton serves
+// every PipelineRun through all its served versions, PipelineRuns created using v1beta1 will still be visible when
+// using v1.
+func DiscoverAPIVersion(config *rest.Config) (APIVersion, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return "", err
+	}
+
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(tektonv1.SchemeGroupVersion.String())
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return APIVersionV1beta1, nil
+		}
+
+		return "", err
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == "pipelineruns" {
+			return APIVersionV1, nil
+		}
+	}
+
+	return APIVersionV1beta1, nil
+}
+
+// NewPipelineRunObject returns an empty PipelineRun of the Tekton API version in use, so it can be used in watches.
+func NewPipelineRunObject() client.Object {
+	if apiVersion == APIVersionV1 {
+		return &tektonv1.PipelineRun{}
+	}
+
+	return &tektonv1beta1.PipelineRun{}
+}
+
+// NewPipelineRunList returns an empty PipelineRunList of the Tekton API version in use, so it can be used in List
+// operations. Its items can be retrieved using GetPipelineRunsFromList.
+func NewPipelineRunList() client.ObjectList {
+	if apiVersion == APIVersionV1 {
+		return &tektonv1.PipelineRunList{}
+	}
+
+	return &tektonv1beta1.PipelineRunList{}
+}
+
+// GetPipelineRunsFromList returns the items of a PipelineRunList of any supported Tekton API version as v1beta1
+// PipelineRuns.
+func GetPipelineRunsFromList(list client.ObjectList) ([]tektonv1beta1.PipelineRun, error) {
+	switch pipelineRunList := list.(type) {
+	case *tektonv1beta1.PipelineRunList:
+		return pipelineRunList.Items, nil
+	case *tektonv1.PipelineRunList:
+		pipelineRuns := make([]tektonv1beta1.PipelineRun, 0, len(pipelineRunList.Items))
+		for i := range pipelineRunList.Items {
+			pipelineRun, err := FromVersionedPipelineRun(&pipelineRunList.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			pipelineRuns = append(pipelineRuns, *pipelineRun)
+		}
+
+		return pipelineRuns, nil
+	default:
+		return nil, fmt.Errorf("unsupported PipelineRunList type %T", list)
+	}
+}
+
+// ToVersionedPipelineRun converts the given v1beta1 PipelineRun to the Tekton API version in use, so it can be used
+// in Create and Delete operations.
+func ToVersionedPipelineRun(pipelineRun *tektonv1beta1.PipelineRun) (client.Object, error) {
+	if apiVersion != APIVersionV1 {
+		return pipelineRun, nil
+	}
+
+	versionedPipelineRun := &tektonv1.PipelineRun{}
+	if err := pipelineRun.ConvertTo(context.Background(), versionedPipelineRun); err != nil {
+		return nil, err
+	}
+	versionedPipelineRun.Status.Status = pipelineRun.Status.Status
+
+	// The upstream conversion of bundle references sets the resolver kind to task and keeps the Pipeline name
+	if pipelineRef := pipelineRun.Spec.PipelineRef; pipelineRef != nil && pipelineRef.Bundle != "" {
+		versionedPipelineRun.Spec.PipelineRef = &tektonv1.PipelineRef{
+			ResolverRef: tektonv1.ResolverRef{
+				Resolver: "bundles",
+				Params: []tektonv1.Param{
+					{Name: "bundle", Value: *tektonv1.NewStructuredValues(pipelineRef.Bundle)},
+					{Name: "name", Value: *tektonv1.NewStructuredValues(pipelineRef.Name)},
+					{Name: "kind", Value: *tektonv1.NewStructuredValues("pipeline")},
+				},
+			},
+		}
+	}
+
+	return versionedPipelineRun, nil
+}
+
+// FromVersionedPipelineRun converts a PipelineRun of any supported Tekton API version to a v1beta1 PipelineRun. Only
+// the status conditions are kept from the PipelineRun status.
+func FromVersionedPipelineRun(object client.Object) (*tektonv1beta1.PipelineRun, error) {
+	switch versionedPipelineRun := object.(type) {
+	case *tektonv1beta1.PipelineRun:
+		return versionedPipelineRun, nil
+	case *tektonv1.PipelineRun:
+		pipelineRun := &tektonv1beta1.PipelineRun{}
+		if err := pipelineRun.ConvertFrom(context.Background(), versionedPipelineRun); err != nil {
+			return nil, err
+		}
+		pipelineRun.Status.Status = versionedPipelineRun.Status.Status
+
+		return pipelineRun, nil
+	default:
+		return nil, fmt.Errorf("unsupported PipelineRun type %T", object)
+	}
+}
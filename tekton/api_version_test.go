@@ -0,0 +1,143 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/apis"
+)
+
+var _ = Describe("Tekton API version", func() {
+	var releasePipelineRun *ReleasePipelineRun
+
+	BeforeEach(func() {
+		releasePipelineRun = NewReleasePipelineRun("test-pipeline", "default").
+			WithReleaseStrategy(&v1alpha1.ReleaseStrategy{
+				Spec: v1alpha1.ReleaseStrategySpec{
+					Pipeline:       "release-pipeline",
+					ServiceAccount: "service-account",
+				},
+			}).
+			WithExtraParam("foo", *tektonv1beta1.NewArrayOrString("bar"))
+		releasePipelineRun.Name = "test-pipeline-run"
+		releasePipelineRun.Labels = map[string]string{PipelinesTypeLabel: PipelineTypeRelease}
+		releasePipelineRun.Status.InitializeConditions(clock.RealClock{})
+		releasePipelineRun.Status.MarkSucceeded("Succeeded", "Tests succeeded")
+	})
+
+	AfterEach(func() {
+		Expect(SetAPIVersion(APIVersionV1beta1)).To(Succeed())
+	})
+
+	Context("When SetAPIVersion is called", func() {
+		It("should set the API version if it's supported", func() {
+			Expect(SetAPIVersion(APIVersionV1)).To(Succeed())
+			Expect(GetAPIVersion()).To(Equal(APIVersionV1))
+		})
+
+		It("should fail if the API version is not supported", func() {
+			Expect(SetAPIVersion("v1alpha1")).To(MatchError("unsupported Tekton API version 'v1alpha1'"))
+			Expect(GetAPIVersion()).To(Equal(APIVersionV1beta1))
+		})
+	})
+
+	Context("When the v1beta1 API version is in use", func() {
+		It("should return v1beta1 objects", func() {
+			Expect(NewPipelineRunObject()).To(BeAssignableToTypeOf(&tektonv1beta1.PipelineRun{}))
+			Expect(NewPipelineRunList()).To(BeAssignableToTypeOf(&tektonv1beta1.PipelineRunList{}))
+
+			versionedPipelineRun, err := ToVersionedPipelineRun(releasePipelineRun.AsPipelineRun())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versionedPipelineRun).To(Equal(releasePipelineRun.AsPipelineRun()))
+		})
+	})
+
+	Context("When the v1 API version is in use", func() {
+		BeforeEach(func() {
+			Expect(SetAPIVersion(APIVersionV1)).To(Succeed())
+		})
+
+		It("should return v1 objects", func() {
+			Expect(NewPipelineRunObject()).To(BeAssignableToTypeOf(&tektonv1.PipelineRun{}))
+			Expect(NewPipelineRunList()).To(BeAssignableToTypeOf(&tektonv1.PipelineRunList{}))
+		})
+
+		It("should convert v1beta1 PipelineRuns to v1 and back", func() {
+			versionedPipelineRun, err := ToVersionedPipelineRun(releasePipelineRun.AsPipelineRun())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versionedPipelineRun).To(BeAssignableToTypeOf(&tektonv1.PipelineRun{}))
+
+			v1PipelineRun := versionedPipelineRun.(*tektonv1.PipelineRun)
+			Expect(v1PipelineRun.Name).To(Equal(releasePipelineRun.Name))
+			Expect(v1PipelineRun.Spec.PipelineRef.Name).To(Equal("release-pipeline"))
+			Expect(v1PipelineRun.Spec.TaskRunTemplate.ServiceAccountName).To(Equal("service-account"))
+			Expect(v1PipelineRun.Spec.Params).To(HaveLen(1))
+			Expect(v1PipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsTrue()).To(BeTrue())
+
+			pipelineRun, err := FromVersionedPipelineRun(v1PipelineRun)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipelineRun.Name).To(Equal(releasePipelineRun.Name))
+			Expect(pipelineRun.Spec.ServiceAccountName).To(Equal("service-account"))
+			Expect(pipelineRun.IsDone()).To(BeTrue())
+			Expect(pipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsTrue()).To(BeTrue())
+		})
+
+		It("should reference bundled Pipelines through the bundles resolver", func() {
+			releasePipelineRun.Spec.PipelineRef.Bundle = "quay.io/foo/bar:baz"
+
+			versionedPipelineRun, err := ToVersionedPipelineRun(releasePipelineRun.AsPipelineRun())
+			Expect(err).NotTo(HaveOccurred())
+
+			pipelineRef := versionedPipelineRun.(*tektonv1.PipelineRun).Spec.PipelineRef
+			Expect(pipelineRef.Name).To(BeEmpty())
+			Expect(pipelineRef.Resolver).To(Equal(tektonv1.ResolverName("bundles")))
+			Expect(pipelineRef.Params).To(ContainElements(
+				tektonv1.Param{Name: "bundle", Value: *tektonv1.NewStructuredValues("quay.io/foo/bar:baz")},
+				tektonv1.Param{Name: "name", Value: *tektonv1.NewStructuredValues("release-pipeline")},
+				tektonv1.Param{Name: "kind", Value: *tektonv1.NewStructuredValues("pipeline")},
+			))
+		})
+
+		It("should return the items of a v1 PipelineRunList as v1beta1 PipelineRuns", func() {
+			versionedPipelineRun, err := ToVersionedPipelineRun(releasePipelineRun.AsPipelineRun())
+			Expect(err).NotTo(HaveOccurred())
+
+			pipelineRuns, err := GetPipelineRunsFromList(&tektonv1.PipelineRunList{
+				Items: []tektonv1.PipelineRun{*versionedPipelineRun.(*tektonv1.PipelineRun)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipelineRuns).To(HaveLen(1))
+			Expect(pipelineRuns[0].Name).To(Equal(releasePipelineRun.Name))
+		})
+	})
+
+	Context("When unsupported objects are passed", func() {
+		It("should return an error", func() {
+			_, err := FromVersionedPipelineRun(&tektonv1beta1.TaskRun{})
+			Expect(err).To(HaveOccurred())
+
+			_, err = GetPipelineRunsFromList(&tektonv1beta1.TaskRunList{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
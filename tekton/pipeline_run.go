@@ -19,7 +19,6 @@ package tekton
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"unicode"
 
 	ecapiv1alpha1 "github.com/hacbs-contract/enterprise-contract-controller/api/v1alpha1"
@@ -29,8 +28,9 @@ import (
 	libhandler "github.com/operator-framework/operator-lib/handler"
 	integrationServiceGitopsPkg "github.com/redhat-appstudio/integration-service/gitops"
 	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
-	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -49,6 +49,9 @@ const (
 
 	//PipelineTypeRelease is the type for PipelineRuns created to run a release Pipeline
 	PipelineTypeRelease = "release"
+
+	// PipelineTypeVerification is the type for PipelineRuns created to verify the deployment of a Release
+	PipelineTypeVerification = "verification"
 )
 
 var (
@@ -65,6 +68,10 @@ var (
 	ReleaseNamespaceLabel = fmt.Sprintf("%s/%s", releaseLabelPrefix, "namespace")
 )
 
+// ServiceProvidedParams are the names of the params added to every release PipelineRun by WithSnapshot and
+// WithEnterpriseContractPolicy, so release Pipelines can require them without ReleaseStrategies setting them.
+var ServiceProvidedParams = []string{"enterpriseContractPolicy", "snapshot"}
+
 // ReleasePipelineRun is a PipelineRun alias, so we can add new methods to it in this file.
 type ReleasePipelineRun struct {
 	tektonv1beta1.PipelineRun
@@ -135,14 +142,14 @@ func (r *ReleasePipelineRun) WithEnterpriseContractPolicy(enterpriseContractPoli
 }
 
 // WithOwner set's owner annotations to the release PipelineRun.
-func (r *ReleasePipelineRun) WithOwner(release *v1alpha1.Release) *ReleasePipelineRun {
+func (r *ReleasePipelineRun) WithOwner(release *v1alpha2.Release) *ReleasePipelineRun {
 	_ = libhandler.SetOwnerAnnotations(release, r)
 
 	return r
 }
 
 // WithReleaseAndApplicationMetadata adds Release and Application metadata to the release PipelineRun.
-func (r *ReleasePipelineRun) WithReleaseAndApplicationMetadata(release *v1alpha1.Release, applicationName string) *ReleasePipelineRun {
+func (r *ReleasePipelineRun) WithReleaseAndApplicationMetadata(release *v1alpha2.Release, applicationName string) *ReleasePipelineRun {
 	r.ObjectMeta.Labels = map[string]string{
 		PipelinesTypeLabel:    PipelineTypeRelease,
 		ReleaseNameLabel:      release.Name,
@@ -155,34 +162,138 @@ func (r *ReleasePipelineRun) WithReleaseAndApplicationMetadata(release *v1alpha1
 	return r
 }
 
-// WithReleaseStrategy adds Pipeline reference and parameters to the release PipelineRun.
-func (r *ReleasePipelineRun) WithReleaseStrategy(strategy *v1alpha1.ReleaseStrategy) *ReleasePipelineRun {
-	r.Spec.PipelineRef = &tektonv1beta1.PipelineRef{
-		Name:   strategy.Spec.Pipeline,
-		Bundle: strategy.Spec.Bundle,
+// WithPipelineType sets the type label of the PipelineRun, replacing the one set by WithReleaseAndApplicationMetadata.
+func (r *ReleasePipelineRun) WithPipelineType(pipelineType string) *ReleasePipelineRun {
+	if r.ObjectMeta.Labels == nil {
+		r.ObjectMeta.Labels = map[string]string{}
 	}
+	r.ObjectMeta.Labels[PipelinesTypeLabel] = pipelineType
 
-	valueType := tektonv1beta1.ParamTypeString
+	return r
+}
 
-	for _, param := range strategy.Spec.Params {
-		if len(param.Values) > 0 {
-			valueType = tektonv1beta1.ParamTypeArray
+// WithRequester copies the annotations recording who created the given Release to the release PipelineRun.
+func (r *ReleasePipelineRun) WithRequester(release *v1alpha2.Release) *ReleasePipelineRun {
+	annotations := map[string]string{}
+	for _, annotation := range v1alpha1.RequesterAnnotations {
+		if value, found := release.GetAnnotations()[annotation]; found {
+			annotations[annotation] = value
 		}
+	}
+	metadata.AddAnnotations(r.AsPipelineRun(), annotations)
 
-		r.WithExtraParam(param.Name, tektonv1beta1.ArrayOrString{
-			Type:      valueType,
-			StringVal: param.Value,
-			ArrayVal:  param.Values,
-		})
+	return r
+}
+
+// WithParams adds the given params to the release PipelineRun. If a param with the same name was already added, its
+// value will be replaced.
+func (r *ReleasePipelineRun) WithParams(params []v1alpha1.Params) *ReleasePipelineRun {
+	for _, param := range params {
+		value := getParamValue(param)
+
+		replaced := false
+		for i := range r.Spec.Params {
+			if r.Spec.Params[i].Name == param.Name {
+				r.Spec.Params[i].Value = value
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			r.WithExtraParam(param.Name, value)
+		}
 	}
 
-	if strategy.Spec.PersistentVolumeClaim == "" {
-		r.WithWorkspace(os.Getenv("DEFAULT_RELEASE_WORKSPACE_NAME"), os.Getenv("DEFAULT_RELEASE_PVC"))
-	} else {
-		r.WithWorkspace(os.Getenv("DEFAULT_RELEASE_WORKSPACE_NAME"), strategy.Spec.PersistentVolumeClaim)
+	return r
+}
+
+// WithReleaseStrategy adds Pipeline reference and parameters to the release PipelineRun.
+func (r *ReleasePipelineRun) WithReleaseStrategy(strategy *v1alpha1.ReleaseStrategy) *ReleasePipelineRun {
+	r.Spec.PipelineRef = getPipelineRef(strategy)
+
+	for _, param := range strategy.Spec.Params {
+		r.WithExtraParam(param.Name, getParamValue(param))
+	}
+
+	for _, workspace := range strategy.GetWorkspaces() {
+		r.WithWorkspace(workspace)
 	}
 
 	r.WithServiceAccount(strategy.Spec.ServiceAccount)
+	r.WithPodTemplate(strategy.Spec.PodTemplate)
+
+	for _, taskRunSpec := range strategy.Spec.TaskRunSpecs {
+		r.WithTaskRunSpec(taskRunSpec)
+	}
+
+	return r
+}
+
+// WithVerificationPipeline adds the Pipeline reference, parameters and service account of the given verification
+// Pipeline to the PipelineRun.
+func (r *ReleasePipelineRun) WithVerificationPipeline(pipeline *v1alpha1.VerificationPipeline) *ReleasePipelineRun {
+	r.Spec.PipelineRef = getResolverPipelineRef(&pipeline.PipelineRef)
+
+	for _, param := range pipeline.Params {
+		r.WithExtraParam(param.Name, getParamValue(param))
+	}
+
+	r.WithServiceAccount(pipeline.ServiceAccount)
+
+	return r
+}
+
+// WithEnvironment adds a param containing the name of the given Environment to the PipelineRun.
+func (r *ReleasePipelineRun) WithEnvironment(environment string) *ReleasePipelineRun {
+	r.WithExtraParam("environment", tektonv1beta1.ArrayOrString{
+		Type:      tektonv1beta1.ParamTypeString,
+		StringVal: environment,
+	})
+
+	return r
+}
+
+// WithPodTemplate sets the pod template used for the pods of the release PipelineRun. If the given template is nil,
+// no changes will be made. Environment variables are not part of the template yet, as the pod template of the Tekton
+// version in use (v0.41) has no env field to map them to.
+func (r *ReleasePipelineRun) WithPodTemplate(podTemplate *v1alpha1.PodTemplate) *ReleasePipelineRun {
+	if podTemplate == nil {
+		return r
+	}
+
+	r.Spec.PodTemplate = &pod.PodTemplate{
+		NodeSelector:    podTemplate.NodeSelector,
+		Tolerations:     podTemplate.Tolerations,
+		Affinity:        podTemplate.Affinity,
+		SecurityContext: podTemplate.SecurityContext,
+	}
+
+	if podTemplate.PriorityClassName != "" {
+		priorityClassName := podTemplate.PriorityClassName
+		r.Spec.PodTemplate.PriorityClassName = &priorityClassName
+	}
+
+	return r
+}
+
+// WithTaskRunSpec overrides the service account and compute resources of a specific task of the release Pipeline,
+// replacing any existing override for the same task.
+func (r *ReleasePipelineRun) WithTaskRunSpec(taskRunSpec v1alpha1.TaskRunSpec) *ReleasePipelineRun {
+	pipelineTaskRunSpec := tektonv1beta1.PipelineTaskRunSpec{
+		PipelineTaskName:       taskRunSpec.PipelineTaskName,
+		TaskServiceAccountName: taskRunSpec.ServiceAccount,
+		ComputeResources:       taskRunSpec.ComputeResources,
+	}
+
+	for i, existingTaskRunSpec := range r.Spec.TaskRunSpecs {
+		if existingTaskRunSpec.PipelineTaskName == taskRunSpec.PipelineTaskName {
+			r.Spec.TaskRunSpecs[i] = pipelineTaskRunSpec
+
+			return r
+		}
+	}
+
+	r.Spec.TaskRunSpecs = append(r.Spec.TaskRunSpecs, pipelineTaskRunSpec)
 
 	return r
 }
@@ -195,19 +306,28 @@ func (r *ReleasePipelineRun) WithServiceAccount(serviceAccount string) *ReleaseP
 	return r
 }
 
-// WithWorkspace adds a workspace to the PipelineRun using the given name and PersistentVolumeClaim.
-// If any of those values is empty, no workspace will be added.
-func (r *ReleasePipelineRun) WithWorkspace(name, persistentVolumeClaim string) *ReleasePipelineRun {
-	if name == "" || persistentVolumeClaim == "" {
-		return r
+// WithWorkspace binds the given workspace to the PipelineRun, replacing any existing workspace with the same name.
+func (r *ReleasePipelineRun) WithWorkspace(workspace v1alpha1.Workspace) *ReleasePipelineRun {
+	workspaceBinding := tektonv1beta1.WorkspaceBinding{
+		Name:                  workspace.Name,
+		SubPath:               workspace.SubPath,
+		PersistentVolumeClaim: workspace.PersistentVolumeClaim,
+		VolumeClaimTemplate:   workspace.VolumeClaimTemplate,
+		EmptyDir:              workspace.EmptyDir,
+		ConfigMap:             workspace.ConfigMap,
+		Secret:                workspace.Secret,
+		CSI:                   workspace.CSI,
 	}
 
-	r.Spec.Workspaces = append(r.Spec.Workspaces, tektonv1beta1.WorkspaceBinding{
-		Name: name,
-		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-			ClaimName: persistentVolumeClaim,
-		},
-	})
+	for i, existingWorkspace := range r.Spec.Workspaces {
+		if existingWorkspace.Name == workspace.Name {
+			r.Spec.Workspaces[i] = workspaceBinding
+
+			return r
+		}
+	}
+
+	r.Spec.Workspaces = append(r.Spec.Workspaces, workspaceBinding)
 
 	return r
 }
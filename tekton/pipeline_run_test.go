@@ -19,7 +19,6 @@ package tekton
 import (
 	"context"
 	"encoding/json"
-	"os"
 	"reflect"
 
 	ecapiv1alpha1 "github.com/hacbs-contract/enterprise-contract-controller/api/v1alpha1"
@@ -29,8 +28,11 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
 
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -50,7 +52,7 @@ var _ = Describe("PipelineRun", func() {
 		applicationName       = "test-application"
 	)
 	var (
-		release                  *v1alpha1.Release
+		release                  *v1alpha2.Release
 		extraParams              *ExtraParams
 		releasePipelineRun       *ReleasePipelineRun
 		snapshot                 *applicationapiv1alpha1.Snapshot
@@ -67,7 +69,7 @@ var _ = Describe("PipelineRun", func() {
 				StringVal: "path/to/extra/config.yaml",
 			},
 		}
-		release = &v1alpha1.Release{
+		release = &v1alpha2.Release{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: apiVersion,
 				Kind:       "Release",
@@ -76,7 +78,7 @@ var _ = Describe("PipelineRun", func() {
 				GenerateName: "myrelease-",
 				Namespace:    namespace,
 			},
-			Spec: v1alpha1.ReleaseSpec{
+			Spec: v1alpha2.ReleaseSpec{
 				Snapshot:    "testsnapshot",
 				ReleasePlan: "testreleaseplan",
 			},
@@ -133,7 +135,7 @@ var _ = Describe("PipelineRun", func() {
 		ctx := context.Background()
 
 		// The code below sets the ownership for the Release Object
-		kind := reflect.TypeOf(v1alpha1.Release{}).Name()
+		kind := reflect.TypeOf(v1alpha2.Release{}).Name()
 		gvk := v1alpha1.GroupVersion.WithKind(kind)
 		controllerRef := metav1.NewControllerRef(release, gvk)
 
@@ -186,6 +188,27 @@ var _ = Describe("PipelineRun", func() {
 				To(Equal(applicationName))
 		})
 
+		It("can replace the type label set along with the release metadata", func() {
+			releasePipelineRun.WithReleaseAndApplicationMetadata(release, applicationName).
+				WithPipelineType(PipelineTypeVerification)
+			Expect(releasePipelineRun.Labels).To(HaveKeyWithValue(PipelinesTypeLabel, PipelineTypeVerification))
+			Expect(releasePipelineRun.Labels).To(HaveKeyWithValue(ReleaseNameLabel, release.Name))
+		})
+
+		It("can copy the requester annotations of a Release to a ReleasePipelineRun object", func() {
+			requesterRelease := release.DeepCopy()
+			requesterRelease.Annotations = map[string]string{
+				v1alpha1.RequesterAnnotation:               "user",
+				v1alpha1.RequesterServiceAccountAnnotation: "false",
+				"foo": "bar",
+			}
+			releasePipelineRun.WithRequester(requesterRelease)
+			Expect(releasePipelineRun.Annotations).To(HaveKeyWithValue(v1alpha1.RequesterAnnotation, "user"))
+			Expect(releasePipelineRun.Annotations).To(HaveKeyWithValue(v1alpha1.RequesterServiceAccountAnnotation, "false"))
+			Expect(releasePipelineRun.Annotations).NotTo(HaveKey(v1alpha1.RequesterGroupsAnnotation))
+			Expect(releasePipelineRun.Annotations).NotTo(HaveKey("foo"))
+		})
+
 		It("can return a PipelineRun object from a ReleasePipelineRun object", func() {
 			Expect(reflect.TypeOf(releasePipelineRun.AsPipelineRun())).
 				To(Equal(reflect.TypeOf(&tektonv1beta1.PipelineRun{})))
@@ -214,17 +237,122 @@ var _ = Describe("PipelineRun", func() {
 				To(Equal("release-pipeline"))
 		})
 
+		It("can add params to the PipelineRun replacing the ones with the same name", func() {
+			releasePipelineRun.WithReleaseStrategy(strategy)
+			releasePipelineRun.WithParams([]v1alpha1.Params{
+				{Name: "testparam1", Value: "override"},
+				{Name: "testparam2", Value: "new"},
+			})
+			Expect(releasePipelineRun.Spec.Params).To(HaveLen(2))
+			Expect(releasePipelineRun.Spec.Params).Should(ContainElement(HaveField("Value.StringVal", Equal("override"))))
+			Expect(releasePipelineRun.Spec.Params).Should(ContainElement(HaveField("Name", Equal("testparam2"))))
+		})
+
+		It("can add the verification Pipeline information and the Environment to a PipelineRun object", func() {
+			releasePipelineRun.WithVerificationPipeline(&v1alpha1.VerificationPipeline{
+				PipelineRef: v1alpha1.PipelineRef{
+					Resolver: "git",
+					Params: []v1alpha1.ResolverParam{
+						{Name: "pathInRepo", Value: "pipelines/smoke-tests.yaml"},
+					},
+				},
+				Params:         []v1alpha1.Params{{Name: "url", Value: "https://example.com"}},
+				ServiceAccount: serviceAccountName,
+			}).WithEnvironment("production")
+
+			Expect(string(releasePipelineRun.Spec.PipelineRef.Resolver)).To(Equal("git"))
+			Expect(releasePipelineRun.Spec.PipelineRef.Params).To(HaveLen(1))
+			Expect(releasePipelineRun.Spec.ServiceAccountName).To(Equal(serviceAccountName))
+			Expect(releasePipelineRun.Spec.Params).To(ContainElement(HaveField("Value.StringVal", Equal("https://example.com"))))
+			Expect(releasePipelineRun.Spec.Params).To(ContainElement(And(
+				HaveField("Name", Equal("environment")),
+				HaveField("Value.StringVal", Equal("production")),
+			)))
+		})
+
 		It("can add the reference to the service account that should be used", func() {
 			releasePipelineRun.WithServiceAccount(serviceAccountName)
 			Expect(releasePipelineRun.Spec.ServiceAccountName).To(Equal(serviceAccountName))
 		})
 
 		It("can add a workspace to the PipelineRun using the given name and PVC", func() {
-			releasePipelineRun.WithWorkspace(workspace, persistentVolumeClaim)
+			releasePipelineRun.WithWorkspace(v1alpha1.Workspace{
+				Name: workspace,
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: persistentVolumeClaim,
+				},
+			})
 			Expect(releasePipelineRun.Spec.Workspaces).Should(ContainElement(HaveField("Name", Equal(workspace))))
 			Expect(releasePipelineRun.Spec.Workspaces).Should(ContainElement(HaveField("PersistentVolumeClaim.ClaimName", Equal(persistentVolumeClaim))))
 		})
 
+		It("can add workspaces of any type to the PipelineRun", func() {
+			releasePipelineRun.WithWorkspace(v1alpha1.Workspace{
+				Name:     "empty-dir",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			})
+			releasePipelineRun.WithWorkspace(v1alpha1.Workspace{
+				Name:    "secret",
+				SubPath: "foo",
+				Secret:  &corev1.SecretVolumeSource{SecretName: "bar"},
+			})
+			Expect(releasePipelineRun.Spec.Workspaces).To(HaveLen(2))
+			Expect(releasePipelineRun.Spec.Workspaces[0].EmptyDir).NotTo(BeNil())
+			Expect(releasePipelineRun.Spec.Workspaces[1].SubPath).To(Equal("foo"))
+			Expect(releasePipelineRun.Spec.Workspaces[1].Secret.SecretName).To(Equal("bar"))
+		})
+
+		It("replaces an existing workspace with the same name", func() {
+			releasePipelineRun.WithWorkspace(v1alpha1.Workspace{
+				Name:     workspace,
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			})
+			releasePipelineRun.WithWorkspace(v1alpha1.Workspace{
+				Name:      workspace,
+				ConfigMap: &corev1.ConfigMapVolumeSource{},
+			})
+			Expect(releasePipelineRun.Spec.Workspaces).To(HaveLen(1))
+			Expect(releasePipelineRun.Spec.Workspaces[0].EmptyDir).To(BeNil())
+			Expect(releasePipelineRun.Spec.Workspaces[0].ConfigMap).NotTo(BeNil())
+		})
+
+		It("can set the pod template of the PipelineRun", func() {
+			releasePipelineRun.WithPodTemplate(&v1alpha1.PodTemplate{
+				NodeSelector:      map[string]string{"node-role": "release"},
+				Tolerations:       []corev1.Toleration{{Key: "dedicated", Value: "release"}},
+				SecurityContext:   &corev1.PodSecurityContext{FSGroup: &[]int64{65532}[0]},
+				PriorityClassName: "high-priority",
+			})
+			Expect(releasePipelineRun.Spec.PodTemplate).NotTo(BeNil())
+			Expect(releasePipelineRun.Spec.PodTemplate.NodeSelector).To(HaveKeyWithValue("node-role", "release"))
+			Expect(releasePipelineRun.Spec.PodTemplate.Tolerations).To(HaveLen(1))
+			Expect(*releasePipelineRun.Spec.PodTemplate.SecurityContext.FSGroup).To(Equal(int64(65532)))
+			Expect(*releasePipelineRun.Spec.PodTemplate.PriorityClassName).To(Equal("high-priority"))
+		})
+
+		It("doesn't set the pod template if none is given", func() {
+			releasePipelineRun.WithPodTemplate(nil)
+			Expect(releasePipelineRun.Spec.PodTemplate).To(BeNil())
+		})
+
+		It("can override the service account and compute resources of a task", func() {
+			releasePipelineRun.WithTaskRunSpec(v1alpha1.TaskRunSpec{
+				PipelineTaskName: "push",
+				ServiceAccount:   "foo",
+			})
+			releasePipelineRun.WithTaskRunSpec(v1alpha1.TaskRunSpec{
+				PipelineTaskName: "push",
+				ServiceAccount:   "bar",
+				ComputeResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+				},
+			})
+			Expect(releasePipelineRun.Spec.TaskRunSpecs).To(HaveLen(1))
+			Expect(releasePipelineRun.Spec.TaskRunSpecs[0].PipelineTaskName).To(Equal("push"))
+			Expect(releasePipelineRun.Spec.TaskRunSpecs[0].TaskServiceAccountName).To(Equal("bar"))
+			Expect(releasePipelineRun.Spec.TaskRunSpecs[0].ComputeResources.Requests.Memory().String()).To(Equal("2Gi"))
+		})
+
 		It("can add an EnterpriseContractPolicy to the PipelineRun", func() {
 			releasePipelineRun.WithEnterpriseContractPolicy(enterpriseContractPolicy)
 			jsonSpec, _ := json.Marshal(enterpriseContractPolicy.Spec)
@@ -232,34 +360,48 @@ var _ = Describe("PipelineRun", func() {
 		})
 	})
 
-	Context("WithReleaseStrategy handles all limbs of PVC conditional branch", func() {
-		When("strategy.Spec.PersistentVolumeClaim is empty", func() {
-			It("nothing happens when the DEFAULT_RELEASE_WORKSPACE_NAME environment variable is empty", func() {
-				os.Setenv("DEFAULT_RELEASE_WORKSPACE_NAME", "")
-				os.Setenv("DEFAULT_RELEASE_PVC", "bar")
-				strategy.Spec.PersistentVolumeClaim = ""
-				releasePipelineRun.WithReleaseStrategy(strategy)
-				Expect(releasePipelineRun.Spec.Workspaces).To(BeNil())
-			})
+	Context("WithReleaseStrategy binds the ReleaseStrategy workspaces", func() {
+		It("binds no workspace when the strategy doesn't declare any", func() {
+			strategy.Spec.PersistentVolumeClaim = ""
+			strategy.Spec.Workspaces = nil
+			releasePipelineRun.WithReleaseStrategy(strategy)
+			Expect(releasePipelineRun.Spec.Workspaces).To(BeNil())
 		})
-		When("strategy.Spec.PersistentVolumeClaim is empty", func() {
-			It("nothing happens when the DEFAULT_RELEASE_PVC environment variable is empty", func() {
-				os.Setenv("DEFAULT_RELEASE_WORKSPACE_NAME", "foo")
-				os.Setenv("DEFAULT_RELEASE_PVC", "")
-				strategy.Spec.PersistentVolumeClaim = ""
-				releasePipelineRun.WithReleaseStrategy(strategy)
-				Expect(releasePipelineRun.Spec.Workspaces).To(BeNil())
-			})
+
+		It("binds the deprecated PersistentVolumeClaim to the default workspace", func() {
+			strategy.Spec.PersistentVolumeClaim = persistentVolumeClaim
+			strategy.Spec.Workspaces = nil
+			releasePipelineRun.WithReleaseStrategy(strategy)
+			Expect(releasePipelineRun.Spec.Workspaces).To(HaveLen(1))
+			Expect(releasePipelineRun.Spec.Workspaces[0].Name).To(Equal(v1alpha1.DefaultReleaseWorkspaceName))
+			Expect(releasePipelineRun.Spec.Workspaces[0].PersistentVolumeClaim.ClaimName).To(Equal(persistentVolumeClaim))
 		})
-		When("strategy.Spec.PersistentVolumeClaim is empty", func() {
-			It("sets the ClaimName from the DEFAULT_RELEASE_PVC environment variable", func() {
-				os.Setenv("DEFAULT_RELEASE_WORKSPACE_NAME", "foo")
-				os.Setenv("DEFAULT_RELEASE_PVC", "bar")
-				strategy.Spec.PersistentVolumeClaim = ""
-				releasePipelineRun.WithReleaseStrategy(strategy)
-				Expect(releasePipelineRun.Spec.Workspaces).Should(ContainElement(HaveField("Name", Equal("foo"))))
-				Expect(releasePipelineRun.Spec.Workspaces).Should(ContainElement(HaveField("PersistentVolumeClaim.ClaimName", Equal("bar"))))
-			})
+
+		It("maps the pod template and task overrides of the strategy", func() {
+			strategy.Spec.PodTemplate = &v1alpha1.PodTemplate{NodeSelector: map[string]string{"foo": "bar"}}
+			strategy.Spec.TaskRunSpecs = []v1alpha1.TaskRunSpec{{PipelineTaskName: "push", ServiceAccount: "baz"}}
+			releasePipelineRun.WithReleaseStrategy(strategy)
+			Expect(releasePipelineRun.Spec.PodTemplate.NodeSelector).To(HaveKeyWithValue("foo", "bar"))
+			Expect(releasePipelineRun.Spec.TaskRunSpecs).To(HaveLen(1))
+			Expect(releasePipelineRun.Spec.TaskRunSpecs[0].TaskServiceAccountName).To(Equal("baz"))
+		})
+
+		It("binds a volumeClaimTemplate so each release gets its own volume", func() {
+			strategy.Spec.PersistentVolumeClaim = ""
+			strategy.Spec.Workspaces = []v1alpha1.Workspace{
+				{
+					Name: workspace,
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						},
+					},
+				},
+			}
+			releasePipelineRun.WithReleaseStrategy(strategy)
+			Expect(releasePipelineRun.Spec.Workspaces).To(HaveLen(1))
+			Expect(releasePipelineRun.Spec.Workspaces[0].Name).To(Equal(workspace))
+			Expect(releasePipelineRun.Spec.Workspaces[0].VolumeClaimTemplate).NotTo(BeNil())
 		})
 	})
 })
@@ -39,3 +39,22 @@ func ReleasePipelineRunSucceededPredicate() predicate.Predicate {
 		},
 	}
 }
+
+// VerificationPipelineRunSucceededPredicate returns a predicate which filters out all objects except
+// verification PipelineRuns which have just succeeded.
+func VerificationPipelineRunSucceededPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(genericEvent event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isVerificationPipelineRun(e.ObjectNew) && hasPipelineSucceeded(e.ObjectNew)
+		},
+	}
+}
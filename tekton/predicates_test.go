@@ -26,6 +26,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,12 +42,12 @@ var _ = Describe("Predicates", func() {
 		namespace             = "default"
 	)
 
-	var release *v1alpha1.Release
+	var release *v1alpha2.Release
 	var releasePipelineRun *ReleasePipelineRun
 
 	BeforeEach(func() {
 
-		release = &v1alpha1.Release{
+		release = &v1alpha2.Release{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: apiVersion,
 				Kind:       "Release",
@@ -54,7 +56,7 @@ var _ = Describe("Predicates", func() {
 				GenerateName: "testrelease-",
 				Namespace:    namespace,
 			},
-			Spec: v1alpha1.ReleaseSpec{
+			Spec: v1alpha2.ReleaseSpec{
 				Snapshot:    "testsnapshot",
 				ReleasePlan: "testreleaseplan",
 			},
@@ -62,7 +64,7 @@ var _ = Describe("Predicates", func() {
 		ctx := context.Background()
 
 		// The code below sets the ownership for the Release Object
-		kind := reflect.TypeOf(v1alpha1.Release{}).Name()
+		kind := reflect.TypeOf(v1alpha2.Release{}).Name()
 		gvk := v1alpha1.GroupVersion.WithKind(kind)
 		controllerRef := metav1.NewControllerRef(release, gvk)
 
@@ -122,5 +124,45 @@ var _ = Describe("Predicates", func() {
 			releasePipelineRun.Status.MarkSucceeded("Predicate function tests", "Set it to Succeeded")
 			Expect(instance.Update(contextEvent)).To(BeTrue())
 		})
+
+		It("should return true when an updated event is received for a succeeded v1 release PipelineRun", func() {
+			releasePipelineRun.WithReleaseAndApplicationMetadata(release, applicationName)
+			releasePipelineRun.Status.MarkSucceeded("Predicate function tests", "Set it to Succeeded")
+
+			pipelineRun := &tektonv1.PipelineRun{}
+			Expect(releasePipelineRun.AsPipelineRun().ConvertTo(ctx, pipelineRun)).To(Succeed())
+			pipelineRun.Status.Status = releasePipelineRun.Status.Status
+
+			contextEvent := event.UpdateEvent{
+				ObjectOld: pipelineRun,
+				ObjectNew: pipelineRun,
+			}
+			Expect(instance.Update(contextEvent)).To(BeTrue())
+		})
+	})
+
+	Context("when testing VerificationPipelineRunSucceededPredicate predicate", func() {
+		instance := VerificationPipelineRunSucceededPredicate()
+
+		It("should ignore creating events", func() {
+			contextEvent := event.CreateEvent{
+				Object: releasePipelineRun.AsPipelineRun(),
+			}
+			Expect(instance.Create(contextEvent)).To(BeFalse())
+		})
+
+		It("should return true only when an updated event is received for a succeeded verification PipelineRun", func() {
+			releasePipelineRun.AsPipelineRun().Status.InitializeConditions(clock.RealClock{})
+			releasePipelineRun.WithReleaseAndApplicationMetadata(release, applicationName)
+			releasePipelineRun.Status.MarkSucceeded("Predicate function tests", "Set it to Succeeded")
+			contextEvent := event.UpdateEvent{
+				ObjectOld: releasePipelineRun.AsPipelineRun(),
+				ObjectNew: releasePipelineRun.AsPipelineRun(),
+			}
+			Expect(instance.Update(contextEvent)).To(BeFalse())
+
+			releasePipelineRun.WithPipelineType(PipelineTypeVerification)
+			Expect(instance.Update(contextEvent)).To(BeTrue())
+		})
 	})
 })
@@ -0,0 +1,167 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// ErrResolutionInProgress is returned when the resolution of a remote Pipeline hasn't finished yet.
+var ErrResolutionInProgress = errors.New("the resolution of the Pipeline is still in progress")
+
+// NewPipelineResolutionRequest returns a ResolutionRequest to resolve the Pipeline referenced by the given
+// ReleaseStrategy through a Tekton remote resolver. Pipelines referenced by bundle are resolved using the bundles
+// resolver. The name of the request depends on the resolver and its params, so a new request is created every time
+// the reference changes. If the ReleaseStrategy references an in-cluster Pipeline by name, nil will be returned.
+func NewPipelineResolutionRequest(strategy *v1alpha1.ReleaseStrategy) *resolutionv1beta1.ResolutionRequest {
+	var resolver string
+	var params []tektonv1beta1.Param
+
+	if strategy.Spec.PipelineRef != nil {
+		resolver = strategy.Spec.PipelineRef.Resolver
+		for _, param := range strategy.Spec.PipelineRef.Params {
+			params = append(params, tektonv1beta1.Param{
+				Name:  param.Name,
+				Value: *tektonv1beta1.NewArrayOrString(param.Value),
+			})
+		}
+	} else if strategy.Spec.Bundle != "" {
+		resolver = "bundles"
+		params = []tektonv1beta1.Param{
+			{Name: "bundle", Value: *tektonv1beta1.NewArrayOrString(strategy.Spec.Bundle)},
+			{Name: "name", Value: *tektonv1beta1.NewArrayOrString(strategy.Spec.Pipeline)},
+			{Name: "kind", Value: *tektonv1beta1.NewArrayOrString("pipeline")},
+		}
+	} else {
+		return nil
+	}
+
+	// We ignore the error here because none should be raised when marshalling a list of params
+	paramsJson, _ := json.Marshal(params)
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(resolver))
+	_, _ = hasher.Write(paramsJson)
+
+	return &resolutionv1beta1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%x", strategy.Name, hasher.Sum32()),
+			Namespace: strategy.Namespace,
+			Labels: map[string]string{
+				resolutioncommon.LabelKeyResolverType: resolver,
+			},
+		},
+		Spec: resolutionv1beta1.ResolutionRequestSpec{
+			Params: params,
+		},
+	}
+}
+
+// GetPipelineFromResolutionRequest returns the Pipeline resolved by the given ResolutionRequest as a v1beta1 Pipeline.
+// ErrResolutionInProgress will be returned if the resolution hasn't finished yet. If the resolution failed or the
+// resolved resource is not a Pipeline, an error will be returned.
+func GetPipelineFromResolutionRequest(resolutionRequest *resolutionv1beta1.ResolutionRequest) (*tektonv1beta1.Pipeline, error) {
+	condition := resolutionRequest.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status == corev1.ConditionUnknown {
+		return nil, ErrResolutionInProgress
+	}
+
+	if condition.Status == corev1.ConditionFalse {
+		return nil, fmt.Errorf("unable to resolve the Pipeline: %s", condition.Message)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(resolutionRequest.Status.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the resolved Pipeline: %w", err)
+	}
+
+	object, _, err := scheme.Codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the resolved Pipeline: %w", err)
+	}
+
+	switch resolvedPipeline := object.(type) {
+	case *tektonv1beta1.Pipeline:
+		return resolvedPipeline, nil
+	case *tektonv1.Pipeline:
+		pipeline := &tektonv1beta1.Pipeline{}
+		if err := pipeline.ConvertFrom(context.Background(), resolvedPipeline); err != nil {
+			return nil, err
+		}
+
+		return pipeline, nil
+	default:
+		return nil, fmt.Errorf("the resolved resource is a %T instead of a Pipeline", object)
+	}
+}
+
+// ValidatePipelineParams returns an error if the given params don't match the ones declared by the Pipeline. A param
+// will be considered an array if it sets a list of values, as done when building the release PipelineRun. Params
+// declared by the Pipeline without a default value must be either among the given params or the provided params,
+// which are expected to be set by other means when the PipelineRun is created.
+func ValidatePipelineParams(pipeline *tektonv1beta1.Pipeline, params []v1alpha1.Params, providedParams []string) error {
+	for _, pipelineParam := range pipeline.Spec.Params {
+		expectedType := pipelineParam.Type
+		if expectedType == "" {
+			expectedType = tektonv1beta1.ParamTypeString
+		}
+
+		found := false
+		for _, param := range params {
+			if param.Name != pipelineParam.Name {
+				continue
+			}
+
+			found = true
+			if paramType := getParamValue(param).Type; paramType != expectedType {
+				return fmt.Errorf("param '%s' is of type '%s' in the Pipeline, but a value of type '%s' is provided",
+					param.Name, expectedType, paramType)
+			}
+		}
+
+		if found || pipelineParam.Default != nil {
+			continue
+		}
+
+		for _, providedParam := range providedParams {
+			if providedParam == pipelineParam.Name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("the Pipeline requires the param '%s', which is not provided", pipelineParam.Name)
+		}
+	}
+
+	return nil
+}
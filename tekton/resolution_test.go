@@ -0,0 +1,230 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton
+
+import (
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+var _ = Describe("Resolution", func() {
+	var releaseStrategy *v1alpha1.ReleaseStrategy
+
+	BeforeEach(func() {
+		releaseStrategy = &v1alpha1.ReleaseStrategy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "strategy",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.ReleaseStrategySpec{
+				Pipeline: "release-pipeline",
+			},
+		}
+	})
+
+	Context("When NewPipelineResolutionRequest is called", func() {
+		It("returns nil if the Pipeline is referenced by name", func() {
+			Expect(NewPipelineResolutionRequest(releaseStrategy)).To(BeNil())
+		})
+
+		It("returns a request for the bundles resolver if the Pipeline is referenced by bundle", func() {
+			releaseStrategy.Spec.Bundle = "quay.io/foo/bar:baz"
+
+			resolutionRequest := NewPipelineResolutionRequest(releaseStrategy)
+			Expect(resolutionRequest).NotTo(BeNil())
+			Expect(resolutionRequest.Namespace).To(Equal(releaseStrategy.Namespace))
+			Expect(resolutionRequest.Labels).To(HaveKeyWithValue(resolutioncommon.LabelKeyResolverType, "bundles"))
+			Expect(resolutionRequest.Spec.Params).To(ContainElements(
+				tektonv1beta1.Param{Name: "bundle", Value: *tektonv1beta1.NewArrayOrString("quay.io/foo/bar:baz")},
+				tektonv1beta1.Param{Name: "name", Value: *tektonv1beta1.NewArrayOrString("release-pipeline")},
+				tektonv1beta1.Param{Name: "kind", Value: *tektonv1beta1.NewArrayOrString("pipeline")},
+			))
+		})
+
+		It("returns a request for the resolver referenced by the ReleaseStrategy", func() {
+			releaseStrategy.Spec.Pipeline = ""
+			releaseStrategy.Spec.PipelineRef = &v1alpha1.PipelineRef{
+				Resolver: "git",
+				Params: []v1alpha1.ResolverParam{
+					{Name: "url", Value: "https://github.com/foo/bar"},
+				},
+			}
+
+			resolutionRequest := NewPipelineResolutionRequest(releaseStrategy)
+			Expect(resolutionRequest).NotTo(BeNil())
+			Expect(resolutionRequest.Labels).To(HaveKeyWithValue(resolutioncommon.LabelKeyResolverType, "git"))
+			Expect(resolutionRequest.Spec.Params).To(Equal([]tektonv1beta1.Param{
+				{Name: "url", Value: *tektonv1beta1.NewArrayOrString("https://github.com/foo/bar")},
+			}))
+		})
+
+		It("returns a request with a different name when the reference changes", func() {
+			releaseStrategy.Spec.Bundle = "quay.io/foo/bar:baz"
+			name := NewPipelineResolutionRequest(releaseStrategy).Name
+			Expect(NewPipelineResolutionRequest(releaseStrategy).Name).To(Equal(name))
+
+			releaseStrategy.Spec.Bundle = "quay.io/foo/bar:qux"
+			Expect(NewPipelineResolutionRequest(releaseStrategy).Name).NotTo(Equal(name))
+		})
+	})
+
+	Context("When GetPipelineFromResolutionRequest is called", func() {
+		var resolutionRequest *resolutionv1beta1.ResolutionRequest
+
+		BeforeEach(func() {
+			resolutionRequest = &resolutionv1beta1.ResolutionRequest{}
+		})
+
+		It("returns ErrResolutionInProgress if the request hasn't been resolved yet", func() {
+			_, err := GetPipelineFromResolutionRequest(resolutionRequest)
+			Expect(err).To(Equal(ErrResolutionInProgress))
+		})
+
+		It("fails if the resolution failed", func() {
+			resolutionRequest.Status.SetConditions(apis.Conditions{{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Message: "bundle not found",
+			}})
+
+			_, err := GetPipelineFromResolutionRequest(resolutionRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bundle not found"))
+		})
+
+		It("returns the resolved v1beta1 Pipeline", func() {
+			resolutionRequest.Status.SetConditions(apis.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}})
+			resolutionRequest.Status.Data = base64.StdEncoding.EncodeToString([]byte(`
+apiVersion: tekton.dev/v1beta1
+kind: Pipeline
+metadata:
+  name: release-pipeline
+spec:
+  params:
+    - name: foo
+`))
+
+			pipeline, err := GetPipelineFromResolutionRequest(resolutionRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipeline.Name).To(Equal("release-pipeline"))
+			Expect(pipeline.Spec.Params).To(HaveLen(1))
+		})
+
+		It("returns the resolved v1 Pipeline as a v1beta1 Pipeline", func() {
+			resolutionRequest.Status.SetConditions(apis.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}})
+			resolutionRequest.Status.Data = base64.StdEncoding.EncodeToString([]byte(`
+apiVersion: tekton.dev/v1
+kind: Pipeline
+metadata:
+  name: release-pipeline
+spec:
+  params:
+    - name: foo
+      type: array
+`))
+
+			pipeline, err := GetPipelineFromResolutionRequest(resolutionRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipeline.Spec.Params).To(HaveLen(1))
+			Expect(pipeline.Spec.Params[0].Type).To(Equal(tektonv1beta1.ParamTypeArray))
+		})
+
+		It("fails if the resolved resource is not a Pipeline", func() {
+			resolutionRequest.Status.SetConditions(apis.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}})
+			resolutionRequest.Status.Data = base64.StdEncoding.EncodeToString([]byte(`
+apiVersion: tekton.dev/v1beta1
+kind: Task
+metadata:
+  name: release-task
+`))
+
+			_, err := GetPipelineFromResolutionRequest(resolutionRequest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("instead of a Pipeline"))
+		})
+	})
+
+	Context("When ValidatePipelineParams is called", func() {
+		var pipeline *tektonv1beta1.Pipeline
+
+		BeforeEach(func() {
+			pipeline = &tektonv1beta1.Pipeline{
+				Spec: tektonv1beta1.PipelineSpec{
+					Params: []tektonv1beta1.ParamSpec{
+						{Name: "foo"},
+						{Name: "bar", Type: tektonv1beta1.ParamTypeArray},
+						{Name: "baz", Default: tektonv1beta1.NewArrayOrString("baz")},
+						{Name: "snapshot"},
+					},
+				},
+			}
+		})
+
+		It("succeeds if the params match the ones declared by the Pipeline", func() {
+			params := []v1alpha1.Params{
+				{Name: "foo", Value: "foo"},
+				{Name: "bar", Values: []string{"bar"}},
+			}
+			Expect(ValidatePipelineParams(pipeline, params, ServiceProvidedParams)).To(Succeed())
+		})
+
+		It("fails if a required param is not provided", func() {
+			params := []v1alpha1.Params{
+				{Name: "foo", Value: "foo"},
+			}
+			err := ValidatePipelineParams(pipeline, params, ServiceProvidedParams)
+			Expect(err).To(MatchError("the Pipeline requires the param 'bar', which is not provided"))
+		})
+
+		It("fails if the type of a param doesn't match", func() {
+			params := []v1alpha1.Params{
+				{Name: "foo", Values: []string{"foo"}},
+				{Name: "bar", Values: []string{"bar"}},
+			}
+			err := ValidatePipelineParams(pipeline, params, ServiceProvidedParams)
+			Expect(err).To(MatchError("param 'foo' is of type 'string' in the Pipeline, but a value of type 'array' is provided"))
+		})
+
+		It("accepts required params provided by other means", func() {
+			params := []v1alpha1.Params{
+				{Name: "foo", Value: "foo"},
+			}
+			Expect(ValidatePipelineParams(pipeline, params, append(ServiceProvidedParams, "bar"))).To(Succeed())
+		})
+	})
+})
@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	appstudiov1alpha1 "github.com/redhat-appstudio/release-service/api/v1alpha1"
+	appstudiov1alpha2 "github.com/redhat-appstudio/release-service/api/v1alpha2"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
 
@@ -83,6 +84,9 @@ var _ = BeforeSuite(func() {
 	err = appstudiov1alpha1.AddToScheme(clientsetscheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = appstudiov1alpha2.AddToScheme(clientsetscheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = applicationapiv1alpha1.AddToScheme(clientsetscheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -101,7 +105,7 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = (&appstudiov1alpha1.Release{}).SetupWebhookWithManager(k8sManager)
+	err = (&appstudiov1alpha1.Release{}).SetupWebhookWithManager(k8sManager, nil)
 	Expect(err).NotTo(HaveOccurred())
 })
 
@@ -17,29 +17,94 @@ limitations under the License.
 package tekton
 
 import (
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // isReleasePipelineRun returns a boolean indicating whether the object passed is a release PipelineRun or not.
+// PipelineRuns of any supported Tekton API version are considered.
 func isReleasePipelineRun(object client.Object) bool {
-	_, ok := object.(*tektonv1beta1.PipelineRun)
-	if !ok {
+	return isPipelineRunOfType(object, PipelineTypeRelease)
+}
+
+// isVerificationPipelineRun returns a boolean indicating whether the object passed is a verification PipelineRun or
+// not. PipelineRuns of any supported Tekton API version are considered.
+func isVerificationPipelineRun(object client.Object) bool {
+	return isPipelineRunOfType(object, PipelineTypeVerification)
+}
+
+// isPipelineRunOfType returns a boolean indicating whether the object passed is a PipelineRun with the given type
+// label or not.
+func isPipelineRunOfType(object client.Object, pipelineType string) bool {
+	switch object.(type) {
+	case *tektonv1.PipelineRun, *tektonv1beta1.PipelineRun:
+	default:
 		return false
 	}
 
 	labelValue, found := object.GetLabels()[PipelinesTypeLabel]
 
-	return found && labelValue == PipelineTypeRelease
+	return found && labelValue == pipelineType
 }
 
 // hasPipelineSucceeded returns a boolean indicating whether the PipelineRun succeeded or not.
 // If the object passed to this function is not a PipelineRun, the function will return false.
 func hasPipelineSucceeded(object client.Object) bool {
-	if pipelineRun, ok := object.(*tektonv1beta1.PipelineRun); ok {
+	switch pipelineRun := object.(type) {
+	case *tektonv1.PipelineRun:
+		return !pipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown()
+	case *tektonv1beta1.PipelineRun:
 		return !pipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown()
 	}
 
 	return false
 }
+
+// getParamValue returns the value of the given param as an ArrayOrString. Params with a list of values will be
+// considered arrays, while the rest of them will be considered strings.
+func getParamValue(param v1alpha1.Params) tektonv1beta1.ArrayOrString {
+	if len(param.Values) > 0 {
+		return tektonv1beta1.ArrayOrString{
+			Type:     tektonv1beta1.ParamTypeArray,
+			ArrayVal: param.Values,
+		}
+	}
+
+	return tektonv1beta1.ArrayOrString{
+		Type:      tektonv1beta1.ParamTypeString,
+		StringVal: param.Value,
+	}
+}
+
+// getPipelineRef returns the reference to the release Pipeline declared in the given ReleaseStrategy. If the strategy
+// references the Pipeline through a resolver, a ResolverRef will be returned. Otherwise, the Pipeline will be
+// referenced by name and, optionally, by bundle.
+func getPipelineRef(strategy *v1alpha1.ReleaseStrategy) *tektonv1beta1.PipelineRef {
+	if strategy.Spec.PipelineRef == nil {
+		return &tektonv1beta1.PipelineRef{
+			Name:   strategy.Spec.Pipeline,
+			Bundle: strategy.Spec.Bundle,
+		}
+	}
+
+	return getResolverPipelineRef(strategy.Spec.PipelineRef)
+}
+
+// getResolverPipelineRef returns a reference to the Pipeline located through the resolver and params of the given
+// PipelineRef.
+func getResolverPipelineRef(pipelineRef *v1alpha1.PipelineRef) *tektonv1beta1.PipelineRef {
+	resolverRef := tektonv1beta1.ResolverRef{
+		Resolver: tektonv1beta1.ResolverName(pipelineRef.Resolver),
+	}
+	for _, param := range pipelineRef.Params {
+		resolverRef.Params = append(resolverRef.Params, tektonv1beta1.Param{
+			Name:  param.Name,
+			Value: *tektonv1beta1.NewArrayOrString(param.Value),
+		})
+	}
+
+	return &tektonv1beta1.PipelineRef{ResolverRef: resolverRef}
+}
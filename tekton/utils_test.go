@@ -26,7 +26,9 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
 
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -39,12 +41,12 @@ var _ = Describe("Utils", func() {
 		namespace             = "default"
 	)
 
-	var release *v1alpha1.Release
+	var release *v1alpha2.Release
 	var releasePipelineRun *ReleasePipelineRun
 
 	BeforeEach(func() {
 
-		release = &v1alpha1.Release{
+		release = &v1alpha2.Release{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: apiVersion,
 				Kind:       "Release",
@@ -53,7 +55,7 @@ var _ = Describe("Utils", func() {
 				GenerateName: "testrelease-",
 				Namespace:    namespace,
 			},
-			Spec: v1alpha1.ReleaseSpec{
+			Spec: v1alpha2.ReleaseSpec{
 				Snapshot:    "testsnapshot",
 				ReleasePlan: "testreleaseplan",
 			},
@@ -61,7 +63,7 @@ var _ = Describe("Utils", func() {
 		ctx := context.Background()
 
 		// The code below sets the ownership for the Release Object
-		kind := reflect.TypeOf(v1alpha1.Release{}).Name()
+		kind := reflect.TypeOf(v1alpha2.Release{}).Name()
 		gvk := v1alpha1.GroupVersion.WithKind(kind)
 		controllerRef := metav1.NewControllerRef(release, gvk)
 
@@ -99,5 +101,50 @@ var _ = Describe("Utils", func() {
 			releasePipelineRun.Status.MarkSucceeded("PipelineRun Tests", "sets it to Succeeded")
 			Expect(hasPipelineSucceeded(releasePipelineRun.AsPipelineRun())).Should(BeTrue())
 		})
+
+		It("returns a string value for params with a single value", func() {
+			value := getParamValue(v1alpha1.Params{Name: "foo", Value: "bar"})
+			Expect(value.Type).To(Equal(tektonv1beta1.ParamTypeString))
+			Expect(value.StringVal).To(Equal("bar"))
+		})
+
+		It("returns an array value for params with a list of values", func() {
+			value := getParamValue(v1alpha1.Params{Name: "foo", Values: []string{"bar", "baz"}})
+			Expect(value.Type).To(Equal(tektonv1beta1.ParamTypeArray))
+			Expect(value.ArrayVal).To(Equal([]string{"bar", "baz"}))
+		})
+
+		It("returns a PipelineRef using the pipeline name and bundle for strategies not using a resolver", func() {
+			pipelineRef := getPipelineRef(&v1alpha1.ReleaseStrategy{
+				Spec: v1alpha1.ReleaseStrategySpec{
+					Pipeline: "foo",
+					Bundle:   "bar",
+				},
+			})
+			Expect(pipelineRef.Name).To(Equal("foo"))
+			Expect(pipelineRef.Bundle).To(Equal("bar"))
+			Expect(pipelineRef.Resolver).To(BeEmpty())
+		})
+
+		It("returns a PipelineRef using a resolver for strategies declaring a pipelineRef", func() {
+			pipelineRef := getPipelineRef(&v1alpha1.ReleaseStrategy{
+				Spec: v1alpha1.ReleaseStrategySpec{
+					PipelineRef: &v1alpha1.PipelineRef{
+						Resolver: "git",
+						Params: []v1alpha1.ResolverParam{
+							{Name: "url", Value: "https://example.com/repo.git"},
+							{Name: "revision", Value: "abcdef"},
+							{Name: "pathInRepo", Value: "pipeline.yaml"},
+						},
+					},
+				},
+			})
+			Expect(pipelineRef.Name).To(BeEmpty())
+			Expect(pipelineRef.Bundle).To(BeEmpty())
+			Expect(pipelineRef.Resolver).To(Equal(tektonv1beta1.ResolverName("git")))
+			Expect(pipelineRef.Params).To(HaveLen(3))
+			Expect(pipelineRef.Params[1].Name).To(Equal("revision"))
+			Expect(pipelineRef.Params[1].Value.StringVal).To(Equal("abcdef"))
+		})
 	})
 })
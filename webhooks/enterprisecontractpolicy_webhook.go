@@ -0,0 +1,92 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+
+	ecapiv1alpha1 "github.com/hacbs-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/loader"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// EnterpriseContractPolicyWebhook protects EnterpriseContractPolicies used by ReleasePlanAdmissions from deletion.
+type EnterpriseContractPolicyWebhook struct {
+	client client.Client
+	loader loader.ObjectLoader
+}
+
+// NewEnterpriseContractPolicyWebhook creates and returns an EnterpriseContractPolicyWebhook.
+func NewEnterpriseContractPolicyWebhook(client client.Client, loader loader.ObjectLoader) *EnterpriseContractPolicyWebhook {
+	return &EnterpriseContractPolicyWebhook{
+		client: client,
+		loader: loader,
+	}
+}
+
+// SetupEnterpriseContractPolicyWebhook creates a new EnterpriseContractPolicyWebhook and registers it in the Manager.
+func SetupEnterpriseContractPolicyWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&ecapiv1alpha1.EnterpriseContractPolicy{}).
+		WithValidator(NewEnterpriseContractPolicyWebhook(mgr.GetClient(), loader.NewLoader())).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-appstudio-redhat-com-v1alpha1-enterprisecontractpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=enterprisecontractpolicies,verbs=delete,versions=v1alpha1,name=venterprisecontractpolicy.kb.io,admissionReviewVersions=v1
+
+var _ admission.CustomValidator = &EnterpriseContractPolicyWebhook{}
+
+// ValidateCreate implements admission.CustomValidator. EnterpriseContractPolicies are not validated on creation.
+func (w *EnterpriseContractPolicyWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// ValidateUpdate implements admission.CustomValidator. EnterpriseContractPolicies are not validated on update.
+func (w *EnterpriseContractPolicyWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return nil
+}
+
+// ValidateDelete implements admission.CustomValidator. The deletion will be rejected if the effective ReleaseStrategy
+// of any ReleasePlanAdmission in the same namespace references the EnterpriseContractPolicy.
+func (w *EnterpriseContractPolicyWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	enterpriseContractPolicy := obj.(*ecapiv1alpha1.EnterpriseContractPolicy)
+
+	releasePlanAdmissions := &v1alpha1.ReleasePlanAdmissionList{}
+	err := w.client.List(ctx, releasePlanAdmissions, client.InNamespace(enterpriseContractPolicy.Namespace))
+	if err != nil {
+		return err
+	}
+
+	var referencingReleasePlanAdmissions []v1alpha1.ReleasePlanAdmission
+	for i, releasePlanAdmission := range releasePlanAdmissions.Items {
+		releaseStrategy, err := w.loader.GetReleaseStrategy(ctx, w.client, &releasePlanAdmissions.Items[i])
+		if err != nil {
+			// The ReleasePlanAdmission is already broken, so the policy can't be in use
+			continue
+		}
+
+		if releaseStrategy.Spec.Policy == enterpriseContractPolicy.Name {
+			referencingReleasePlanAdmissions = append(referencingReleasePlanAdmissions, releasePlanAdmission)
+		}
+	}
+
+	return v1alpha1.NewReferencedError("EnterpriseContractPolicy", enterpriseContractPolicy.Name, referencingReleasePlanAdmissions)
+}
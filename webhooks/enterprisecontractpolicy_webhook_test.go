@@ -0,0 +1,98 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	ecapiv1alpha1 "github.com/hacbs-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/loader"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("EnterpriseContractPolicy webhook", Ordered, func() {
+	var (
+		enterpriseContractPolicy        *ecapiv1alpha1.EnterpriseContractPolicy
+		enterpriseContractPolicyWebhook *EnterpriseContractPolicyWebhook
+		releasePlanAdmission            *v1alpha1.ReleasePlanAdmission
+		releaseStrategy                 *v1alpha1.ReleaseStrategy
+	)
+
+	BeforeAll(func() {
+		enterpriseContractPolicyWebhook = NewEnterpriseContractPolicyWebhook(k8sClient, loader.NewLoader())
+
+		enterpriseContractPolicy = &ecapiv1alpha1.EnterpriseContractPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "enterprise-contract-policy",
+				Namespace: "default",
+			},
+		}
+
+		releaseStrategy = &v1alpha1.ReleaseStrategy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "policy-release-strategy",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.ReleaseStrategySpec{
+				Pipeline: "release-pipeline",
+				Policy:   enterpriseContractPolicy.Name,
+			},
+		}
+		Expect(k8sClient.Create(ctx, releaseStrategy)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		_ = k8sClient.Delete(ctx, releasePlanAdmission)
+		_ = k8sClient.Delete(ctx, releaseStrategy)
+	})
+
+	Context("When ValidateDelete is called", func() {
+		It("accepts the deletion if no ReleasePlanAdmission uses the EnterpriseContractPolicy", func() {
+			Expect(enterpriseContractPolicyWebhook.ValidateDelete(ctx, enterpriseContractPolicy)).To(Succeed())
+		})
+
+		It("rejects the deletion if the ReleaseStrategy of a ReleasePlanAdmission uses the EnterpriseContractPolicy", func() {
+			releasePlanAdmission = &v1alpha1.ReleasePlanAdmission{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "policy-release-plan-admission",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.ReleasePlanAdmissionSpec{
+					Application:     "application",
+					Origin:          "default",
+					Environment:     "environment",
+					ReleaseStrategy: releaseStrategy.Name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, releasePlanAdmission)).To(Succeed())
+
+			Eventually(func() error {
+				return enterpriseContractPolicyWebhook.ValidateDelete(ctx, enterpriseContractPolicy)
+			}).Should(MatchError("EnterpriseContractPolicy 'enterprise-contract-policy' cannot be deleted as it's " +
+				"referenced by the ReleasePlanAdmissions: default/policy-release-plan-admission"))
+		})
+	})
+
+	Context("When ValidateCreate and ValidateUpdate are called", func() {
+		It("accepts the request", func() {
+			Expect(enterpriseContractPolicyWebhook.ValidateCreate(ctx, enterpriseContractPolicy)).To(Succeed())
+			Expect(enterpriseContractPolicyWebhook.ValidateUpdate(ctx, enterpriseContractPolicy, enterpriseContractPolicy)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,86 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// EnvironmentWebhook protects Environments used by ReleasePlanAdmissions from deletion.
+type EnvironmentWebhook struct {
+	client client.Reader
+}
+
+// NewEnvironmentWebhook creates and returns an EnvironmentWebhook.
+func NewEnvironmentWebhook(client client.Reader) *EnvironmentWebhook {
+	return &EnvironmentWebhook{
+		client: client,
+	}
+}
+
+// SetupEnvironmentWebhook creates a new EnvironmentWebhook and registers it in the Manager.
+func SetupEnvironmentWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&applicationapiv1alpha1.Environment{}).
+		WithValidator(NewEnvironmentWebhook(mgr.GetClient())).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-appstudio-redhat-com-v1alpha1-environment,mutating=false,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=environments,verbs=delete,versions=v1alpha1,name=venvironment.kb.io,admissionReviewVersions=v1
+
+var _ admission.CustomValidator = &EnvironmentWebhook{}
+
+// ValidateCreate implements admission.CustomValidator. Environments are not validated on creation.
+func (w *EnvironmentWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// ValidateUpdate implements admission.CustomValidator. Environments are not validated on update.
+func (w *EnvironmentWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return nil
+}
+
+// ValidateDelete implements admission.CustomValidator. The deletion will be rejected if any ReleasePlanAdmission in
+// the same namespace references the Environment.
+func (w *EnvironmentWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	environment := obj.(*applicationapiv1alpha1.Environment)
+
+	releasePlanAdmissions := &v1alpha1.ReleasePlanAdmissionList{}
+	err := w.client.List(ctx, releasePlanAdmissions, client.InNamespace(environment.Namespace))
+	if err != nil {
+		return err
+	}
+
+	var referencingReleasePlanAdmissions []v1alpha1.ReleasePlanAdmission
+	for _, releasePlanAdmission := range releasePlanAdmissions.Items {
+		for _, environmentStep := range releasePlanAdmission.GetEnvironments() {
+			if environmentStep.Name == environment.Name {
+				referencingReleasePlanAdmissions = append(referencingReleasePlanAdmissions, releasePlanAdmission)
+				break
+			}
+		}
+	}
+
+	return v1alpha1.NewReferencedError("Environment", environment.Name, referencingReleasePlanAdmissions)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Environment webhook", Ordered, func() {
+	var (
+		environment          *applicationapiv1alpha1.Environment
+		environmentWebhook   *EnvironmentWebhook
+		releasePlanAdmission *v1alpha1.ReleasePlanAdmission
+	)
+
+	BeforeAll(func() {
+		environmentWebhook = NewEnvironmentWebhook(k8sClient)
+
+		environment = &applicationapiv1alpha1.Environment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "environment",
+				Namespace: "default",
+			},
+			Spec: applicationapiv1alpha1.EnvironmentSpec{
+				DeploymentStrategy: applicationapiv1alpha1.DeploymentStrategy_Manual,
+				DisplayName:        "environment",
+			},
+		}
+	})
+
+	AfterAll(func() {
+		_ = k8sClient.Delete(ctx, releasePlanAdmission)
+	})
+
+	Context("When ValidateDelete is called", func() {
+		It("accepts the deletion if no ReleasePlanAdmission references the Environment", func() {
+			Expect(environmentWebhook.ValidateDelete(ctx, environment)).To(Succeed())
+		})
+
+		It("rejects the deletion if a ReleasePlanAdmission references the Environment", func() {
+			releasePlanAdmission = &v1alpha1.ReleasePlanAdmission{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "release-plan-admission",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.ReleasePlanAdmissionSpec{
+					Application:     "application",
+					Origin:          "default",
+					Environment:     environment.Name,
+					ReleaseStrategy: "release-strategy",
+				},
+			}
+			Expect(k8sClient.Create(ctx, releasePlanAdmission)).To(Succeed())
+
+			Eventually(func() error {
+				return environmentWebhook.ValidateDelete(ctx, environment)
+			}).Should(MatchError("Environment 'environment' cannot be deleted as it's referenced by the " +
+				"ReleasePlanAdmissions: default/release-plan-admission"))
+		})
+
+		It("rejects the deletion if a ReleasePlanAdmission lists the Environment in its environments", func() {
+			otherReleasePlanAdmission := &v1alpha1.ReleasePlanAdmission{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "other-release-plan-admission",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.ReleasePlanAdmissionSpec{
+					Application: "other-application",
+					Origin:      "default",
+					Environments: []v1alpha1.EnvironmentStep{
+						{Name: "stage"},
+						{Name: environment.Name},
+					},
+					ReleaseStrategy: "release-strategy",
+				},
+			}
+			Expect(k8sClient.Create(ctx, otherReleasePlanAdmission)).To(Succeed())
+			defer func() {
+				_ = k8sClient.Delete(ctx, otherReleasePlanAdmission)
+			}()
+
+			Eventually(func() error {
+				return environmentWebhook.ValidateDelete(ctx, environment)
+			}).Should(MatchError(ContainSubstring("other-release-plan-admission")))
+		})
+	})
+
+	Context("When ValidateCreate and ValidateUpdate are called", func() {
+		It("accepts the request", func() {
+			Expect(environmentWebhook.ValidateCreate(ctx, environment)).To(Succeed())
+			Expect(environmentWebhook.ValidateUpdate(ctx, environment, environment)).To(Succeed())
+		})
+	})
+})
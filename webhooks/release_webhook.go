@@ -0,0 +1,97 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+	"github.com/redhat-appstudio/release-service/loader"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ReleaseWebhook rejects Releases that would be marked as invalid by the release controller. The ReleasePlan, the
+// active ReleasePlanAdmission, its ReleaseStrategy, the Release to roll back to and the Snapshot used by the
+// Release are resolved, so users get immediate feedback when creating a Release.
+type ReleaseWebhook struct {
+	client client.Client
+	loader loader.ObjectLoader
+}
+
+// NewReleaseWebhook creates and returns a ReleaseWebhook.
+func NewReleaseWebhook(client client.Client, loader loader.ObjectLoader) *ReleaseWebhook {
+	return &ReleaseWebhook{
+		client: client,
+		loader: loader,
+	}
+}
+
+var _ admission.CustomValidator = &ReleaseWebhook{}
+
+// ValidateCreate implements admission.CustomValidator. The Release will be rejected if any of the resources needed to
+// process it can't be resolved or if releases to the target are disabled. The reasons used in the error messages match
+// the ones the release controller would set in the Release status. As this validator is called by the v1alpha1 Release
+// webhook, the Release is converted to the version used by the loader before resolving its resources.
+func (w *ReleaseWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	release := &v1alpha2.Release{}
+	if err := obj.(*v1alpha1.Release).ConvertTo(release); err != nil {
+		return err
+	}
+
+	releasePlanAdmission, err := w.loader.GetActiveReleasePlanAdmissionFromRelease(ctx, w.client, release)
+	if err != nil {
+		return newReleaseValidationError(loader.GetActiveReleasePlanAdmissionErrorReason(err), err)
+	}
+
+	_, err = w.loader.GetReleaseStrategy(ctx, w.client, releasePlanAdmission)
+	if err != nil {
+		return newReleaseValidationError(v1alpha2.ReleaseReasonValidationError, err)
+	}
+
+	if release.Spec.RollbackTo != "" {
+		rollbackTarget, err := w.loader.GetRelease(ctx, w.client, release.Spec.RollbackTo, release.Namespace)
+		if err == nil {
+			err = release.ValidateRollbackTarget(rollbackTarget)
+		}
+		if err != nil {
+			return newReleaseValidationError(v1alpha2.ReleaseReasonRollbackValidationError, err)
+		}
+
+		// Rollbacks release the Snapshot of the Release they roll back to
+		release.Status.Snapshot = rollbackTarget.GetSnapshotName()
+	}
+
+	_, err = w.loader.GetSnapshot(ctx, w.client, release)
+	if err != nil {
+		return newReleaseValidationError(v1alpha2.ReleaseReasonValidationError, err)
+	}
+
+	return nil
+}
+
+// ValidateUpdate implements admission.CustomValidator. Releases are not validated on update as their spec can't change.
+func (w *ReleaseWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return nil
+}
+
+// ValidateDelete implements admission.CustomValidator. Releases are not validated on deletion.
+func (w *ReleaseWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+	"github.com/redhat-appstudio/release-service/loader"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("Release webhook", func() {
+	var (
+		release              *v1alpha1.Release
+		releasePlanAdmission *v1alpha1.ReleasePlanAdmission
+		releaseWebhook       *ReleaseWebhook
+	)
+
+	BeforeEach(func() {
+		releaseWebhook = NewReleaseWebhook(k8sClient, loader.NewMockLoader())
+
+		release = &v1alpha1.Release{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "release",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.ReleaseSpec{
+				Snapshot:    "snapshot",
+				ReleasePlan: "release-plan",
+			},
+		}
+
+		releasePlanAdmission = &v1alpha1.ReleasePlanAdmission{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "release-plan-admission",
+				Namespace: "default",
+			},
+		}
+	})
+
+	Context("When ValidateCreate is called", func() {
+		It("rejects the Release if the ReleasePlan can't be found", func() {
+			err := errors.NewNotFound(schema.GroupResource{Resource: "releaseplans"}, "release-plan")
+			ctx := loader.GetMockedContext(ctx, []loader.MockData{
+				{ContextKey: loader.ReleasePlanAdmissionContextKey, Err: err},
+			})
+
+			Expect(releaseWebhook.ValidateCreate(ctx, release)).To(MatchError(
+				fmt.Sprintf("%s: %s", v1alpha2.ReleaseReasonReleasePlanValidationError, err.Error())))
+		})
+
+		It("rejects the Release if the target is disabled", func() {
+			ctx := loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Err:        fmt.Errorf("found ReleasePlanAdmission 'foo' with %w", loader.ErrReleasePlanAdmissionDisabled),
+				},
+			})
+
+			Expect(releaseWebhook.ValidateCreate(ctx, release)).To(MatchError(
+				ContainSubstring(v1alpha2.ReleaseReasonTargetDisabledError.String())))
+		})
+
+		It("rejects the Release if multiple ReleasePlanAdmissions are active", func() {
+			ctx := loader.GetMockedContext(ctx, []loader.MockData{
+				{
+					ContextKey: loader.ReleasePlanAdmissionContextKey,
+					Err:        loader.ErrMultipleReleasePlanAdmissions,
+				},
+			})
+
+			Expect(releaseWebhook.ValidateCreate(ctx, release)).To(MatchError(
+				ContainSubstring(v1alpha2.ReleaseReasonValidationError.String())))
+		})
+
+		It("rejects the Release if the ReleaseStrategy can't be found", func() {
+			ctx := loader.GetMockedContext(ctx, []loader.MockData{
+				{ContextKey: loader.ReleasePlanAdmissionContextKey, Resource: releasePlanAdmission},
+				{ContextKey: loader.ReleaseStrategyContextKey, Err: fmt.Errorf("strategy not found")},
+			})
+
+			Expect(releaseWebhook.ValidateCreate(ctx, release)).To(MatchError(
+				fmt.Sprintf("%s: strategy not found", v1alpha2.ReleaseReasonValidationError)))
+		})
+
+		It("rejects the Release if the Snapshot can't be found", func() {
+			ctx := loader.GetMockedContext(ctx, []loader.MockData{
+				{ContextKey: loader.ReleasePlanAdmissionContextKey, Resource: releasePlanAdmission},
+				{ContextKey: loader.ReleaseStrategyContextKey, Resource: &v1alpha1.ReleaseStrategy{}},
+				{ContextKey: loader.SnapshotContextKey, Err: fmt.Errorf("snapshot not found")},
+			})
+
+			Expect(releaseWebhook.ValidateCreate(ctx, release)).To(MatchError(
+				fmt.Sprintf("%s: snapshot not found", v1alpha2.ReleaseReasonValidationError)))
+		})
+
+		It("rejects a rollback to a Release that was never deployed", func() {
+			release.Spec.Snapshot = ""
+			release.Spec.RollbackTo = "previous-release"
+			ctx := loader.GetMockedContext(ctx, []loader.MockData{
+				{ContextKey: loader.ReleasePlanAdmissionContextKey, Resource: releasePlanAdmission},
+				{ContextKey: loader.ReleaseStrategyContextKey, Resource: &v1alpha1.ReleaseStrategy{}},
+				{ContextKey: loader.SnapshotContextKey, Resource: &applicationapiv1alpha1.Snapshot{}},
+				{
+					ContextKey: loader.ReleaseContextKey,
+					Resource: &v1alpha2.Release{
+						ObjectMeta: metav1.ObjectMeta{Name: "previous-release"},
+						Spec:       v1alpha2.ReleaseSpec{Snapshot: "snapshot", ReleasePlan: "release-plan"},
+					},
+				},
+			})
+
+			Expect(releaseWebhook.ValidateCreate(ctx, release)).To(MatchError(
+				fmt.Sprintf("%s: the Release previous-release to roll back to was never successfully deployed",
+					v1alpha2.ReleaseReasonRollbackValidationError)))
+		})
+
+		It("rejects a rollback if the Snapshot of the Release to roll back to can't be found", func() {
+			release.Spec.Snapshot = ""
+			release.Spec.RollbackTo = "previous-release"
+			rollbackTarget := &v1alpha2.Release{
+				ObjectMeta: metav1.ObjectMeta{Name: "previous-release"},
+				Spec:       v1alpha2.ReleaseSpec{Snapshot: "snapshot", ReleasePlan: "release-plan"},
+			}
+			rollbackTarget.MarkRunning()
+			rollbackTarget.MarkSucceeded()
+			rollbackTarget.MarkEnvironmentDeploying("production", nil)
+			rollbackTarget.MarkDeployed("CommitsSynced", "")
+			ctx := loader.GetMockedContext(ctx, []loader.MockData{
+				{ContextKey: loader.ReleasePlanAdmissionContextKey, Resource: releasePlanAdmission},
+				{ContextKey: loader.ReleaseStrategyContextKey, Resource: &v1alpha1.ReleaseStrategy{}},
+				{ContextKey: loader.ReleaseContextKey, Resource: rollbackTarget},
+				{ContextKey: loader.SnapshotContextKey, Err: fmt.Errorf("snapshot not found")},
+			})
+
+			Expect(releaseWebhook.ValidateCreate(ctx, release)).To(MatchError(
+				fmt.Sprintf("%s: snapshot not found", v1alpha2.ReleaseReasonValidationError)))
+		})
+
+		It("accepts the Release if all the resources can be resolved", func() {
+			ctx := loader.GetMockedContext(ctx, []loader.MockData{
+				{ContextKey: loader.ReleasePlanAdmissionContextKey, Resource: releasePlanAdmission},
+				{ContextKey: loader.ReleaseStrategyContextKey, Resource: &v1alpha1.ReleaseStrategy{}},
+				{ContextKey: loader.SnapshotContextKey, Resource: &applicationapiv1alpha1.Snapshot{}},
+			})
+
+			Expect(releaseWebhook.ValidateCreate(ctx, release)).To(Succeed())
+		})
+	})
+
+	Context("When ValidateUpdate and ValidateDelete are called", func() {
+		It("accepts the request", func() {
+			Expect(releaseWebhook.ValidateUpdate(ctx, release, release)).To(Succeed())
+			Expect(releaseWebhook.ValidateDelete(ctx, release)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,104 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SnapshotWebhook protects Snapshots used by in-flight Releases from deletion.
+type SnapshotWebhook struct {
+	client client.Reader
+}
+
+// NewSnapshotWebhook creates and returns a SnapshotWebhook.
+func NewSnapshotWebhook(client client.Reader) *SnapshotWebhook {
+	return &SnapshotWebhook{
+		client: client,
+	}
+}
+
+// SetupSnapshotWebhook creates a new SnapshotWebhook and registers it in the Manager.
+func SetupSnapshotWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&applicationapiv1alpha1.Snapshot{}).
+		WithValidator(NewSnapshotWebhook(mgr.GetClient())).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-appstudio-redhat-com-v1alpha1-snapshot,mutating=false,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=snapshots,verbs=delete,versions=v1alpha1,name=vsnapshot.kb.io,admissionReviewVersions=v1
+
+var _ admission.CustomValidator = &SnapshotWebhook{}
+
+// ValidateCreate implements admission.CustomValidator. Snapshots are not validated on creation.
+func (w *SnapshotWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// ValidateUpdate implements admission.CustomValidator. Snapshots are not validated on update.
+func (w *SnapshotWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return nil
+}
+
+// ValidateDelete implements admission.CustomValidator. The deletion will be rejected if any Release in the same
+// namespace referencing the Snapshot is still in progress. Only the namespace of the Snapshot is checked, as Releases
+// can only reference Snapshots in their own namespace. The release PipelineRuns and deployments running in the target
+// namespace are not checked separately, as the Releases using the Snapshot stay in progress until they finish.
+func (w *SnapshotWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	snapshot := obj.(*applicationapiv1alpha1.Snapshot)
+
+	releases := &v1alpha2.ReleaseList{}
+	err := w.client.List(ctx, releases, client.InNamespace(snapshot.Namespace))
+	if err != nil {
+		return err
+	}
+
+	var inFlightReleases []string
+	for i, release := range releases.Items {
+		if release.GetSnapshotName() == snapshot.Name && isInFlight(&releases.Items[i]) {
+			inFlightReleases = append(inFlightReleases, release.Name)
+		}
+	}
+
+	if len(inFlightReleases) > 0 {
+		return fmt.Errorf("Snapshot '%s' cannot be deleted as it's used by the in-flight Releases: %s",
+			snapshot.Name, strings.Join(inFlightReleases, ", "))
+	}
+
+	return nil
+}
+
+// isInFlight returns a boolean indicating whether the given Release is still being processed, either because its
+// release PipelineRun hasn't finished yet or because its deployment is still in progress. A failed deployment is
+// terminal, so it doesn't keep the Release in flight.
+func isInFlight(release *v1alpha2.Release) bool {
+	if release.DeletionTimestamp != nil {
+		return false
+	}
+
+	return !release.IsDone() ||
+		(release.HasSucceeded() && release.IsDeploying() && !release.IsDeployed() && !release.HasDeploymentFailed())
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	applicationapiv1alpha1 "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Snapshot webhook", Ordered, func() {
+	var (
+		release         *v1alpha2.Release
+		snapshot        *applicationapiv1alpha1.Snapshot
+		snapshotWebhook *SnapshotWebhook
+	)
+
+	BeforeAll(func() {
+		snapshotWebhook = NewSnapshotWebhook(k8sClient)
+
+		snapshot = &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "snapshot",
+				Namespace: "default",
+			},
+		}
+
+		release = &v1alpha2.Release{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "release",
+				Namespace: "default",
+			},
+			Spec: v1alpha2.ReleaseSpec{
+				Snapshot:    snapshot.Name,
+				ReleasePlan: "release-plan",
+			},
+		}
+		Expect(k8sClient.Create(ctx, release)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		_ = k8sClient.Delete(ctx, release)
+	})
+
+	Context("When ValidateDelete is called", func() {
+		It("rejects the deletion if an in-flight Release uses the Snapshot", func() {
+			Eventually(func() error {
+				return snapshotWebhook.ValidateDelete(ctx, snapshot)
+			}).Should(MatchError("Snapshot 'snapshot' cannot be deleted as it's used by the in-flight Releases: release"))
+		})
+
+		It("accepts the deletion if the Releases using the Snapshot are done", func() {
+			release.MarkRunning()
+			release.MarkFailed(v1alpha2.ReleaseReasonPipelineFailed, "failed")
+			Expect(k8sClient.Status().Update(ctx, release)).To(Succeed())
+
+			Eventually(func() error {
+				return snapshotWebhook.ValidateDelete(ctx, snapshot)
+			}).Should(Succeed())
+		})
+	})
+
+	Context("When isInFlight is called", func() {
+		var deployingRelease *v1alpha2.Release
+
+		BeforeEach(func() {
+			deployingRelease = &v1alpha2.Release{}
+			deployingRelease.MarkRunning()
+			deployingRelease.MarkSucceeded()
+			deployingRelease.MarkDeploying(metav1.ConditionFalse, v1alpha2.ReleaseReasonDeploymentPending.String(), "")
+		})
+
+		It("returns true if the Release is still deploying", func() {
+			Expect(isInFlight(deployingRelease)).To(BeTrue())
+		})
+
+		It("returns false if the deployment of the Release failed", func() {
+			deployingRelease.MarkDeploymentFailed(v1alpha2.ReleaseReasonDeploymentFailed, "failed")
+			Expect(isInFlight(deployingRelease)).To(BeFalse())
+		})
+	})
+
+	Context("When ValidateCreate and ValidateUpdate are called", func() {
+		It("accepts the request", func() {
+			Expect(snapshotWebhook.ValidateCreate(ctx, snapshot)).To(Succeed())
+			Expect(snapshotWebhook.ValidateUpdate(ctx, snapshot, snapshot)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,29 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"fmt"
+
+	"github.com/redhat-appstudio/release-service/api/v1alpha2"
+)
+
+// newReleaseValidationError returns an error prefixed with the given reason, so it matches the reason the release
+// controller would set in the Release status.
+func newReleaseValidationError(reason v1alpha2.ReleaseReason, err error) error {
+	return fmt.Errorf("%s: %w", reason, err)
+}